@@ -14,9 +14,10 @@
 package main
 
 import (
-	"log"
 	"os"
+	"strings"
 
+	"github.com/akamai/cli-dns/edgegrid"
 	"github.com/urfave/cli"
 )
 
@@ -26,6 +27,21 @@ var (
 
 func main() {
 	setHelpTemplates()
+	app := newApp()
+
+	// Errors that reach here were not returned by a command, such as flag parse errors
+	if err := app.Run(os.Args); err != nil {
+		ce := wrapError(err)
+		if ce.Code == exitError {
+			ce.Code, ce.Kind = exitValidation, exitKind(exitValidation)
+		}
+		writeCommandError(os.Stderr, ce)
+		os.Exit(ce.Code)
+	}
+}
+
+// The CLI application with its global flags and commands
+func newApp() *cli.App {
 	app := cli.NewApp()
 	app.Name = "akamai-dns"
 	app.Usage = "CLI DNS"
@@ -47,11 +63,70 @@ func main() {
 			Usage:  "Account switch key",
 			EnvVar: "AKAMAI_EDGERC_ACCOUNT_KEY",
 		},
+		cli.StringFlag{
+			Name:   "profile",
+			Usage:  "Use the account settings of profile `NAME` in ~/.akamai-dns.yaml",
+			EnvVar: "AKAMAI_DNS_PROFILE",
+		},
+		cli.StringFlag{
+			Name:   "endpoint",
+			Usage:  "Send unsigned API requests to `URL`, such as a local mock-server, instead of the .edgerc host",
+			EnvVar: "AKAMAI_DNS_ENDPOINT",
+		},
+		cli.StringFlag{
+			Name:   "record",
+			Usage:  "Save every API request and response to `DIR`, with credentials and TSIG secrets redacted",
+			EnvVar: "AKAMAI_DNS_RECORD",
+		},
+		cli.StringFlag{
+			Name:   "replay",
+			Usage:  "Answer API requests from the interactions saved in `DIR` instead of the network",
+			EnvVar: "AKAMAI_DNS_REPLAY",
+		},
+		cli.BoolFlag{
+			Name:   "no-cache",
+			Usage:  "Always retrieve zone details from the API instead of the zone cache",
+			EnvVar: "AKAMAI_DNS_NO_CACHE",
+		},
+		cli.DurationFlag{
+			Name:   "cache-ttl",
+			Value:  defaultZoneCacheTTL,
+			Usage:  "Use cached zone details for up to `DURATION`; 0 turns the cache off",
+			EnvVar: "AKAMAI_DNS_CACHE_TTL",
+		},
+		cli.BoolFlag{
+			Name:   "show-secrets",
+			Usage:  "Show TSIG secrets in table and tsig command output instead of masking them",
+			EnvVar: "AKAMAI_DNS_SHOW_SECRETS",
+		},
+		cli.StringFlag{
+			Name:   "error-format",
+			Value:  errorFormatText,
+			Usage:  "Write errors to STDERR as `FORMAT`: text or json",
+			EnvVar: "AKAMAI_DNS_ERROR_FORMAT",
+		},
 	}
 
-	app.Commands = GetCommands()
-
-	if err := app.Run(os.Args); err != nil {
-		log.Fatal(err)
+	app.Before = func(c *cli.Context) error {
+		format := strings.ToLower(c.String("error-format"))
+		if format != errorFormatText && format != errorFormatJSON {
+			return newCommandError(exitValidation, "error-format must be one of text or json")
+		}
+		errorFormat = format
+		if err := edgegrid.ValidateCassetteFlags(c); err != nil {
+			return newCommandError(exitValidation, "%v", err)
+		}
+		// The profile commands read the config themselves, so a broken profile can be fixed
+		if c.Args().First() == "profile" {
+			return nil
+		}
+		if err := applyProfile(c); err != nil {
+			return err
+		}
+		return initZoneCache(c)
 	}
+	app.ExitErrHandler = handleCommandError
+
+	app.Commands = GetCommands()
+	return app
 }
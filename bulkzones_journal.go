@@ -0,0 +1,246 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/fatih/color"
+)
+
+// BulkZonesJournal records a bulk zone submission batch by batch so that an
+// interrupted submission can be resumed and failed zones retried
+type BulkZonesJournal struct {
+	Op                 string            `json:"op"`
+	ContractID         string            `json:"contractId,omitempty"`
+	GroupID            string            `json:"groupId,omitempty"`
+	BypassSafetyChecks bool              `json:"bypassZoneSafety,omitempty"`
+	RetryOf            string            `json:"retryOf,omitempty"`
+	Created            string            `json:"created"`
+	Batches            []*BulkZonesBatch `json:"batches"`
+
+	path string
+}
+
+// BulkZonesBatch is one bulk request. RequestID is empty until the batch is submitted.
+type BulkZonesBatch struct {
+	Zones          []dns.ZoneCreate `json:"zones,omitempty"`
+	ZoneNames      []string         `json:"zoneNames,omitempty"`
+	RequestID      string           `json:"requestId,omitempty"`
+	ExpirationDate string           `json:"expirationDate,omitempty"`
+	Submitted      string           `json:"submitted,omitempty"`
+}
+
+// Build a create journal with the zones split into batches of at most batchSize
+func newBulkCreateJournal(path string, zones []dns.ZoneCreate, batchSize int, contractid, groupid string) *BulkZonesJournal {
+	j := &BulkZonesJournal{
+		Op:         "create",
+		ContractID: contractid,
+		GroupID:    groupid,
+		Created:    time.Now().UTC().Format(time.RFC3339),
+		Batches:    []*BulkZonesBatch{},
+		path:       path,
+	}
+	if batchSize < 1 {
+		batchSize = len(zones)
+	}
+	for start := 0; start < len(zones); start += batchSize {
+		end := start + batchSize
+		if end > len(zones) {
+			end = len(zones)
+		}
+		j.Batches = append(j.Batches, &BulkZonesBatch{Zones: append([]dns.ZoneCreate(nil), zones[start:end]...)})
+	}
+	return j
+}
+
+// Build a delete journal. Deletes are submitted as a single request.
+func newBulkDeleteJournal(path string, zones []string, bypass bool) *BulkZonesJournal {
+	return &BulkZonesJournal{
+		Op:                 "delete",
+		BypassSafetyChecks: bypass,
+		Created:            time.Now().UTC().Format(time.RFC3339),
+		Batches:            []*BulkZonesBatch{{ZoneNames: zones}},
+		path:               path,
+	}
+}
+
+func loadBulkZonesJournal(path string) (*BulkZonesJournal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal %s: %w", path, err)
+	}
+	j := &BulkZonesJournal{}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("failed to parse journal %s: %w", path, err)
+	}
+	if j.Op != "create" && j.Op != "delete" {
+		return nil, newCommandError(exitValidation, "journal %s has unknown operation %q", path, j.Op)
+	}
+	j.path = path
+	return j, nil
+}
+
+// Write the journal atomically so a crash never leaves it truncated
+func (j *BulkZonesJournal) save() error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal: %w", err)
+	}
+	if err := writeFileAtomic(j.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write journal %s: %w", j.path, err)
+	}
+	return nil
+}
+
+// Request ids of the submitted batches
+func (j *BulkZonesJournal) requestIDs() []string {
+	ids := []string{}
+	for _, b := range j.Batches {
+		if b.RequestID != "" {
+			ids = append(ids, b.RequestID)
+		}
+	}
+	return ids
+}
+
+// Submit every batch that has no request id yet, saving the journal after each one.
+// beforeDelete is called with the zones of each delete batch before it is submitted.
+func submitBulkZonesJournal(ctx context.Context, dnsClient dns.DNS, j *BulkZonesJournal, beforeDelete func(zones ...string) error) error {
+	for i, b := range j.Batches {
+		if b.RequestID != "" {
+			continue
+		}
+		if j.Op == "create" {
+			resp, err := dnsClient.CreateBulkZones(ctx, dns.CreateBulkZonesRequest{
+				BulkZones:       &dns.BulkZonesCreate{Zones: b.Zones},
+				ZoneQueryString: dns.ZoneQueryString{Contract: j.ContractID, Group: j.GroupID},
+			})
+			if err != nil {
+				return fmt.Errorf("bulk zone submit request failed for batch %d of %d: %w", i+1, len(j.Batches), err)
+			}
+			b.RequestID, b.ExpirationDate = resp.RequestID, resp.ExpirationDate
+		} else {
+			if err := beforeDelete(b.ZoneNames...); err != nil {
+				return err
+			}
+			bypass := j.BypassSafetyChecks
+			zoneCache.invalidate(b.ZoneNames...)
+			resp, err := dnsClient.DeleteBulkZones(ctx, dns.DeleteBulkZonesRequest{
+				ZonesList:          &dns.ZoneNameListResponse{Zones: b.ZoneNames},
+				BypassSafetyChecks: &bypass,
+			})
+			if err != nil {
+				return fmt.Errorf("Bulk Zone Request submit failed. Error: %w", err)
+			}
+			b.RequestID, b.ExpirationDate = resp.RequestID, resp.ExpirationDate
+		}
+		b.Submitted = time.Now().UTC().Format(time.RFC3339)
+		if err := j.save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Submitted batch %d of %d: request id %s\n", i+1, len(j.Batches), b.RequestID)
+	}
+	return nil
+}
+
+// Build a new journal from the zones that failed in a completed submission,
+// leaving out zones that succeeded in any of its batches
+func bulkRetryJournal(ctx context.Context, dnsClient dns.DNS, prev *BulkZonesJournal, path string, batchSize int) (*BulkZonesJournal, error) {
+	failed := map[string]string{}
+	succeeded := map[string]bool{}
+	for i, b := range prev.Batches {
+		if b.RequestID == "" {
+			return nil, fmt.Errorf("batch %d of %d in %s was never submitted; use --resume first", i+1, len(prev.Batches), prev.path)
+		}
+		st, err := bulkZoneStatus(ctx, dnsClient, prev.Op, b.RequestID)
+		if err != nil {
+			return nil, err
+		}
+		if !st.IsComplete {
+			return nil, fmt.Errorf("request %s is not complete yet", b.RequestID)
+		}
+
+		var ok []string
+		var failedZones []dns.BulkFailedZone
+		if prev.Op == "create" {
+			r, err := dnsClient.GetBulkZoneCreateResult(ctx, dns.GetBulkZoneCreateResultRequest{RequestID: b.RequestID})
+			if err != nil {
+				return nil, fmt.Errorf("bulk zone create error: %w", err)
+			}
+			ok, failedZones = r.SuccessfullyCreatedZones, r.FailedZones
+		} else {
+			r, err := dnsClient.GetBulkZoneDeleteResult(ctx, dns.GetBulkZoneDeleteResultRequest{RequestID: b.RequestID})
+			if err != nil {
+				return nil, fmt.Errorf("bulk zone delete error: %w", err)
+			}
+			ok, failedZones = r.SuccessfullyDeletedZones, r.FailedZones
+		}
+		for _, z := range ok {
+			succeeded[bulkZoneKey(z)] = true
+		}
+		for _, f := range failedZones {
+			failed[bulkZoneKey(f.Zone)] = f.FailureReason
+		}
+	}
+	for z := range succeeded {
+		delete(failed, z)
+	}
+
+	names := make([]string, 0, len(failed))
+	for z := range failed {
+		names = append(names, z)
+	}
+	sort.Strings(names)
+	for _, z := range names {
+		fmt.Fprintln(os.Stderr, color.YellowString("Retrying %s: %s", z, failed[z]))
+	}
+
+	var j *BulkZonesJournal
+	if prev.Op == "create" {
+		zones := []dns.ZoneCreate{}
+		for _, b := range prev.Batches {
+			for _, z := range b.Zones {
+				if _, ok := failed[bulkZoneKey(z.Zone)]; ok {
+					zones = append(zones, z)
+				}
+			}
+		}
+		j = newBulkCreateJournal(path, zones, batchSize, prev.ContractID, prev.GroupID)
+	} else {
+		zones := []string{}
+		for _, b := range prev.Batches {
+			for _, z := range b.ZoneNames {
+				if _, ok := failed[bulkZoneKey(z)]; ok {
+					zones = append(zones, z)
+				}
+			}
+		}
+		j = newBulkDeleteJournal(path, zones, prev.BypassSafetyChecks)
+	}
+	j.RetryOf = prev.path
+	return j, nil
+}
+
+func bulkZoneKey(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(zone, "."))
+}
@@ -0,0 +1,157 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// Longest pause between bulk status polls
+const bulkWaitMaxInterval = 60 * time.Second
+
+// Query the status of a bulk create or delete request
+func bulkZoneStatus(ctx context.Context, dnsClient dns.DNS, op, requestid string) (*dns.BulkStatusResponse, error) {
+	if op == "create" {
+		r, err := dnsClient.GetBulkZoneCreateStatus(ctx, dns.GetBulkZoneCreateStatusRequest{RequestID: requestid})
+		if err != nil {
+			return nil, fmt.Errorf("Bulk Zone Create Status query failed: %w", err)
+		}
+		return &dns.BulkStatusResponse{
+			RequestID:      r.RequestID,
+			ZonesSubmitted: r.ZonesSubmitted,
+			SuccessCount:   r.SuccessCount,
+			FailureCount:   r.FailureCount,
+			IsComplete:     r.IsComplete,
+			ExpirationDate: r.ExpirationDate,
+		}, nil
+	}
+
+	r, err := dnsClient.GetBulkZoneDeleteStatus(ctx, dns.GetBulkZoneDeleteStatusRequest{RequestID: requestid})
+	if err != nil {
+		return nil, fmt.Errorf("Bulk Zone Delete Status query failed: %w", err)
+	}
+	return &dns.BulkStatusResponse{
+		RequestID:      r.RequestID,
+		ZonesSubmitted: r.ZonesSubmitted,
+		SuccessCount:   r.SuccessCount,
+		FailureCount:   r.FailureCount,
+		IsComplete:     r.IsComplete,
+		ExpirationDate: r.ExpirationDate,
+	}, nil
+}
+
+// Retrieve the results of bulk create or delete requests
+func bulkZoneResults(ctx context.Context, dnsClient dns.DNS, op string, requestids []string, c *cli.Context) (*CommandOutput, error) {
+	var resultList interface{}
+	if op == "create" {
+		list := make([]*dns.GetBulkZoneCreateResultResponse, 0, len(requestids))
+		for _, requestid := range requestids {
+			resp, err := dnsClient.GetBulkZoneCreateResult(ctx, dns.GetBulkZoneCreateResultRequest{RequestID: requestid})
+			if err != nil {
+				return nil, fmt.Errorf("bulk zone create error: %w", err)
+			}
+			list = append(list, resp)
+		}
+		resultList = list
+	} else {
+		list := make([]*dns.GetBulkZoneDeleteResultResponse, 0, len(requestids))
+		for _, requestid := range requestids {
+			resp, err := dnsClient.GetBulkZoneDeleteResult(ctx, dns.GetBulkZoneDeleteResultRequest{RequestID: requestid})
+			if err != nil {
+				return nil, fmt.Errorf("bulk zone delete error: %w", err)
+			}
+			list = append(list, resp)
+		}
+		resultList = list
+	}
+
+	return &CommandOutput{
+		Value: resultList,
+		Table: func() string { return renderBulkZonesResultTable(resultList, c) },
+	}, nil
+}
+
+// Poll the status of all requests with exponential backoff until each is
+// complete, then return the results and the total failed and submitted zone counts
+func waitBulkZones(ctx context.Context, dnsClient dns.DNS, op string, requestids []string, c *cli.Context) (*CommandOutput, int, int, error) {
+	interval := time.Duration(c.Int("poll-interval")) * time.Second
+	if interval <= 0 {
+		interval = time.Second
+	}
+	var deadline time.Time
+	if c.Int("wait-timeout") > 0 {
+		deadline = time.Now().Add(time.Duration(c.Int("wait-timeout")) * time.Second)
+	}
+
+	statuses := make(map[string]*dns.BulkStatusResponse, len(requestids))
+	for {
+		complete, submitted, processed, failures := 0, 0, 0, 0
+		for _, requestid := range requestids {
+			// Completed requests do not change, skip querying them again
+			if st, ok := statuses[requestid]; !ok || !st.IsComplete {
+				st, err := bulkZoneStatus(ctx, dnsClient, op, requestid)
+				if err != nil {
+					return nil, 0, 0, err
+				}
+				statuses[requestid] = st
+			}
+			st := statuses[requestid]
+			if st.IsComplete {
+				complete++
+			}
+			submitted += st.ZonesSubmitted
+			processed += st.SuccessCount + st.FailureCount
+			failures += st.FailureCount
+		}
+		fmt.Fprintf(os.Stderr, "Waiting for bulk zone %s: %d/%d request(s) complete, %d/%d zone(s) processed, %d failed\n",
+			op, complete, len(requestids), processed, submitted, failures)
+
+		if complete == len(requestids) {
+			fmt.Fprintln(os.Stderr, color.BlueString("Retrieving bulk zone %s results...", op))
+			results, err := bulkZoneResults(ctx, dnsClient, op, requestids, c)
+			return results, failures, submitted, err
+		}
+		// The last sleep is cut short so the status is polled once more at the deadline
+		sleep := interval
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return nil, failures, submitted, fmt.Errorf("Timed out waiting for bulk zone %s request(s) to complete", op)
+			}
+			if remaining < sleep {
+				sleep = remaining
+			}
+		}
+		time.Sleep(sleep)
+		if interval *= 2; interval > bulkWaitMaxInterval {
+			interval = bulkWaitMaxInterval
+		}
+	}
+}
+
+// Map the bulk failure count to the command exit status
+func bulkWaitExitError(op string, failures, submitted int) error {
+	if failures > 0 {
+		return newCommandError(partialExitCode(failures, submitted), "%d zone(s) failed bulk %s", failures, op)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+)
+
+// Regression tests that replay the API interactions recorded in testdata/cassettes.
+// Re-record a cassette against mock-server with --record when a command is meant
+// to send different requests.
+
+// Copy a recorded cassette so each run replays it from the start
+func cassetteDir(t *testing.T, name string) string {
+	t.Helper()
+	src := filepath.Join("testdata", "cassettes", name)
+	files, err := filepath.Glob(filepath.Join(src, "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no cassette in %s", src)
+	}
+	dir := t.TempDir()
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(f)), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// Replay a command and fail when it sends a request the cassette does not hold
+func replayCLI(t *testing.T, cassette string, args ...string) cliResult {
+	t.Helper()
+	isolateCLI(t)
+	res := mustRun(t, "", exitOK, append([]string{"--no-cache", "--replay", cassetteDir(t, cassette)}, args...)...)
+	if strings.Contains(res.stderr, "differs from the recording") {
+		t.Errorf("%s sent a request that differs from the recording:\n%s", args[0], res.stderr)
+	}
+	return res
+}
+
+func TestUpdateZoneCassette(t *testing.T) {
+	res := replayCLI(t, "update-zone", "update-zone", "example.com", "--file", filepath.Join("testdata", "update-zone.json"), "--json")
+
+	var out ZoneExport
+	decodeOutput(t, res, &out)
+	sets := map[string]dns.RecordSet{}
+	for _, rs := range out.RecordSets {
+		sets[recordSetKey(rs.Name, rs.Type)] = rs
+	}
+	if www := sets[recordSetKey("www.example.com", "A")]; len(www.Rdata) != 2 {
+		t.Errorf("www.example.com A is %+v, want two addresses", www)
+	}
+	if _, ok := sets[recordSetKey("mail.example.com", "MX")]; !ok {
+		t.Error("update-zone did not add mail.example.com MX")
+	}
+	if soa := sets[recordSetKey("example.com", "SOA")]; len(soa.Rdata) != 1 || strings.Fields(soa.Rdata[0])[2] != "2" {
+		t.Errorf("SOA serial was not incremented: %+v", soa)
+	}
+}
+
+func TestSubmitBulkZonesCassette(t *testing.T) {
+	res := replayCLI(t, "submit-bulkzones", "submit-bulkzones", "--create", "--contractid", "C-1", "--groupid", "1",
+		"--file", filepath.Join("testdata", "bulk-create.json"), "--journal", filepath.Join(t.TempDir(), "journal.json"),
+		"--wait", "--poll-interval", "1", "--json")
+
+	var results []dns.BulkCreateResultResponse
+	decodeOutput(t, res, &results)
+	if len(results) != 1 || len(results[0].SuccessfullyCreatedZones) != 2 || len(results[0].FailedZones) != 0 {
+		t.Errorf("submit-bulkzones --wait returned %+v", results)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/session"
+)
+
+// Change list recordset operations
+const (
+	changeListOpAdd    = "ADD"
+	changeListOpEdit   = "EDIT"
+	changeListOpDelete = "DELETE"
+)
+
+// ChangeListChange is a single recordset change staged in a change list
+type ChangeListChange struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	Op    string   `json:"op"`
+	TTL   int      `json:"ttl,omitempty"`
+	Rdata []string `json:"rdata,omitempty"`
+}
+
+// The edgegrid dns package only covers creating and submitting change lists, the
+// remaining change list endpoints are called through the session directly.
+
+// Retrieve all recordsets staged in a zone's change list
+func getChangeListRecordSets(ctx context.Context, sess session.Session, zone string) ([]dns.RecordSet, error) {
+	getURL := fmt.Sprintf("/config-dns/v2/changelists/%s/recordsets?showAll=true", url.PathEscape(zone))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create change list recordsets request: %w", err)
+	}
+
+	var result dns.GetRecordSetsResponse
+	resp, err := sess.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("change list recordsets request failed: %w", err)
+	}
+	defer session.CloseResponseBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+	return result.RecordSets, nil
+}
+
+// Stage a recordset change in a zone's change list
+func addChangeListChange(ctx context.Context, sess session.Session, zone string, change ChangeListChange) error {
+	postURL := fmt.Sprintf("/config-dns/v2/changelists/%s/recordsets/add-change", url.PathEscape(zone))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create change list change request: %w", err)
+	}
+
+	resp, err := sess.Exec(req, nil, change)
+	if err != nil {
+		return fmt.Errorf("change list change request failed: %w", err)
+	}
+	defer session.CloseResponseBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// Delete a zone's change list without submitting it
+func discardChangeList(ctx context.Context, sess session.Session, zone string) error {
+	delURL := fmt.Sprintf("/config-dns/v2/changelists/%s", url.PathEscape(zone))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, delURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create change list delete request: %w", err)
+	}
+
+	resp, err := sess.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("change list delete request failed: %w", err)
+	}
+	defer session.CloseResponseBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// Decode an API problem response into the same error type the dns package returns
+func responseError(resp *http.Response) error {
+	e := &dns.Error{}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || json.Unmarshal(body, e) != nil {
+		e.Title = "Failed to read error body"
+		e.Detail = string(body)
+	}
+	e.StatusCode = resp.StatusCode
+	return e
+}
@@ -15,6 +15,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/urfave/cli"
 )
 
@@ -22,26 +24,64 @@ func GetCommands() []cli.Command {
 	var commands []cli.Command
 
 	// V11 Recordsets
-	baseV11BaseFlags := []cli.Flag{
-		cli.BoolFlag{
-			Name:   "json",
-			Usage:  "Output as JSON",
-			EnvVar: "AKAMAI_CLI_DNS_" + "JSON",
-		},
-		cli.StringFlag{
-			Name:  "output",
-			Usage: "Output command results to FILE",
-		},
+	minTTLFlag := cli.IntFlag{
+		Name:   "min-ttl",
+		Value:  lintDefaultMinTTL,
+		Usage:  "Lint policy minimum recordset `TTL`",
+		EnvVar: "AKAMAI_CLI_DNS_" + "MIN_TTL",
 	}
 
-	baseV11CmdFlags := append(baseV11BaseFlags,
-		cli.BoolFlag{
-			Name:   "suppress",
-			Usage:  "Suppress command result output",
-			EnvVar: "AKAMAI_CLI_DNS_" + "SUPPRESS",
-		})
+	noLintFlag := cli.BoolFlag{
+		Name:  "no-lint",
+		Usage: "Skip the recordset lint checks before updating the zone",
+	}
+
+	snapshotFlag := cli.BoolFlag{
+		Name:   "snapshot",
+		Usage:  "Write a snapshot of the zone configuration and recordsets before changing the zone",
+		EnvVar: "AKAMAI_CLI_DNS_" + "SNAPSHOT",
+	}
+
+	snapshotDirFlag := cli.StringFlag{
+		Name:   "snapshot-dir",
+		Usage:  "Snapshot `DIRECTORY` (default: ~/.akamai-dns/snapshots)",
+		EnvVar: "AKAMAI_CLI_DNS_" + "SNAPSHOT_DIR",
+	}
+
+	zonesFileFlag := cli.StringFlag{
+		Name:  "zones-file",
+		Usage: "Also run against the zones listed one per line in `FILE`",
+	}
+
+	zoneSearchFlag := cli.StringFlag{
+		Name:  "zone-search",
+		Usage: "Also run against the zones matching `SEARCH`",
+	}
+
+	parallelFlag := cli.IntFlag{
+		Name:  "parallel",
+		Value: defaultZoneParallel,
+		Usage: "Number of zones to process at once",
+	}
+
+	bulkWaitFlag := cli.BoolFlag{
+		Name:  "wait",
+		Usage: "Poll until the bulk request(s) complete and print the results",
+	}
+
+	bulkPollIntervalFlag := cli.IntFlag{
+		Name:  "poll-interval",
+		Value: 5,
+		Usage: "Initial `SECONDS` between status polls, doubled up to 60 seconds",
+	}
+
+	bulkWaitTimeoutFlag := cli.IntFlag{
+		Name:  "wait-timeout",
+		Value: 3600,
+		Usage: "Give up waiting after `SECONDS`. 0 waits indefinitely",
+	}
 
-	baseSetCmdFlags := append(baseV11CmdFlags,
+	baseSetCmdFlags := []cli.Flag{
 		cli.StringFlag{
 			Name:  "name",
 			Usage: "Recordset NAME",
@@ -50,23 +90,22 @@ func GetCommands() []cli.Command {
 			Name:  "type",
 			Usage: "Recordset TYPE (can be ignored for add-record command)",
 		},
-	)
+	}
 
 	commands = append(commands, cli.Command{
 		Name:        "retrieve-zone",
 		Description: "Retrieve a zone's configuration and records",
-		ArgsUsage:   "<zonename>",
+		ArgsUsage:   "<zonename> [zonename...]",
 		Action:      cmdRetrieveZone,
-		Flags: []cli.Flag{
-			cli.BoolFlag{
-				Name:  "json",
-				Usage: "Output zone in JSON format",
-			},
+		Flags: append(outputFlags(formatJSONL, formatBIND),
 			cli.StringSliceFlag{
 				Name:  "filter",
 				Usage: "Filter by record type",
 			},
-		},
+			zonesFileFlag,
+			zoneSearchFlag,
+			parallelFlag,
+		),
 	})
 
 	commands = append(commands, cli.Command{
@@ -74,7 +113,8 @@ func GetCommands() []cli.Command {
 		Description: "Update a zone using either a recordsets JSON file or a DNS master zone file",
 		ArgsUsage:   "<zonename>",
 		Action:      cmdUpdateZone,
-		Flags: []cli.Flag{
+		Before:      profileHeader,
+		Flags: append(outputFlags(),
 			cli.StringFlag{
 				Name:  "file, f",
 				Usage: "Path to input file (JSON for recordsets or DNS master file)",
@@ -83,31 +123,277 @@ func GetCommands() []cli.Command {
 				Name:  "dns",
 				Usage: "Use this flag if input file is a DNS master zone file",
 			},
-			cli.StringFlag{
-				Name:  "output, o",
-				Usage: "Optional output path for updated zone details",
-			},
 			cli.BoolFlag{
 				Name:  "overwrite",
 				Usage: "Overwrite all recordsets instead of merging with existing",
 			},
 			cli.BoolFlag{
-				Name:  "json",
-				Usage: "Output zone response in JSON format",
+				Name:  "plan",
+				Usage: "Print the recordset changes that would be applied and exit without updating the zone",
+			},
+			cli.BoolFlag{
+				Name:  "merge",
+				Usage: "Parse the DNS master zone file locally and merge it with existing recordsets instead of replacing the zone",
+			},
+			noLintFlag,
+			minTTLFlag,
+			snapshotFlag,
+			snapshotDirFlag,
+		),
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "diff-zone",
+		Description: "Preview the recordset changes update-zone would apply to a zone",
+		ArgsUsage:   "<zonename>",
+		Action:      cmdDiffZone,
+		Flags: append(outputFlags("text"),
+			cli.StringFlag{
+				Name:  "file, f",
+				Usage: "Path to input file (JSON for recordsets or DNS master file)",
+			},
+			cli.BoolFlag{
+				Name:  "dns",
+				Usage: "Use this flag if input file is a DNS master zone file",
+			},
+			cli.BoolFlag{
+				Name:  "merge",
+				Usage: "Compare a DNS master zone file as merged with existing recordsets instead of replacing the zone",
+			},
+			cli.BoolFlag{
+				Name:  "overwrite",
+				Usage: "Compare as if all recordsets are overwritten instead of merged with existing",
+			},
+		),
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "lint-zone",
+		Description: "Check zone recordsets from a file or the live zone for common mistakes",
+		ArgsUsage:   "<zonename> [zonename...]",
+		Action:      cmdLintZone,
+		Flags: append(outputFlags("text", "sarif"),
+			cli.StringFlag{
+				Name:  "file, f",
+				Usage: "Lint a recordsets JSON or DNS master zone `FILE` instead of the live zone",
 			},
 			cli.BoolFlag{
-				Name:  "suppress",
-				Usage: "Suppress output to console",
+				Name:  "dns",
+				Usage: "Use this flag if input file is a DNS master zone file",
+			},
+			minTTLFlag,
+			zonesFileFlag,
+			zoneSearchFlag,
+			parallelFlag,
+		),
+	})
+
+	changeListRecordFlags := []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "Recordset `NAME`, relative names are qualified with the zone",
+		},
+		cli.StringFlag{
+			Name:  "type",
+			Usage: "Recordset `TYPE`",
+		},
+		cli.IntFlag{
+			Name:  "ttl",
+			Usage: "Recordset `TTL`",
+		},
+		cli.StringSliceFlag{
+			Name:  "rdata",
+			Usage: "Recordset `RDATA`. Multiple flags allowed",
+		},
+		cli.StringFlag{
+			Name:  "op",
+			Value: "add",
+			Usage: "Change `OP`: add, edit or delete",
+		},
+	}
+
+	commands = append(commands, cli.Command{
+		Name:        "changelist",
+		Description: "Stage, review and submit zone changes in a change list",
+		Subcommands: []cli.Command{
+			{
+				Name:        "create",
+				Description: "Create a change list from the current zone content",
+				ArgsUsage:   "<zonename>",
+				Action:      cmdChangeListCreate,
+				Before:      profileHeader,
+				Flags:       outputFlags(),
+			},
+			{
+				Name:        "show",
+				Description: "Show change list metadata and staged recordsets",
+				ArgsUsage:   "<zonename>",
+				Action:      cmdChangeListShow,
+				Flags: append(outputFlags(),
+					cli.BoolFlag{
+						Name:  "summary",
+						Usage: "Show change list metadata only",
+					},
+				),
+			},
+			{
+				Name:        "diff",
+				Description: "Show the recordset changes staged in the change list",
+				ArgsUsage:   "<zonename>",
+				Action:      cmdChangeListDiff,
+				Flags:       outputFlags("text"),
+			},
+			{
+				Name:        "add",
+				Description: "Stage a recordset add, edit or delete in the change list",
+				ArgsUsage:   "<zonename>",
+				Action:      cmdChangeListAdd,
+				Before:      profileHeader,
+				Flags:       append(outputFlags(), changeListRecordFlags...),
+			},
+			{
+				Name:        "submit",
+				Description: "Submit the change list and activate its changes",
+				ArgsUsage:   "<zonename>",
+				Action:      cmdChangeListSubmit,
+				Before:      profileHeader,
+				Flags: append(outputFlags("text"),
+					noLintFlag,
+					minTTLFlag,
+					snapshotFlag,
+					snapshotDirFlag,
+				),
+			},
+			{
+				Name:        "discard",
+				Description: "Delete the change list without submitting it",
+				ArgsUsage:   "<zonename>",
+				Action:      cmdChangeListDiscard,
+				Before:      profileHeader,
+				Flags:       outputFlags(),
+			},
+		},
+	})
+
+	tsigKeyFlags := []cli.Flag{
+		cli.StringFlag{
+			Name:  "key",
+			Usage: "TSIG key `NAME`",
+		},
+		cli.StringFlag{
+			Name:  "algorithm",
+			Usage: "TSIG key `ALGORITHM`, needed when several keys share a name",
+		},
+	}
+
+	commands = append(commands, cli.Command{
+		Name:        "tsig",
+		Description: "Manage TSIG keys used by secondary zones",
+		Subcommands: []cli.Command{
+			{
+				Name:        "list",
+				Description: "List TSIG keys and the number of zones using them",
+				Action:      cmdTSIGList,
+				Flags: append(outputFlags(),
+					cli.StringSliceFlag{
+						Name:  "contractid",
+						Usage: "Limit to keys used by zones in contract `CONTRACTID`. Multiple flags allowed",
+					},
+					cli.StringFlag{
+						Name:  "search",
+						Usage: "Filter keys by `SEARCH` string",
+					},
+				),
+			},
+			{
+				Name:        "zones",
+				Description: "List the zones that use a TSIG key",
+				Action:      cmdTSIGZones,
+				Flags:       append(outputFlags(), tsigKeyFlags...),
+			},
+			{
+				Name:        "rotate",
+				Description: "Replace a TSIG key on every zone that uses it in one operation",
+				Action:      cmdTSIGRotate,
+				Before:      profileHeader,
+				Flags: append(append(outputFlags(), tsigKeyFlags...),
+					cli.StringFlag{
+						Name:  "secret",
+						Usage: "New base64 `SECRET`",
+					},
+					cli.BoolFlag{
+						Name:  "generate",
+						Usage: "Generate a random secret and print the new key in BIND format",
+					},
+					cli.StringFlag{
+						Name:  "new-name",
+						Usage: "Rename the key to `NAME`",
+					},
+					cli.StringFlag{
+						Name:  "new-algorithm",
+						Usage: "Change the key algorithm to `ALGORITHM`",
+					},
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "List the zones that would be updated and exit",
+					},
+					snapshotFlag,
+					snapshotDirFlag,
+				),
+			},
+			{
+				Name:        "delete",
+				Description: "Remove a TSIG key from the zones that do not use it for zone transfers",
+				Action:      cmdTSIGDelete,
+				Before:      profileHeader,
+				Flags: append(append(outputFlags(), tsigKeyFlags...),
+					cli.StringSliceFlag{
+						Name:  "zone",
+						Usage: "Only remove the key from `ZONE`, including a secondary zone that uses it for zone transfers. Multiple flags allowed",
+					},
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "List the zones the key would be removed from and exit",
+					},
+					snapshotFlag,
+					snapshotDirFlag,
+				),
 			},
 		},
 	})
 
+	commands = append(commands, cli.Command{
+		Name:        "dnssec-status",
+		Description: "Report DNSSEC signing state, keys and DS records of sign-and-serve zones",
+		ArgsUsage:   "<zonename> [zonename...]",
+		Action:      cmdDNSSecStatus,
+		Flags: append(outputFlags(),
+			cli.StringFlag{
+				Name:  "expiring-within",
+				Usage: "Only report zones whose signatures expire within `DURATION` (e.g. 30d) and exit non-zero if any",
+			},
+			cli.StringSliceFlag{
+				Name:  "nameserver",
+				Usage: "Query `HOST[:PORT]` for signature expirations instead of the zone's NS records. Multiple flags allowed",
+			},
+			cli.IntFlag{
+				Name:  "timeout",
+				Value: 5,
+				Usage: "Name server query timeout in `SECONDS`",
+			},
+			cli.BoolFlag{
+				Name:  "no-query",
+				Usage: "Do not query name servers for signature expirations",
+			},
+		),
+	})
+
 	commands = append(commands, cli.Command{
 		Name:        "list-recordsets",
 		Description: "Retreive list of zone Recordsets",
-		ArgsUsage:   "<zonename>",
+		ArgsUsage:   "<zonename> [zonename...]",
 		Action:      cmdListRecordsets,
-		Flags: append(baseV11BaseFlags,
+		Flags: append(outputFlags(formatJSONL, formatBIND),
 			cli.StringSliceFlag{
 				Name:  "type",
 				Usage: "List recordset(s) matching `TYPE`. Multiple flags allowed",
@@ -120,6 +406,9 @@ func GetCommands() []cli.Command {
 				Name:  "search",
 				Usage: "Filter returned recordsets by `SEARCH` criteria",
 			},
+			zonesFileFlag,
+			zoneSearchFlag,
+			parallelFlag,
 		),
 	})
 
@@ -128,11 +417,14 @@ func GetCommands() []cli.Command {
 		Description: "Create multiple zone Recordsets from `FILE`",
 		ArgsUsage:   "<zonename>",
 		Action:      cmdCreateRecordsets,
-		Flags: append(baseV11CmdFlags,
+		Before:      profileHeader,
+		Flags: append(outputFlags(),
 			cli.StringFlag{
 				Name:  "file",
 				Usage: "`FILE` path to JSON formatted recordset content",
 			},
+			snapshotFlag,
+			snapshotDirFlag,
 		),
 	})
 
@@ -141,7 +433,8 @@ func GetCommands() []cli.Command {
 		Description: "Update multiple zone Recordsets from `FILE`",
 		ArgsUsage:   "<zonename>",
 		Action:      cmdUpdateRecordsets,
-		Flags: append(baseV11CmdFlags,
+		Before:      profileHeader,
+		Flags: append(outputFlags(),
 			cli.BoolFlag{
 				Name:  "overwrite",
 				Usage: "Replace ALL Recordsets",
@@ -150,6 +443,10 @@ func GetCommands() []cli.Command {
 				Name:  "file",
 				Usage: "`FILE` path to JSON formatted recordset content",
 			},
+			noLintFlag,
+			minTTLFlag,
+			snapshotFlag,
+			snapshotDirFlag,
 		),
 	})
 
@@ -158,7 +455,7 @@ func GetCommands() []cli.Command {
 		Description: "Retrieve recordset",
 		ArgsUsage:   "<zonename>",
 		Action:      cmdRetrieveRecordset,
-		Flags: append(baseV11BaseFlags,
+		Flags: append(outputFlags(formatJSONL, formatBIND),
 			cli.StringFlag{
 				Name:  "name",
 				Usage: "Recordset `NAME`",
@@ -175,7 +472,8 @@ func GetCommands() []cli.Command {
 		Description: "Create a new recordset",
 		ArgsUsage:   "<zonename>",
 		Action:      cmdCreateRecordset,
-		Flags: append(baseSetCmdFlags,
+		Before:      profileHeader,
+		Flags: append(append(outputFlags(), baseSetCmdFlags...),
 			cli.IntFlag{
 				Name:  "ttl",
 				Usage: "Recordset `TTL`",
@@ -188,6 +486,8 @@ func GetCommands() []cli.Command {
 				Name:  "file",
 				Usage: "`FILE` path to JSON formatted recordset content",
 			},
+			snapshotFlag,
+			snapshotDirFlag,
 		),
 	})
 
@@ -196,7 +496,8 @@ func GetCommands() []cli.Command {
 		Description: "Update existing recordset",
 		ArgsUsage:   "<zonename>",
 		Action:      cmdUpdateRecordset,
-		Flags: append(baseSetCmdFlags,
+		Before:      profileHeader,
+		Flags: append(append(outputFlags(), baseSetCmdFlags...),
 			cli.IntFlag{
 				Name:  "ttl",
 				Usage: "Recordset `TTL`",
@@ -209,6 +510,8 @@ func GetCommands() []cli.Command {
 				Name:  "file",
 				Usage: "`FILE` path to JSON formatted recordset content. Allows multiple recordsets.",
 			},
+			snapshotFlag,
+			snapshotDirFlag,
 		),
 	})
 
@@ -217,7 +520,8 @@ func GetCommands() []cli.Command {
 		Description: "Delete recordset",
 		ArgsUsage:   "<zonename>",
 		Action:      cmdDeleteRecordset,
-		Flags: []cli.Flag{
+		Before:      profileHeader,
+		Flags: append(outputFlags(formatJSONL, formatBIND),
 			cli.StringFlag{
 				Name:  "name",
 				Usage: "Recordset `NAME`",
@@ -226,15 +530,18 @@ func GetCommands() []cli.Command {
 				Name:  "type",
 				Usage: "Recordset `TYPE`",
 			},
-		},
+			snapshotFlag,
+			snapshotDirFlag,
+		),
 	})
 
 	commands = append(commands, cli.Command{
 		Name:        "add-record",
 		Description: "Create or update a DNS recordset in a zone",
-		ArgsUsage:   "<type> <zonename>",
+		ArgsUsage:   "<type> <zonename> [zonename...]",
 		Action:      cmdAddRecord,
-		Flags: append(baseSetCmdFlags,
+		Before:      profileHeader,
+		Flags: append(append(outputFlags(formatJSONL, formatBIND), baseSetCmdFlags...),
 			cli.StringSliceFlag{
 				Name:  "rdata",
 				Usage: "Record target (RDATA), multiple flags allowed",
@@ -243,15 +550,23 @@ func GetCommands() []cli.Command {
 				Name:  "ttl",
 				Usage: "Recordset TTL in seconds",
 			},
+			noLintFlag,
+			minTTLFlag,
+			snapshotFlag,
+			snapshotDirFlag,
+			zonesFileFlag,
+			zoneSearchFlag,
+			parallelFlag,
 		),
 	})
 
 	commands = append(commands, cli.Command{
 		Name:        "rm-record",
 		Description: "Remove a DNS recordset from a zone",
-		ArgsUsage:   "<record type> <zonename>",
+		ArgsUsage:   "<record type> <zonename> [zonename...]",
 		Action:      cmdRmRecord,
-		Flags: append(baseV11CmdFlags,
+		Before:      profileHeader,
+		Flags: append(outputFlags(),
 			cli.StringFlag{
 				Name:  "name",
 				Usage: "Record name to delete (eg: --name www)",
@@ -264,12 +579,59 @@ func GetCommands() []cli.Command {
 				Name:  "non-interactive",
 				Usage: "Run in non-interactive mode (e.g. CI). Fails if multiple matches and not forced.",
 			},
+			snapshotFlag,
+			snapshotDirFlag,
+			zonesFileFlag,
+			zoneSearchFlag,
+			parallelFlag,
+		),
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "find-record",
+		Description: "Find recordsets across zones by rdata, type and name, and optionally replace the matching rdata",
+		ArgsUsage:   "[zonename...]",
+		Action:      cmdFindRecord,
+		Flags: append(outputFlags(),
+			cli.StringSliceFlag{
+				Name:  "rdata",
+				Usage: "Match recordsets with `RDATA`, or with an rdata field such as an MX target equal to it. Multiple flags allowed",
+			},
+			cli.StringSliceFlag{
+				Name:  "type",
+				Usage: "Match recordsets of `TYPE`, comma separated. Multiple flags allowed",
+			},
+			cli.StringFlag{
+				Name:  "name-regex",
+				Usage: "Match recordset names against `REGEX`",
+			},
+			cli.StringFlag{
+				Name:  "replace-with",
+				Usage: "Replace the matched --rdata with `RDATA` after reviewing the planned changes",
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the planned replacement without applying it",
+			},
+			cli.BoolFlag{
+				Name:  "auto-approve",
+				Usage: "Apply the replacement without asking for confirmation",
+			},
+			cli.BoolFlag{
+				Name:  "non-interactive",
+				Usage: "Fail instead of asking for confirmation",
+			},
+			zonesFileFlag,
+			zoneSearchFlag,
+			parallelFlag,
+			snapshotFlag,
+			snapshotDirFlag,
 		),
 	})
 
 	// V11 Zones
 	//Zone level flags
-	baseZoneCmdFlags := append(baseV11CmdFlags,
+	baseZoneCmdFlags := []cli.Flag{
 		cli.StringFlag{
 			Name:  "type",
 			Usage: "Zone `TYPE`",
@@ -314,13 +676,13 @@ func GetCommands() []cli.Command {
 			Name:  "file",
 			Usage: "Read JSON formatted input from `FILE`",
 		},
-	)
+	}
 
 	commands = append(commands, cli.Command{
 		Name:        "list-zoneconfig",
 		Description: "List zone configuration(s)",
 		Action:      cmdListZoneconfig,
-		Flags: append(baseV11BaseFlags,
+		Flags: append(outputFlags(),
 			cli.StringFlag{
 				Name:  "contractid",
 				Usage: "Contract `ID`",
@@ -345,7 +707,7 @@ func GetCommands() []cli.Command {
 		Description: "Fetch and display zone configuration",
 		ArgsUsage:   "<zonename>",
 		Action:      cmdRetrieveZoneconfig,
-		Flags: append(baseV11BaseFlags,
+		Flags: append(outputFlags(),
 			cli.BoolFlag{
 				Name:  "dns",
 				Usage: "Retrieve Zone Master File",
@@ -358,7 +720,8 @@ func GetCommands() []cli.Command {
 		ArgsUsage:   "<zonename>",
 		Description: "Create zone from configuration",
 		Action:      cmdCreateZoneconfig,
-		Flags: append(baseZoneCmdFlags,
+		Before:      profileHeader,
+		Flags: append(append(outputFlags(), baseZoneCmdFlags...),
 			cli.StringFlag{
 				Name:  "contractid",
 				Usage: "Contract `ID`",
@@ -379,11 +742,167 @@ func GetCommands() []cli.Command {
 		Description: "Update a zone",
 		ArgsUsage:   "<zonename>",
 		Action:      cmdUpdateZoneconfig,
-		Flags: append(baseZoneCmdFlags,
+		Before:      profileHeader,
+		Flags: append(append(outputFlags(), baseZoneCmdFlags...),
 			cli.BoolFlag{
 				Name:  "dns",
 				Usage: "Input is Zone Master File",
 			},
+			snapshotFlag,
+			snapshotDirFlag,
+		),
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "apply",
+		Description: "Converge zones to the desired state described by zone manifests",
+		Action:      cmdApply,
+		Before:      profileHeader,
+		Flags: append(outputFlags(),
+			cli.StringFlag{
+				Name:  "manifest",
+				Usage: "Zone manifest `PATH`. A JSON file or a directory of JSON files",
+			},
+			cli.StringFlag{
+				Name:  "plan-out",
+				Usage: "Save the computed plan to `FILE` without applying it",
+			},
+			cli.StringFlag{
+				Name:  "plan",
+				Usage: "Apply a plan previously saved to `FILE` with --plan-out",
+			},
+			cli.BoolFlag{
+				Name:  "auto-approve",
+				Usage: "Apply without asking for confirmation",
+			},
+			cli.BoolFlag{
+				Name:  "non-interactive",
+				Usage: "Run in non-interactive mode (e.g. CI). Fails unless --auto-approve is set.",
+			},
+			snapshotFlag,
+			snapshotDirFlag,
+		),
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "snapshot-zone",
+		Description: "Save a snapshot of zone configuration and recordsets, or list saved snapshots",
+		ArgsUsage:   "<zonename> [zonename...]",
+		Action:      cmdSnapshotZone,
+		Flags: append(outputFlags(),
+			cli.BoolFlag{
+				Name:  "list",
+				Usage: "List the saved snapshots of the zone(s)",
+			},
+			snapshotDirFlag,
+		),
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "restore-zone",
+		Description: "Restore a zone to a saved snapshot",
+		ArgsUsage:   "<zonename>",
+		Action:      cmdRestoreZone,
+		Before:      profileHeader,
+		Flags: append(outputFlags(),
+			cli.StringFlag{
+				Name:  "snapshot",
+				Usage: "Snapshot `ID`, latest, or path to a snapshot file",
+			},
+			snapshotDirFlag,
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the restore plan and exit",
+			},
+			cli.BoolFlag{
+				Name:  "auto-approve",
+				Usage: "Restore without asking for confirmation",
+			},
+			cli.BoolFlag{
+				Name:  "non-interactive",
+				Usage: "Run in non-interactive mode (e.g. CI). Fails unless --auto-approve is set.",
+			},
+		),
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "import-zone",
+		Description: "Convert another DNS provider's zone export into recordsets JSON for create-recordsets, and optionally create them",
+		ArgsUsage:   "<zonename>",
+		Action:      cmdImportZone,
+		Before:      profileHeader,
+		Flags: append(outputFlags(formatJSONL, formatBIND),
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "Export `FORMAT`: route53, cloudflare, azure, gcloud or octodns",
+			},
+			cli.StringFlag{
+				Name:  "file, f",
+				Usage: "Read the provider export from `FILE`",
+			},
+			cli.IntFlag{
+				Name:  "default-ttl",
+				Value: 300,
+				Usage: "`TTL` for records without one, and for Cloudflare automatic TTLs",
+			},
+			cli.StringFlag{
+				Name:  "report",
+				Usage: "Write the converted recordsets and the records left out or changed as JSON to `FILE`",
+			},
+			cli.BoolFlag{
+				Name:  "strict",
+				Usage: "Fail without writing or creating anything when a record cannot be imported",
+			},
+			cli.BoolFlag{
+				Name:  "create",
+				Usage: "Create the recordsets, and the zone when it does not exist",
+			},
+			cli.StringFlag{
+				Name:  "contractid",
+				Usage: "Contract `ID` of the zone created by --create",
+			},
+			cli.StringFlag{
+				Name:  "groupid",
+				Usage: "Group `ID` of the zone created by --create",
+			},
+			snapshotFlag,
+			snapshotDirFlag,
+		),
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "export-terraform",
+		Description: "Generate Akamai Terraform provider configuration and import blocks for zones and their recordsets",
+		ArgsUsage:   "<zonename> [zonename...]",
+		Action:      cmdExportTerraform,
+		Flags: append(outputFlags(formatHCL),
+			cli.StringSliceFlag{
+				Name:  "file, f",
+				Usage: "Read the zone from a snapshot, retrieve-zone JSON or recordsets JSON `FILE` instead of the API. Multiple flags allowed",
+			},
+			cli.StringFlag{
+				Name:  "snapshot",
+				Usage: "Read each zone from snapshot `ID`, latest, or a snapshot file instead of the API",
+			},
+			snapshotDirFlag,
+			cli.StringFlag{
+				Name:  "group",
+				Usage: "Set the group of the zone resources to `ID`. Required unless --no-zone is set",
+			},
+			cli.StringSliceFlag{
+				Name:  "filter",
+				Usage: "Only export recordsets of `TYPE`. Multiple flags allowed",
+			},
+			cli.BoolFlag{
+				Name:  "no-zone",
+				Usage: "Only export the recordsets, for zones managed elsewhere",
+			},
+			cli.BoolFlag{
+				Name:  "no-import",
+				Usage: "Leave out the import blocks",
+			},
+			zonesFileFlag,
+			zoneSearchFlag,
 		),
 	})
 
@@ -391,7 +910,8 @@ func GetCommands() []cli.Command {
 		Name:        "submit-bulkzones",
 		Description: "Submit Bulk Zones request",
 		Action:      cmdSubmitBulkZones,
-		Flags: append(baseV11CmdFlags,
+		Before:      profileHeader,
+		Flags: append(outputFlags(),
 			cli.StringFlag{
 				Name:  "contractid",
 				Usage: "Contract `ID`. Required for create.",
@@ -416,6 +936,23 @@ func GetCommands() []cli.Command {
 				Name:  "file",
 				Usage: "Read JSON formatted input from `FILE`",
 			},
+			cli.StringFlag{
+				Name:  "journal",
+				Usage: "Record submitted batches in journal `FILE`",
+			},
+			cli.StringFlag{
+				Name:  "resume",
+				Usage: "Submit the remaining batches recorded in journal `FILE`",
+			},
+			cli.StringFlag{
+				Name:  "retry-failed",
+				Usage: "Resubmit the zones that failed in the submission recorded in journal `FILE`",
+			},
+			bulkWaitFlag,
+			bulkPollIntervalFlag,
+			bulkWaitTimeoutFlag,
+			snapshotFlag,
+			snapshotDirFlag,
 		),
 	})
 
@@ -423,7 +960,7 @@ func GetCommands() []cli.Command {
 		Name:        "status-bulkzones",
 		Description: "Query Bulk Zones Request Status",
 		Action:      cmdStatusBulkZones,
-		Flags: append(baseV11BaseFlags,
+		Flags: append(outputFlags(),
 			cli.StringSliceFlag{
 				Name:  "requestid",
 				Usage: "Request Id. Multiple args allowed.",
@@ -436,6 +973,9 @@ func GetCommands() []cli.Command {
 				Name:  "delete",
 				Usage: "Bulk zone delete operation.",
 			},
+			bulkWaitFlag,
+			bulkPollIntervalFlag,
+			bulkWaitTimeoutFlag,
 		),
 	})
 
@@ -443,7 +983,7 @@ func GetCommands() []cli.Command {
 		Name:        "result-bulkzones",
 		Description: "Query Bulk Zones Result Summary",
 		Action:      cmdResultBulkZones,
-		Flags: append(baseV11BaseFlags,
+		Flags: append(outputFlags(),
 			cli.StringSliceFlag{
 				Name:  "requestid",
 				Usage: "Request Id. Multiple args allowed.",
@@ -459,6 +999,256 @@ func GetCommands() []cli.Command {
 		),
 	})
 
+	acmeZoneFlag := cli.StringFlag{
+		Name:  "zone",
+		Usage: "Use `ZONE` instead of finding the closest zone in the zone list",
+	}
+
+	commands = append(commands, cli.Command{
+		Name:        "acme-hook",
+		Description: "Publish and remove ACME DNS-01 challenge TXT values for certbot, lego and acme.sh",
+		Subcommands: []cli.Command{
+			{
+				Name:        "present",
+				Description: "Add the challenge value to the _acme-challenge TXT recordset",
+				ArgsUsage:   "[<fqdn> <token>]",
+				Action:      cmdACMEPresent,
+				Before:      profileHeader,
+				Flags: []cli.Flag{
+					acmeZoneFlag,
+					cli.BoolFlag{
+						Name:  "wait",
+						Usage: "Wait until the zone's name servers answer with the value",
+					},
+					cli.IntFlag{
+						Name:   "wait-timeout",
+						Value:  300,
+						Usage:  "Give up waiting after `SECONDS`. 0 waits indefinitely",
+						EnvVar: "AKAMAI_DNS_ACME_WAIT_TIMEOUT,EXEC_PROPAGATION_TIMEOUT",
+					},
+					cli.IntFlag{
+						Name:   "poll-interval",
+						Value:  5,
+						Usage:  "`SECONDS` between name server queries",
+						EnvVar: "AKAMAI_DNS_ACME_POLL_INTERVAL,EXEC_POLLING_INTERVAL",
+					},
+					cli.StringSliceFlag{
+						Name:  "nameserver",
+						Usage: "Query name server `HOST[:PORT]` instead of the zone's NS records. Multiple flags allowed",
+					},
+				},
+			},
+			{
+				Name:        "cleanup",
+				Description: "Remove the challenge value from the _acme-challenge TXT recordset",
+				ArgsUsage:   "[<fqdn> <token>]",
+				Action:      cmdACMECleanup,
+				Before:      profileHeader,
+				Flags:       []cli.Flag{acmeZoneFlag},
+			},
+		},
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "ddns",
+		Description: "Keep a recordset pointed at this host's public IP address, replacing it whenever the address changes",
+		Action:      cmdDDNS,
+		Before:      profileHeader,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "zone",
+				Usage: "`ZONE` holding the record",
+			},
+			cli.StringFlag{
+				Name:  "name",
+				Usage: "Record `NAME`, relative to the zone or fully qualified",
+			},
+			cli.StringSliceFlag{
+				Name:  "type",
+				Usage: "Record `TYPE`: A, AAAA or A,AAAA (default: A)",
+			},
+			cli.IntFlag{
+				Name:  "ttl",
+				Value: 300,
+				Usage: "Recordset `TTL` in seconds",
+			},
+			cli.StringFlag{
+				Name:  "interface",
+				Usage: "Read the address from local network `INTERFACE` instead of an address URL",
+			},
+			cli.StringFlag{
+				Name:  "ipv4-url",
+				Value: defaultDDNSIPv4URL,
+				Usage: "`URL` that returns the public IPv4 address as plain text",
+			},
+			cli.StringFlag{
+				Name:  "ipv6-url",
+				Value: defaultDDNSIPv6URL,
+				Usage: "`URL` that returns the public IPv6 address as plain text",
+			},
+			cli.DurationFlag{
+				Name:  "interval",
+				Value: 5 * time.Minute,
+				Usage: "Check the address every `DURATION`",
+			},
+			cli.DurationFlag{
+				Name:  "jitter",
+				Value: 30 * time.Second,
+				Usage: "Add a random delay of up to `DURATION` to each interval",
+			},
+			cli.StringFlag{
+				Name:  "state-file",
+				Usage: "Keep the last published addresses in `FILE` across restarts",
+			},
+			cli.StringFlag{
+				Name:  "health-listen",
+				Usage: "Serve GET /healthz on `ADDRESS`",
+			},
+			cli.StringFlag{
+				Name:  "log-format",
+				Value: "text",
+				Usage: "Write logs to STDERR as `FORMAT`: text or json",
+			},
+			cli.BoolFlag{
+				Name:  "once",
+				Usage: "Check and update once, then exit",
+			},
+		},
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "webhook-server",
+		Description: "Serve the ExternalDNS webhook provider protocol for the zones matching the domain filter",
+		Action:      cmdWebhookServer,
+		Before:      profileHeader,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:   "listen",
+				Value:  "127.0.0.1:8888",
+				Usage:  "Listen on `ADDRESS`",
+				EnvVar: "AKAMAI_DNS_WEBHOOK_LISTEN",
+			},
+			cli.StringSliceFlag{
+				Name:   "domain-filter",
+				Usage:  "Only manage names in `DOMAIN`. Multiple flags allowed",
+				EnvVar: "AKAMAI_DNS_DOMAIN_FILTER",
+			},
+			cli.StringSliceFlag{
+				Name:   "exclude-domains",
+				Usage:  "Never manage names in `DOMAIN`. Multiple flags allowed",
+				EnvVar: "AKAMAI_DNS_EXCLUDE_DOMAINS",
+			},
+			cli.StringFlag{
+				Name:   "txt-owner-id",
+				Value:  "default",
+				Usage:  "Only change recordsets owned by `ID` in the TXT registry, the same as ExternalDNS --txt-owner-id",
+				EnvVar: "AKAMAI_DNS_TXT_OWNER_ID",
+			},
+			cli.StringFlag{
+				Name:  "txt-prefix",
+				Usage: "`PREFIX` of the TXT registry record names, the same as ExternalDNS --txt-prefix",
+			},
+			cli.StringFlag{
+				Name:  "txt-suffix",
+				Usage: "`SUFFIX` of the TXT registry record names, the same as ExternalDNS --txt-suffix",
+			},
+			cli.IntFlag{
+				Name:  "default-ttl",
+				Value: 300,
+				Usage: "`TTL` for endpoints without one",
+			},
+			cli.BoolFlag{
+				Name:  "changelist",
+				Usage: "Apply each zone's changes through a change list instead of one recordsets update",
+			},
+			cli.StringFlag{
+				Name:  "log-format",
+				Value: "text",
+				Usage: "Write logs to STDERR as `FORMAT`: text or json",
+			},
+		},
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "update-gateway",
+		Description: "Accept RFC 2136 dynamic updates signed with TSIG and apply them to Edge DNS primary zones",
+		Action:      cmdUpdateGateway,
+		Before:      profileHeader,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:   "listen",
+				Value:  "127.0.0.1:5353",
+				Usage:  "Listen for UDP and TCP on `ADDRESS`",
+				EnvVar: "AKAMAI_DNS_UPDATE_GATEWAY_LISTEN",
+			},
+			cli.StringSliceFlag{
+				Name:   "tsig-key",
+				Usage:  "Accept updates signed with `[ALGORITHM:]NAME:SECRET`, the nsupdate -y format. The algorithm defaults to hmac-sha256. Multiple flags allowed",
+				EnvVar: "AKAMAI_DNS_TSIG_KEY",
+			},
+			cli.StringSliceFlag{
+				Name:  "tsig-key-file",
+				Usage: "Accept updates signed with the keys in BIND key `FILE`, as written by tsig-keygen. Multiple flags allowed",
+			},
+			cli.StringSliceFlag{
+				Name:  "zone",
+				Usage: "Only accept updates to `ZONE`. Multiple flags allowed (default: any primary zone)",
+			},
+			cli.BoolFlag{
+				Name:  "allow-unsigned",
+				Usage: "Accept updates without a TSIG signature",
+			},
+			cli.StringFlag{
+				Name:  "log-format",
+				Value: "text",
+				Usage: "Write logs to STDERR as `FORMAT`: text or json",
+			},
+		},
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "mock-server",
+		Description: "Serve a fake Edge DNS API for offline testing and demos. Use with --endpoint",
+		Action:      cmdMockServer,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "listen",
+				Value: "127.0.0.1:8080",
+				Usage: "Listen on `ADDRESS`. Port 0 picks a free port",
+			},
+			cli.StringFlag{
+				Name:  "data",
+				Usage: "Load and save zones in `FILE` instead of keeping them only in memory",
+			},
+		},
+	})
+
+	commands = append(commands, cli.Command{
+		Name:        "profile",
+		Description: "Manage the named account profiles in ~/.akamai-dns.yaml",
+		Subcommands: []cli.Command{
+			{
+				Name:        "list",
+				Description: "List profiles, marking the one in use",
+				Action:      cmdProfileList,
+				Flags:       outputFlags(),
+			},
+			{
+				Name:        "show",
+				Description: "Show the settings of a profile, by default the one in use",
+				ArgsUsage:   "[name]",
+				Action:      cmdProfileShow,
+				Flags:       outputFlags(),
+			},
+			{
+				Name:        "use",
+				Description: "Make a profile the default for later commands",
+				ArgsUsage:   "<name>",
+				Action:      cmdProfileUse,
+			},
+		},
+	})
+
 	return commands
 
 }
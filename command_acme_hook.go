@@ -0,0 +1,334 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+const (
+	acmeChallengeLabel = "_acme-challenge."
+	acmeTTL            = 60
+
+	// Attempts to write the TXT recordset when a concurrent hook overwrote it
+	acmeWriteAttempts = 5
+)
+
+// An ACME DNS-01 challenge: the TXT record name and the value to publish
+type acmeChallenge struct {
+	Name  string
+	Value string
+}
+
+func cmdACMEPresent(c *cli.Context) error {
+	return runACMEHook(c, true)
+}
+
+func cmdACMECleanup(c *cli.Context) error {
+	return runACMEHook(c, false)
+}
+
+func runACMEHook(c *cli.Context, present bool) error {
+	challenge, err := acmeChallengeFromArgs(c)
+	if err != nil {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return wrapError(err)
+	}
+
+	ctx := context.Background()
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+	zonename := strings.ToLower(strings.TrimSuffix(c.String("zone"), "."))
+	if zonename == "" {
+		if zonename, err = findChallengeZone(ctx, dnsClient, challenge.Name); err != nil {
+			return wrapError(err)
+		}
+	} else if challenge.Name != zonename && !strings.HasSuffix(challenge.Name, "."+zonename) {
+		return newCommandError(exitValidation, "%s is not in zone %s", challenge.Name, zonename)
+	}
+	defer zoneCache.invalidate(zonename)
+
+	if !present {
+		fmt.Fprintln(os.Stderr, color.BlueString("Removing challenge value from %s TXT in %s...", challenge.Name, zonename))
+		if err := updateChallengeTXT(ctx, dnsClient, zonename, challenge, false); err != nil {
+			return wrapError(err)
+		}
+		fmt.Fprintln(os.Stderr, color.GreenString("Challenge value removed"))
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Adding challenge value to %s TXT in %s...", challenge.Name, zonename))
+	if err := updateChallengeTXT(ctx, dnsClient, zonename, challenge, true); err != nil {
+		return wrapError(err)
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("Challenge value added"))
+
+	if !c.Bool("wait") {
+		return nil
+	}
+	nameservers := c.StringSlice("nameserver")
+	if len(nameservers) == 0 {
+		if nameservers, err = zoneNameservers(ctx, dnsClient, zonename); err != nil {
+			return wrapError(err)
+		}
+	}
+	timeout := time.Duration(c.Int("wait-timeout")) * time.Second
+	interval := time.Duration(c.Int("poll-interval")) * time.Second
+	if err := waitForChallenge(ctx, challenge, nameservers, timeout, interval); err != nil {
+		return wrapError(err)
+	}
+	return nil
+}
+
+// Challenge from the command arguments or the hook environment of the ACME
+// client:
+//
+//	acme-hook present <fqdn> <value>                     lego exec, acme.sh and scripts
+//	acme-hook present <domain> <token> <keyAuth>         lego exec with EXEC_MODE=RAW
+//	acme-hook present                                    certbot, from CERTBOT_DOMAIN and CERTBOT_VALIDATION
+//
+// The record name gets the _acme-challenge label when it does not have it.
+func acmeChallengeFromArgs(c *cli.Context) (*acmeChallenge, error) {
+	args := c.Args()
+	var name, value string
+	switch {
+	case len(args) == 3 && strings.EqualFold(os.Getenv("EXEC_MODE"), "RAW"):
+		digest := sha256.Sum256([]byte(args[2]))
+		name, value = args[0], base64.RawURLEncoding.EncodeToString(digest[:])
+	case len(args) == 2:
+		name, value = args[0], args[1]
+	case len(args) == 0 && os.Getenv("CERTBOT_DOMAIN") != "":
+		name, value = os.Getenv("CERTBOT_DOMAIN"), os.Getenv("CERTBOT_VALIDATION")
+	default:
+		return nil, newCommandError(exitValidation, "fqdn and token are required, as arguments or in CERTBOT_DOMAIN and CERTBOT_VALIDATION")
+	}
+	if value == "" {
+		return nil, newCommandError(exitValidation, "challenge token is empty")
+	}
+	if strings.ContainsAny(value, "\"\\ ") {
+		return nil, newCommandError(exitValidation, "challenge token %q is not a valid ACME value", value)
+	}
+
+	name = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+	name = strings.TrimPrefix(name, "*.")
+	if !strings.HasPrefix(name, acmeChallengeLabel) {
+		name = acmeChallengeLabel + name
+	}
+	return &acmeChallenge{Name: name, Value: value}, nil
+}
+
+// Closest zone enclosing a name, found by walking up its labels against the zone list
+func findChallengeZone(ctx context.Context, dnsClient dns.DNS, name string) (string, error) {
+	resp, err := dnsClient.ListZones(ctx, dns.ListZonesRequest{ShowAll: true, SortBy: "zone"})
+	if err != nil {
+		return "", fmt.Errorf("zone list retrieval failed: %w", err)
+	}
+	types := map[string]string{}
+	for _, z := range resp.Zones {
+		types[strings.ToLower(strings.TrimSuffix(z.Zone, "."))] = strings.ToUpper(z.Type)
+	}
+	for candidate := name; candidate != ""; {
+		if ztype, ok := types[candidate]; ok {
+			if ztype != "PRIMARY" {
+				return "", newCommandError(exitValidation, "zone %s for %s is a %s zone; challenge records need a PRIMARY zone", candidate, name, ztype)
+			}
+			return candidate, nil
+		}
+		_, parent, found := strings.Cut(candidate, ".")
+		if !found {
+			break
+		}
+		candidate = parent
+	}
+	return "", newCommandError(exitNotFound, "no zone found for %s", name)
+}
+
+// Add or remove one value of the challenge TXT recordset, keeping the values of
+// other challenges for the same name. Hooks for a certificate with several
+// names run concurrently and can overwrite each other's write, so the recordset
+// is read back and the change retried until it holds.
+func updateChallengeTXT(ctx context.Context, dnsClient dns.DNS, zonename string, challenge *acmeChallenge, add bool) error {
+	for attempt := 1; ; attempt++ {
+		values, err := challengeValues(ctx, dnsClient, zonename, challenge.Name)
+		if err != nil {
+			return err
+		}
+		if hasChallengeValue(values, challenge.Value) == add {
+			return nil
+		}
+		if attempt > acmeWriteAttempts {
+			return fmt.Errorf("challenge value for %s was overwritten by concurrent updates %d times", challenge.Name, acmeWriteAttempts)
+		}
+
+		if add {
+			err = writeChallengeValues(ctx, dnsClient, zonename, challenge.Name, values, append(values, `"`+challenge.Value+`"`))
+		} else {
+			kept := []string{}
+			for _, v := range values {
+				if txtValue(v) != challenge.Value {
+					kept = append(kept, v)
+				}
+			}
+			err = writeChallengeValues(ctx, dnsClient, zonename, challenge.Name, values, kept)
+		}
+		if err != nil && !isConcurrentChange(err) {
+			return err
+		}
+
+		// Give a concurrent writer time to finish before reading back
+		time.Sleep(time.Duration(200+rand.IntN(800)) * time.Millisecond)
+	}
+}
+
+// Current TXT values of a name, nil when the recordset does not exist
+func challengeValues(ctx context.Context, dnsClient dns.DNS, zonename, name string) ([]string, error) {
+	rec, err := dnsClient.GetRecord(ctx, dns.GetRecordRequest{Zone: zonename, Name: name, RecordType: "TXT"})
+	var dnsErr *dns.Error
+	if errors.As(err, &dnsErr) && dnsErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("recordset retrieval failed: %w", err)
+	}
+	return rec.Target, nil
+}
+
+// Replace the TXT values, creating or deleting the recordset as needed
+func writeChallengeValues(ctx context.Context, dnsClient dns.DNS, zonename, name string, current, values []string) error {
+	record := &dns.RecordBody{Name: name, RecordType: "TXT", TTL: acmeTTL, Target: values}
+	switch {
+	case len(values) == 0:
+		if err := dnsClient.DeleteRecord(ctx, dns.DeleteRecordRequest{Zone: zonename, Name: name, RecordType: "TXT"}); err != nil {
+			return fmt.Errorf("recordset delete failed: %w", err)
+		}
+	case len(current) == 0:
+		if err := dnsClient.CreateRecord(ctx, dns.CreateRecordRequest{Zone: zonename, Record: record}); err != nil {
+			return fmt.Errorf("recordset create failed: %w", err)
+		}
+	default:
+		if err := dnsClient.UpdateRecord(ctx, dns.UpdateRecordRequest{Zone: zonename, Record: record}); err != nil {
+			return fmt.Errorf("recordset update failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Errors from a write that raced another hook: the recordset was created,
+// deleted or changed between the read and the write
+func isConcurrentChange(err error) bool {
+	var dnsErr *dns.Error
+	if !errors.As(err, &dnsErr) {
+		return false
+	}
+	switch dnsErr.StatusCode {
+	case http.StatusConflict, http.StatusNotFound, http.StatusPreconditionFailed:
+		return true
+	}
+	return false
+}
+
+func hasChallengeValue(values []string, value string) bool {
+	for _, v := range values {
+		if txtValue(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Name servers of a zone, from its apex NS recordset
+func zoneNameservers(ctx context.Context, dnsClient dns.DNS, zonename string) ([]string, error) {
+	rec, err := dnsClient.GetRecord(ctx, dns.GetRecordRequest{Zone: zonename, Name: zonename, RecordType: "NS"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the name servers of %s: %w", zonename, err)
+	}
+	nameservers := []string{}
+	for _, ns := range rec.Target {
+		nameservers = append(nameservers, strings.TrimSuffix(ns, "."))
+	}
+	return nameservers, nil
+}
+
+// Poll every name server until all of them answer with the challenge value
+func waitForChallenge(ctx context.Context, challenge *acmeChallenge, nameservers []string, timeout, interval time.Duration) error {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	fmt.Fprintln(os.Stderr, color.BlueString("Waiting for %s on %s...", challenge.Name, strings.Join(nameservers, ", ")))
+	deadline := time.Now().Add(timeout)
+	for {
+		pending := []string{}
+		for _, ns := range nameservers {
+			if !nameserverHasValue(ctx, ns, challenge) {
+				pending = append(pending, ns)
+			}
+		}
+		if len(pending) == 0 {
+			fmt.Fprintln(os.Stderr, color.GreenString("Challenge value is live on all name servers"))
+			return nil
+		}
+		if timeout > 0 && time.Now().After(deadline) {
+			return newCommandError(exitError, "timed out waiting for %s on %s", challenge.Name, strings.Join(pending, ", "))
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Ask one name server directly for the challenge TXT record
+func nameserverHasValue(ctx context.Context, nameserver string, challenge *acmeChallenge) bool {
+	addr := nameserver
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "53")
+	}
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	values, err := resolver.LookupTXT(ctx, challenge.Name+".")
+	if err != nil {
+		return false
+	}
+	for _, v := range values {
+		if v == challenge.Value {
+			return true
+		}
+	}
+	return false
+}
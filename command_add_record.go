@@ -16,10 +16,8 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 
@@ -33,54 +31,72 @@ import (
 func cmdAddRecord(c *cli.Context) error {
 
 	//Validate postional arguments; record type and zone name
-	if c.NArg() < 2 {
+	if c.NArg() < 2 && (c.NArg() < 1 || !c.IsSet("zones-file") && !c.IsSet("zone-search")) {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("record type and zonename are required"), 1)
+		return newCommandError(exitValidation, "record type and zonename are required")
 	}
 
 	recordType := strings.ToUpper(c.Args().Get(0))
-	zonename := strings.TrimSuffix(c.Args().Get(1), ".")
 
 	//validate required flags
 	if !c.IsSet("name") || !c.IsSet("rdata") || !c.IsSet("ttl") {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("--name, --rdata and --ttl are required"), 1)
-	}
-
-	name := c.String("name")
-	if !strings.HasSuffix(name, "."+zonename) {
-		name = fmt.Sprintf("%s.%s", name, zonename)
-	}
-	if !strings.HasSuffix(name, "."+zonename) {
-		return cli.NewExitError(color.RedString("record name must be within the zone %s", zonename), 1)
-	}
-
-	ttl := c.Int("ttl")
-	rdata := c.StringSlice("rdata")
-	outputPath := ""
-	if c.IsSet("output") {
-		outputPath = filepath.FromSlash(c.String("output"))
+		return newCommandError(exitValidation, "--name, --rdata and --ttl are required")
 	}
 
 	//Set up Edgegrid session and DNS client
 	ctx := context.Background()
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
+	zones, err := zoneTargets(ctx, dnsClient, c, c.Args().Tail())
+	if err != nil {
+		return wrapError(err)
+	}
+	job := func(ctx context.Context, zonename string) (string, error) {
+		return addRecord(ctx, dnsClient, c, recordType, zonename, zones)
+	}
+	if multiZone(c, zones) {
+		format, err := outputFormat(c, zoneExportOutput(&ZoneExport{}))
+		if err != nil {
+			return wrapError(err)
+		}
+		return runZoneCommand(ctx, c, zones, format == formatJSON, job)
+	}
+
+	results, err := job(ctx, zones[0])
+	if err != nil {
+		return wrapError(err)
+	}
+	if results == "" {
+		return nil
+	}
+	return writeOutputText(c, results)
+}
+
+// Create the record in a zone, or merge the rdata into the existing recordset,
+// and render the resulting recordset. zones are all the zones the command targets.
+func addRecord(ctx context.Context, dnsClient dns.DNS, c *cli.Context, recordType, zonename string, zones []string) (string, error) {
+	name, err := qualifyRecordName(c.String("name"), zonename, zones)
+	if err != nil {
+		return "", err
+	}
+
+	ttl := c.Int("ttl")
+	rdata := c.StringSlice("rdata")
+
 	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return "", fmt.Errorf("Failed to retrieve zone information for %s. Error: %w", zonename, err)
 	}
 
 	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and cannot have recordsets", zonename)), 1)
+		return "", newCommandError(exitValidation, "Zone %s is an ALIAS zone and cannot have recordsets", zonename)
 	}
 
 	// Define new record
@@ -98,9 +114,31 @@ func cmdAddRecord(c *cli.Context) error {
 		Name:       newrecord.Name,
 	})
 
-	if err == nil && existing.RecordType != "" {
+	recordExists := err == nil && existing.RecordType != ""
 
-		fmt.Println("Record already exists, updating it instead...")
+	// Lint the zone with the new rdata added
+	if !c.Bool("no-lint") {
+		current, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+			Zone:      zonename,
+			QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+		})
+		if err != nil {
+			return "", fmt.Errorf("Recordset list retrieval failed. Error: %w", err)
+		}
+		candidate := dns.RecordSet{Name: name, Type: recordType, TTL: ttl, Rdata: rdata}
+		if recordExists {
+			candidate.Name = existing.Name
+			candidate.Rdata = append(append([]string(nil), existing.Target...), rdata...)
+		}
+		proposed := mergeRecordSets(current.RecordSets, []dns.RecordSet{candidate})
+		if err := preflightLint(c, zonename, current.RecordSets, proposed); err != nil {
+			return "", err
+		}
+	}
+
+	if recordExists {
+
+		fmt.Fprintf(os.Stderr, "Record already exists in %s, updating it instead...\n", zonename)
 
 		//Merge TTL and RDATA values if needed
 		ttlChanged := existing.TTL != newrecord.TTL
@@ -120,9 +158,13 @@ func cmdAddRecord(c *cli.Context) error {
 
 		changed := ttlChanged || strings.Join(existing.Target, "") != strings.Join(mergedRdata, "")
 		if !changed {
-			fmt.Println(color.BlueString("No changes to update."))
-			return nil
+			return color.BlueString("No changes to update."), nil
+		}
+
+		if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+			return "", err
 		}
+		defer zoneCache.invalidate(zonename)
 
 		// Update record with merged RDATA and TTL if record already exists
 		updateRecord := &dns.RecordBody{
@@ -137,17 +179,21 @@ func cmdAddRecord(c *cli.Context) error {
 			Record: updateRecord,
 		})
 		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset update failed. Error: %s", err)), 1)
+			return "", fmt.Errorf("Recordset update failed. Error: %w", err)
 		}
 	} else {
 		// Create a new record
-		fmt.Println(color.BlueString("Creating new recordset..."))
+		if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+			return "", err
+		}
+		defer zoneCache.invalidate(zonename)
+		fmt.Fprintln(os.Stderr, color.BlueString("Creating new recordset in %s...", zonename))
 		err = dnsClient.CreateRecord(ctx, dns.CreateRecordRequest{
 			Zone:   zonename,
 			Record: newrecord,
 		})
 		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset create failed. Error: %s", err)), 1)
+			return "", fmt.Errorf("Recordset create failed. Error: %w", err)
 		}
 	}
 
@@ -158,48 +204,10 @@ func cmdAddRecord(c *cli.Context) error {
 		Name:       newrecord.Name,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to read recordset content. Error: %s", err.Error())), 1)
-	}
-	if c.IsSet("suppress") && c.Bool("suppress") {
-		return nil
+		return "", fmt.Errorf("Failed to read recordset content. Error: %w", err)
 	}
-
-	//Output the recordset
-	fmt.Println(color.BlueString("Assembling recordset Content... ", ""))
-	var results string
-	if c.IsSet("json") && c.Bool("json") {
-		recordset := &dns.RecordSet{
-			Name:  record.Name,
-			Type:  record.RecordType,
-			TTL:   record.TTL,
-			Rdata: record.Target,
-		}
-		zjson, err := json.MarshalIndent(recordset, "", "  ")
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to marshal recordset"), 1)
-		}
-		results = string(zjson)
-	} else {
-		results = renderRecordsetTable(zonename, record)
+	if c.Bool("suppress") {
+		return "", nil
 	}
-
-	if len(outputPath) > 1 {
-		//fmt.Println(color.GreenString("Writing output to %s", outputPath))
-		rsHandle, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to create output file. Error: %s", err.Error())), 1)
-		}
-		defer rsHandle.Close()
-		_, err = rsHandle.WriteString(results)
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to write zone output to file"), 1)
-		}
-		rsHandle.Sync()
-		fmt.Println(color.GreenString("Output written to %s", outputPath))
-	} else {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, results)
-	}
-
-	return nil
+	return renderOutput(c, recordsetOutput(zonename, record))
 }
@@ -0,0 +1,233 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+func cmdApply(c *cli.Context) error {
+
+	// Validate flags
+	if c.IsSet("manifest") == c.IsSet("plan") {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "Either --manifest or --plan is required")
+	}
+	if c.IsSet("plan") && c.IsSet("plan-out") {
+		return newCommandError(exitValidation, "--plan-out can only be used with --manifest")
+	}
+
+	// Initialize context and Edgegrid session
+	ctx := context.Background()
+
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+	var plan *ApplyPlan
+	if c.IsSet("manifest") {
+		manifests, err := loadZoneManifests(filepath.FromSlash(c.String("manifest")))
+		if err != nil {
+			return newCommandError(exitValidation, "Failed to load manifests: %v", err)
+		}
+
+		fmt.Fprintln(os.Stderr, color.BlueString("Planning %d zone(s)...", len(manifests)))
+		plan, err = planManifests(ctx, dnsClient, manifests)
+		if err != nil {
+			return apiError(err, "Plan failed: %v", err)
+		}
+	} else {
+		data, err := os.ReadFile(filepath.FromSlash(c.String("plan")))
+		if err != nil {
+			return newCommandError(exitValidation, "Failed to read plan file")
+		}
+		plan = &ApplyPlan{}
+		if err := json.Unmarshal(data, plan); err != nil {
+			return newCommandError(exitValidation, "Failed to parse plan file: %v", err)
+		}
+	}
+
+	// Show the plan
+	if err := writeOutput(c, applyPlanOutput(plan)); err != nil {
+		return err
+	}
+
+	// Save the plan for a later apply
+	if c.IsSet("plan-out") {
+		planPath := filepath.FromSlash(c.String("plan-out"))
+		b, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return newCommandError(exitError, "Unable to marshal plan")
+		}
+		if err := writeFileAtomic(planPath, b, 0600); err != nil {
+			return apiError(err, "Failed to write plan file: %v", err)
+		}
+		fmt.Fprintln(os.Stderr, color.GreenString("Plan written to %s", planPath))
+		return nil
+	}
+
+	pending := 0
+	for _, zp := range plan.Zones {
+		if zp.Action != applyNoop {
+			pending++
+		}
+	}
+	if pending == 0 {
+		fmt.Fprintln(os.Stderr, color.GreenString("No changes. Zones are up to date."))
+		return nil
+	}
+
+	if !c.Bool("auto-approve") {
+		if c.Bool("non-interactive") {
+			return newCommandError(exitValidation, "Refusing to apply without --auto-approve in non-interactive mode")
+		}
+		fmt.Fprintf(os.Stderr, "Apply changes to %d zone(s)? [y/N]: ", pending)
+		reader := bufio.NewReader(os.Stdin)
+		resp, _ := reader.ReadString('\n')
+		resp = strings.ToLower(strings.TrimSpace(resp))
+		if resp != "y" && resp != "yes" {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return nil
+		}
+	}
+
+	// Refuse the whole plan if any zone moved on since it was made
+	for _, zp := range plan.Zones {
+		if err := checkZonePlanVersion(ctx, dnsClient, zp); err != nil {
+			return wrapError(err)
+		}
+	}
+
+	for _, zp := range plan.Zones {
+		if zp.Action == applyNoop {
+			continue
+		}
+		if zp.Action == applyUpdate {
+			if err := snapshotBeforeChange(ctx, dnsClient, c, zp.Zone); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintln(os.Stderr, color.BlueString("Applying %s (%s)...", zp.Zone, zp.Action))
+		if err := applyZonePlan(ctx, dnsClient, zp); err != nil {
+			return apiError(err, "Apply failed for zone %s: %v", zp.Zone, err)
+		}
+		fmt.Fprintln(os.Stderr, color.GreenString("Zone %s applied", zp.Zone))
+	}
+
+	return nil
+}
+
+// Verify the zone is still in the state the plan was computed from
+func checkZonePlanVersion(ctx context.Context, dnsClient dns.DNS, zp ZoneApplyPlan) error {
+	zone, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zp.Zone})
+	if zp.Action == applyCreate {
+		// Only a 404 means the zone is still to be created
+		var dnsErr *dns.Error
+		switch {
+		case err == nil:
+			return newCommandError(exitConflict, "zone %s was created since the plan was made; re-run the plan", zp.Zone)
+		case errors.As(err, &dnsErr) && dnsErr.StatusCode == http.StatusNotFound:
+			return nil
+		}
+	}
+	if err != nil {
+		return apiError(err, "failed to retrieve zone %s: %v", zp.Zone, err)
+	}
+	if zone.VersionID != zp.VersionID {
+		return newCommandError(exitConflict, "zone %s changed since the plan was made (version %s, now %s); re-run the plan", zp.Zone, zp.VersionID, zone.VersionID)
+	}
+	return nil
+}
+
+// Converge a single zone to its planned state
+func applyZonePlan(ctx context.Context, dnsClient dns.DNS, zp ZoneApplyPlan) error {
+	if zp.Config == nil {
+		return fmt.Errorf("plan has no zone configuration")
+	}
+	defer zoneCache.invalidate(zp.Zone)
+
+	switch zp.Action {
+	case applyCreate:
+		err := dnsClient.CreateZone(ctx, dns.CreateZoneRequest{
+			CreateZone:      zp.Config,
+			ZoneQueryString: dns.ZoneQueryString{Contract: zp.Config.ContractID, Group: zp.GroupID},
+		})
+		if err != nil {
+			return fmt.Errorf("zone create failed: %w", err)
+		}
+		if !hasRecordSets(zp.Config.Type) {
+			return nil
+		}
+
+		// Generate the default SOA and NS records before adding the manifest recordsets
+		if err := dnsClient.SaveChangeList(ctx, dns.SaveChangeListRequest{Zone: zp.Zone}); err != nil {
+			return fmt.Errorf("failed to initialize zone records: %w", err)
+		}
+		if err := dnsClient.SubmitChangeList(ctx, dns.SubmitChangeListRequest{Zone: zp.Zone}); err != nil {
+			return fmt.Errorf("failed to initialize zone records during submit changelist: %w", err)
+		}
+		if len(zp.RecordSets) == 0 {
+			return nil
+		}
+		current, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+			Zone:      zp.Zone,
+			QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+		})
+		if err != nil {
+			return fmt.Errorf("recordset list retrieval failed: %w", err)
+		}
+		return dnsClient.UpdateRecordSets(ctx, dns.UpdateRecordSetsRequest{
+			Zone:       zp.Zone,
+			RecordSets: &dns.RecordSets{RecordSets: desiredRecordSets(current.RecordSets, zp.RecordSets)},
+		})
+
+	case applyUpdate:
+		if len(zp.ConfigChanges) > 0 {
+			if err := dns.ValidateZone(zp.Config); err != nil {
+				return fmt.Errorf("invalid zone value: %w", err)
+			}
+			if err := dnsClient.UpdateZone(ctx, dns.UpdateZoneRequest{CreateZone: zp.Config}); err != nil {
+				return fmt.Errorf("zone update failed: %w", err)
+			}
+		}
+		if len(zp.Changes) > 0 {
+			err := dnsClient.UpdateRecordSets(ctx, dns.UpdateRecordSetsRequest{
+				Zone:       zp.Zone,
+				RecordSets: &dns.RecordSets{RecordSets: zp.RecordSets},
+			})
+			if err != nil {
+				return fmt.Errorf("recordset update failed: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/session"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// ChangeListView is the change list metadata with its staged recordsets
+type ChangeListView struct {
+	dns.GetChangeListResponse
+	RecordSets []dns.RecordSet `json:"recordsets,omitempty"`
+}
+
+// Validate the zonename argument and set up the session shared by the changelist commands
+func changeListInit(c *cli.Context) (context.Context, session.Session, dns.DNS, string, error) {
+	if c.NArg() == 0 {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return nil, nil, nil, "", newCommandError(exitValidation, "zonename is required")
+	}
+	zonename := strings.TrimSuffix(c.Args().First(), ".")
+
+	// Initialize context and Edgegrid session
+	ctx := context.Background()
+
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return nil, nil, nil, "", newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	return ctx, sess, dns.Client(edgegrid.GetSession(ctx)), zonename, nil
+}
+
+// Map a missing change list to a readable message
+func changeListError(zonename, action string, err error) error {
+	var dnsErr *dns.Error
+	if errors.As(err, &dnsErr) && dnsErr.StatusCode == http.StatusNotFound {
+		return newCommandError(exitNotFound, "Zone %s has no change list. Create one with 'changelist create'", zonename)
+	}
+	return apiError(err, "Change list %s failed: %v", action, err)
+}
+
+func cmdChangeListCreate(c *cli.Context) error {
+	ctx, _, dnsClient, zonename, err := changeListInit(c)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Creating change list for %s...", zonename))
+	if err := dnsClient.SaveChangeList(ctx, dns.SaveChangeListRequest{Zone: zonename}); err != nil {
+		var dnsErr *dns.Error
+		if errors.As(err, &dnsErr) && dnsErr.StatusCode == http.StatusConflict {
+			return newCommandError(exitConflict, "Zone %s already has a change list. Use 'changelist show' or 'changelist discard'", zonename)
+		}
+		return apiError(err, "Change list create failed: %v", err)
+	}
+
+	cl, err := dnsClient.GetChangeList(ctx, dns.GetChangeListRequest{Zone: zonename})
+	if err != nil {
+		return changeListError(zonename, "retrieval", err)
+	}
+	return printChangeList(c, &ChangeListView{GetChangeListResponse: *cl})
+}
+
+func cmdChangeListShow(c *cli.Context) error {
+	ctx, sess, dnsClient, zonename, err := changeListInit(c)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving change list..."))
+	cl, err := dnsClient.GetChangeList(ctx, dns.GetChangeListRequest{Zone: zonename})
+	if err != nil {
+		return changeListError(zonename, "retrieval", err)
+	}
+	view := &ChangeListView{GetChangeListResponse: *cl}
+	if !c.Bool("summary") {
+		view.RecordSets, err = getChangeListRecordSets(ctx, sess, zonename)
+		if err != nil {
+			return changeListError(zonename, "recordsets retrieval", err)
+		}
+	}
+	return printChangeList(c, view)
+}
+
+func printChangeList(c *cli.Context, view *ChangeListView) error {
+	return writeOutput(c, &CommandOutput{
+		Value: view,
+		Table: func() string { return renderChangeListTable(view) },
+	})
+}
+
+func cmdChangeListDiff(c *cli.Context) error {
+	ctx, sess, dnsClient, zonename, err := changeListInit(c)
+	if err != nil {
+		return err
+	}
+
+	current, staged, err := changeListRecordSets(ctx, sess, dnsClient, zonename)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(c, zonePlanOutput(zonename, diffRecordSets(current, staged)))
+}
+
+// Live zone recordsets and the recordsets staged in its change list
+func changeListRecordSets(ctx context.Context, sess session.Session, dnsClient dns.DNS, zonename string) ([]dns.RecordSet, []dns.RecordSet, error) {
+	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving change list recordsets..."))
+	staged, err := getChangeListRecordSets(ctx, sess, zonename)
+	if err != nil {
+		return nil, nil, changeListError(zonename, "recordsets retrieval", err)
+	}
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving zone recordsets..."))
+	resp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+		Zone:      zonename,
+		QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+	})
+	if err != nil {
+		return nil, nil, apiError(err, "Recordset list retrieval failed: %v", err)
+	}
+	return resp.RecordSets, staged, nil
+}
+
+func cmdChangeListAdd(c *cli.Context) error {
+	op := strings.ToUpper(c.String("op"))
+	if op != changeListOpAdd && op != changeListOpEdit && op != changeListOpDelete {
+		return newCommandError(exitValidation, "op must be one of add, edit or delete")
+	}
+	if !c.IsSet("name") || !c.IsSet("type") {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "--name and --type are required")
+	}
+	if op != changeListOpDelete && (!c.IsSet("ttl") || !c.IsSet("rdata")) {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "--ttl and --rdata are required to %s a recordset", strings.ToLower(op))
+	}
+
+	ctx, sess, dnsClient, zonename, err := changeListInit(c)
+	if err != nil {
+		return err
+	}
+
+	// Relative names are qualified with the zone; names in another zone are rejected
+	name, err := qualifyRecordName(c.String("name"), zonename, []string{zonename})
+	if err != nil {
+		return err
+	}
+
+	change := ChangeListChange{
+		Name: name,
+		Type: strings.ToUpper(c.String("type")),
+		Op:   op,
+	}
+	if op != changeListOpDelete {
+		change.TTL = c.Int("ttl")
+		change.Rdata = c.StringSlice("rdata")
+	}
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Staging %s %s %s...", strings.ToLower(op), change.Name, change.Type))
+	if err := addChangeListChange(ctx, sess, zonename, change); err != nil {
+		return changeListError(zonename, "change", err)
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("Change staged in the change list for %s", zonename))
+
+	cl, err := dnsClient.GetChangeList(ctx, dns.GetChangeListRequest{Zone: zonename})
+	if err != nil {
+		return changeListError(zonename, "retrieval", err)
+	}
+	view := &ChangeListView{GetChangeListResponse: *cl}
+	view.RecordSets, err = getChangeListRecordSets(ctx, sess, zonename)
+	if err != nil {
+		return changeListError(zonename, "recordsets retrieval", err)
+	}
+	return printChangeList(c, view)
+}
+
+func cmdChangeListSubmit(c *cli.Context) error {
+	ctx, sess, dnsClient, zonename, err := changeListInit(c)
+	if err != nil {
+		return err
+	}
+
+	cl, err := dnsClient.GetChangeList(ctx, dns.GetChangeListRequest{Zone: zonename})
+	if err != nil {
+		return changeListError(zonename, "retrieval", err)
+	}
+	if cl.Stale {
+		return newCommandError(exitConflict, "Change list for %s is stale; the zone changed since it was created. Discard and recreate it", zonename)
+	}
+
+	current, staged, err := changeListRecordSets(ctx, sess, dnsClient, zonename)
+	if err != nil {
+		return err
+	}
+	if err := preflightLint(c, zonename, current, staged); err != nil {
+		return err
+	}
+
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+		return err
+	}
+	defer zoneCache.invalidate(zonename)
+
+	changes := diffRecordSets(current, staged)
+	added, removed, changed := summarizeChanges(changes)
+	fmt.Fprintln(os.Stderr, color.BlueString("Submitting change list: %d added, %d removed, %d changed...", added, removed, changed))
+	if err := dnsClient.SubmitChangeList(ctx, dns.SubmitChangeListRequest{Zone: zonename}); err != nil {
+		return changeListError(zonename, "submit", err)
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("Change list for %s submitted", zonename))
+	return writeOutput(c, zonePlanOutput(zonename, changes))
+}
+
+func cmdChangeListDiscard(c *cli.Context) error {
+	ctx, sess, dnsClient, zonename, err := changeListInit(c)
+	if err != nil {
+		return err
+	}
+
+	// The discarded change list is the command result
+	cl, err := dnsClient.GetChangeList(ctx, dns.GetChangeListRequest{Zone: zonename})
+	if err != nil {
+		return changeListError(zonename, "retrieval", err)
+	}
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Discarding change list for %s...", zonename))
+	if err := discardChangeList(ctx, sess, zonename); err != nil {
+		return changeListError(zonename, "discard", err)
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("Change list for %s discarded", zonename))
+	return printChangeList(c, &ChangeListView{GetChangeListResponse: *cl})
+}
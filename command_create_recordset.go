@@ -25,7 +25,6 @@ import (
 	"github.com/akamai/cli-dns/edgegrid"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
-	"github.com/fatih/color"
 	"github.com/urfave/cli"
 )
 
@@ -33,7 +32,7 @@ func cmdCreateRecordset(c *cli.Context) error {
 	//Validate zone name argument
 	if c.NArg() == 0 {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 
 	//Initialize Edgegrid session and DNS client
@@ -41,41 +40,34 @@ func cmdCreateRecordset(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	var (
-		zonename   string
-		outputPath string
-		inputPath  string
+		zonename  string
+		inputPath string
 		// json
 		// suppress
 	)
 
 	zonename = c.Args().First()
 	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
 	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and cannot have recordsets", zonename)), 1)
+		return newCommandError(exitValidation, "Zone %s is an ALIAS zone and cannot have recordsets", zonename)
 	}
 
-	// Get input and output file paths if set
+	// Get input file path if set
 	if c.IsSet("file") {
 		inputPath = c.String("file")
 		inputPath = filepath.FromSlash(inputPath)
 	}
-	if c.IsSet("output") {
-		outputPath = c.String("output")
-		outputPath = filepath.FromSlash(outputPath)
-	}
-	fmt.Println("Preparing recordset ", "")
+	fmt.Fprintln(os.Stderr, "Preparing recordset")
 
 	newrecord := &dns.RecordBody{}
 
@@ -83,12 +75,12 @@ func cmdCreateRecordset(c *cli.Context) error {
 	if c.IsSet("file") {
 		data, err := os.ReadFile(filepath.FromSlash(inputPath))
 		if err != nil {
-			return cli.NewExitError(color.RedString("Failed to read input file"), 1)
+			return newCommandError(exitValidation, "Failed to read input file")
 		}
 		recordset := &dns.RecordSet{}
 		err = json.Unmarshal(data, recordset)
 		if err != nil {
-			return cli.NewExitError(color.RedString("Failed to parse json file content into recordset"), 1)
+			return newCommandError(exitValidation, "Failed to parse json file content into recordset")
 		}
 		newrecord.Name = recordset.Name
 		newrecord.RecordType = recordset.Type
@@ -99,7 +91,7 @@ func cmdCreateRecordset(c *cli.Context) error {
 	} else if c.IsSet("type") {
 		if !c.IsSet("name") || !c.IsSet("ttl") || !c.IsSet("rdata") {
 			cli.ShowCommandHelp(c, c.Command.Name)
-			return cli.NewExitError(color.RedString("Field flags missing for recordset creation"), 1)
+			return newCommandError(exitValidation, "Field flags missing for recordset creation")
 		}
 		newrecord.RecordType = strings.ToUpper(c.String("type"))
 		newrecord.Name = c.String("name")
@@ -107,7 +99,7 @@ func cmdCreateRecordset(c *cli.Context) error {
 		newrecord.Target = c.StringSlice("rdata")
 	} else {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("Recordset field values or input file are required"), 1)
+		return newCommandError(exitValidation, "Recordset field values or input file are required")
 	}
 
 	// Check if record already exists
@@ -117,68 +109,31 @@ func cmdCreateRecordset(c *cli.Context) error {
 		Name:       newrecord.Name,
 	})
 	if err == nil && existing.RecordType != "" {
-		return cli.NewExitError(color.RedString("Recordset already exists"), 1)
+		return newCommandError(exitConflict, "Recordset already exists")
 	} /*else {
 		if !dns.ConfigDNSError() || !err.(dns.ConfigDNSError).NotFound() {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failure while checking recordset existance. Error: %s", err.Error())), 1)
+			return apiError(err, "Failure while checking recordset existance. Error: %s", err)
 		}
 	}*/
 
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+		return err
+	}
+	defer zoneCache.invalidate(zonename)
+
 	// Create new recordset
-	fmt.Println("Creating Recordset  ", "")
+	fmt.Fprintln(os.Stderr, "Creating Recordset")
 	err = dnsClient.CreateRecord(ctx, dns.CreateRecordRequest{Zone: zonename, Record: newrecord})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset create failed. Error: %s", err)), 1)
+		return apiError(err, "Recordset create failed. Error: %s", err)
 	}
 
 	// Retrieve recordset after creation
-	fmt.Println("Verifying Recordset  ", "")
+	fmt.Fprintln(os.Stderr, "Verifying Recordset")
 	record, err := dnsClient.GetRecord(ctx, dns.GetRecordRequest{Zone: zonename, RecordType: newrecord.RecordType, Name: newrecord.Name})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to read recordset content. Error: %s", err.Error())), 1)
-	}
-
-	if c.IsSet("suppress") && c.Bool("suppress") {
-		return nil
-	}
-	results := ""
-	fmt.Println(color.BlueString("Assembling recordset Content... ", ""))
-
-	// Format output as JSON or table
-	if c.IsSet("json") && c.Bool("json") {
-		recordset := &dns.RecordSet{}
-		recordset.Name = record.Name
-		recordset.Type = record.RecordType
-		recordset.TTL = record.TTL
-		recordset.Rdata = record.Target
-		zjson, err := json.MarshalIndent(recordset, "", "  ")
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to marshal recordset"), 1)
-		}
-		results = string(zjson)
-	} else {
-		results = renderRecordsetTable(zonename, record)
-	}
-
-	// Write to file if output path is specified
-	if len(outputPath) > 1 {
-		//fmt.Println(color.GreenString("Writing Output to %s", outputPath))
-		rsHandle, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to create output file. Error: %s", err.Error())), 1)
-		}
-		defer rsHandle.Close()
-		_, err = rsHandle.WriteString(string(results))
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to write zone output to file"), 1)
-		}
-		rsHandle.Sync()
-		fmt.Println(color.GreenString("Output written to %s", outputPath))
-		return nil
-	} else {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, results)
+		return apiError(err, "Failed to read recordset content. Error: %s", err)
 	}
 
-	return nil
+	return writeOutput(c, recordsetOutput(zonename, record))
 }
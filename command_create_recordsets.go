@@ -36,53 +36,46 @@ func cmdCreateRecordsets(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	var (
-		zonename   string
-		outputPath string
-		inputPath  string
+		zonename  string
+		inputPath string
 	)
 
 	// Validate zone name argument
 	if c.NArg() == 0 {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 
 	zonename = c.Args().First()
 
 	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
 	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and cannot have recordsets", zonename)), 1)
+		return newCommandError(exitValidation, "Zone %s is an ALIAS zone and cannot have recordsets", zonename)
 	}
 
-	// Get input and output file paths if set
-	if c.IsSet("output") {
-		outputPath = c.String("output")
-		outputPath = filepath.FromSlash(outputPath)
-	}
+	// Get input file path if set
 	if c.IsSet("file") {
 		inputPath = c.String("file")
 		inputPath = filepath.FromSlash(inputPath)
 	} else {
-		return cli.NewExitError(color.RedString("Input file is required"), 1)
+		return newCommandError(exitValidation, "Input file is required")
 	}
-	fmt.Println("Fetching Recordset data ", "")
+	fmt.Fprintln(os.Stderr, "Fetching Recordset data")
 
 	// Read and parse input JSON file
 	data, err := os.ReadFile(inputPath)
 	if err != nil {
-		return cli.NewExitError(color.RedString("Failed to read input file"), 1)
+		return newCommandError(exitValidation, "Failed to read input file")
 	}
 
 	var wrapper struct {
@@ -90,8 +83,13 @@ func cmdCreateRecordsets(c *cli.Context) error {
 	}
 	err = json.Unmarshal(data, &wrapper)
 	if err != nil {
-		return cli.NewExitError(color.RedString("Failed to parse json file content: %s", err), 1)
+		return newCommandError(exitValidation, "Failed to parse json file content: %s", err)
+	}
+
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+		return err
 	}
+	defer zoneCache.invalidate(zonename)
 
 	// Create multiple recordsets
 	req := dns.CreateRecordSetsRequest{
@@ -102,58 +100,24 @@ func cmdCreateRecordsets(c *cli.Context) error {
 	}
 
 	if err := dnsClient.CreateRecordSets(ctx, req); err != nil {
-		return cli.NewExitError(fmt.Sprintf("Failed to create recordset: %v", err), 1)
+		return apiError(err, "Failed to create recordset: %v", err)
 	}
 
-	fmt.Println("Creating Recordsets ", "")
-
-	if c.IsSet("suppress") && c.Bool("suppress") {
+	fmt.Fprintln(os.Stderr, "Creating Recordsets")
 
+	if c.Bool("suppress") {
 		return nil
-
 	}
 
 	// Retrieve updated list of recordsets
-	fmt.Println(color.BlueString("Retrieving Full Recordsets List... ", ""))
+	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving Full Recordsets List..."))
 	resp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{Zone: zonename, QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true}})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset List retrieval failed. Error: %s", err)), 1)
+		return apiError(err, "Recordset List retrieval failed. Error: %s", err)
 	}
-
-	// Format recordsets for output
-	recordsetList := RecordsetList{Recordsets: resp.RecordSets}
-	results := ""
-	fmt.Println(color.BlueString("Assembling Recordsets List... ", ""))
-	if c.IsSet("json") && c.Bool("json") {
-		rjson, err := json.MarshalIndent(recordsetList, "", "  ")
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to display recordsets list"), 1)
-		}
-		results = string(rjson)
-	} else {
-		results = renderRecordsetListTable(zonename, recordsetList.Recordsets)
-	}
-
-	// Write to file if output path is specified or print to stdout
-	if len(outputPath) > 1 {
-		//fmt.Println(color.GreenString("Writing Output to %s", outputPath))
-		rlfHandle, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to create output file. Error: %s", err.Error())), 1)
-		}
-		defer rlfHandle.Close()
-		_, err = rlfHandle.WriteString(string(results))
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to write zone list output to file"), 1)
-		}
-		rlfHandle.Sync()
-		fmt.Println(color.GreenString("Output written to %s", outputPath))
-		return nil
-	} else {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, results)
+	zone, err := zoneDetails(ctx, c, dnsClient, zoneResp)
+	if err != nil {
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
-
-	return nil
-
+	return writeOutput(c, recordsetListOutput(zonename, zone, resp.RecordSets))
 }
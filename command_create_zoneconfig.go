@@ -18,15 +18,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/akamai/cli-dns/edgegrid"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
-	"github.com/fatih/color"
 	"github.com/urfave/cli"
 )
 
@@ -35,7 +32,7 @@ func cmdCreateZoneconfig(c *cli.Context) error {
 	// Validate zonename argument
 	if c.NArg() == 0 {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 
 	// Initialize context and Edgegrid session
@@ -44,7 +41,7 @@ func cmdCreateZoneconfig(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
@@ -52,9 +49,8 @@ func cmdCreateZoneconfig(c *cli.Context) error {
 	// Parse Flags
 	var (
 		inputPath  = c.String("file")
-		outputPath = c.String("output")
-		contractID = c.String("contractid")
-		groupID    = c.String("groupid")
+		contractID = contractIDFlag(c)
+		groupID    = groupIDFlag(c)
 	)
 
 	newZone := &dns.ZoneCreate{}
@@ -63,10 +59,10 @@ func cmdCreateZoneconfig(c *cli.Context) error {
 	if inputPath != "" {
 		data, err := os.ReadFile(inputPath)
 		if err != nil {
-			return cli.NewExitError(color.RedString("failed to read input file"), 1)
+			return newCommandError(exitValidation, "failed to read input file")
 		}
 		if err := json.Unmarshal(data, newZone); err != nil {
-			return cli.NewExitError(color.RedString("failed to parse JSON config"), 1)
+			return newCommandError(exitValidation, "failed to parse JSON config")
 		}
 		//fmt.Printf("Debug: ContractID from JSON: '%s'\n", newZone.ContractID)
 
@@ -80,7 +76,7 @@ func cmdCreateZoneconfig(c *cli.Context) error {
 		newZone.Zone = zonename
 		newZone.Type = strings.ToUpper(c.String("type"))
 		newZone.Comment = c.String("comment")
-		newZone.ContractID = c.String("contractid")
+		newZone.ContractID = contractID
 		if c.IsSet("master") {
 			newZone.Masters = c.StringSlice("master")
 		}
@@ -103,86 +99,59 @@ func cmdCreateZoneconfig(c *cli.Context) error {
 		}
 	} else {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zone command line values or input file are required"), 1)
+		return newCommandError(exitValidation, "zone command line values or input file are required")
 	}
 
 	if contractID == "" {
-		return cli.NewExitError(color.RedString("contractid is required"), 1)
+		return newCommandError(exitValidation, "contractid is required")
 	}
 
 	err = dns.ValidateZone(newZone)
 	if err != nil {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Invalid zone value: %s", err)), 1)
+		return newCommandError(exitValidation, "Invalid zone value: %s", err)
 	}
 
 	// Check if zone already exists
 	_, err = dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zonename})
 	if err == nil {
-		return cli.NewExitError(color.RedString("zone already exists"), 1)
+		return newCommandError(exitConflict, "zone already exists")
 	} else {
 		if errors.Is(err, dns.ErrGetZone) {
-			return cli.NewExitError(color.RedString("failure while checking zone existance"), 1)
+			return newCommandError(exitError, "failure while checking zone existance")
 		}
 	}
 
 	// Create new zone
+	defer zoneCache.invalidate(zonename)
 	err = dnsClient.CreateZone(ctx, dns.CreateZoneRequest{
 		CreateZone:      newZone,
 		ZoneQueryString: dns.ZoneQueryString{Contract: contractID, Group: groupID},
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString("zone create failed: %s", err), 1)
+		return apiError(err, "zone create failed: %s", err)
 	}
 
 	// Optionally initialize zone with default records
 	if c.Bool("initialize") && strings.ToUpper(newZone.Type) == "PRIMARY" {
 		err = dnsClient.SaveChangeList(ctx, dns.SaveChangeListRequest{Zone: zonename})
 		if err != nil {
-			return cli.NewExitError(color.RedString("failed to initialize zone records"), 1)
+			return newCommandError(exitError, "failed to initialize zone records")
 		}
 		err = dnsClient.SubmitChangeList(ctx, dns.SubmitChangeListRequest{Zone: zonename})
 		if err != nil {
-			return cli.NewExitError(color.RedString("failed to initialize zone records during submit changelist "), 1)
+			return newCommandError(exitError, "failed to initialize zone records during submit changelist ")
 		}
 	}
 
 	// Fetch zone after creation
 	zone, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zonename})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("failed to read zone config: %v", err)), 1)
+		return apiError(err, "failed to read zone config: %v", err)
 	}
 
-	if c.Bool("suppress") {
-		return nil
-	}
-
-	// Format result for display
-	var result string
-	if c.Bool("json") {
-		b, err := json.MarshalIndent(zone.Zone, "", " ")
-		if err != nil {
-			return cli.NewExitError(color.RedString("failed to marshal zone output"), 1)
-		}
-		result = string(b)
-	} else {
-		result = renderZoneconfigTable(zone, c)
-	}
-
-	// Output to file or stdout
-	if outputPath != "" {
-		f, err := os.Create(filepath.FromSlash(outputPath))
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("failed to write output file: %v", err)), 1)
-		}
-		defer f.Close()
-		f.WriteString(result)
-		f.Sync()
-		fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
-	} else {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, result)
-	}
-
-	return nil
+	return writeOutput(c, &CommandOutput{
+		Value: zone,
+		Table: func() string { return renderZoneconfigTable(zone, c) },
+	})
 }
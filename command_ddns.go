@@ -0,0 +1,399 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/urfave/cli"
+)
+
+const (
+	defaultDDNSIPv4URL = "https://api.ipify.org"
+	defaultDDNSIPv6URL = "https://api6.ipify.org"
+)
+
+// DDNSState is the last address published for each record type, kept in the
+// --state-file so a restart does not need to read the recordsets again
+type DDNSState struct {
+	Zone      string            `json:"zone"`
+	Name      string            `json:"name"`
+	Addresses map[string]string `json:"addresses"`
+	Updated   string            `json:"updated,omitempty"`
+}
+
+// DDNSHealth is the body of the health endpoint
+type DDNSHealth struct {
+	Status      string            `json:"status"`
+	Zone        string            `json:"zone"`
+	Name        string            `json:"name"`
+	Addresses   map[string]string `json:"addresses,omitempty"`
+	LastCheck   string            `json:"lastCheck,omitempty"`
+	LastSuccess string            `json:"lastSuccess,omitempty"`
+	LastUpdate  string            `json:"lastUpdate,omitempty"`
+	LastError   string            `json:"lastError,omitempty"`
+}
+
+// ddnsUpdater keeps one recordset name pointed at the detected public address
+type ddnsUpdater struct {
+	dnsClient dns.DNS
+	zone      string
+	name      string
+	types     []string
+	ttl       int
+	iface     string
+	urls      map[string]string
+	stateFile string
+	log       *slog.Logger
+	http      *http.Client
+
+	mu     sync.Mutex
+	state  DDNSState
+	health DDNSHealth
+}
+
+func cmdDDNS(c *cli.Context) error {
+	zonename := strings.ToLower(strings.TrimSuffix(c.String("zone"), "."))
+	if zonename == "" || c.String("name") == "" {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "--zone and --name are required")
+	}
+	name, err := qualifyRecordName(strings.ToLower(c.String("name")), zonename, []string{zonename})
+	if err != nil {
+		return err
+	}
+
+	types := []string{}
+	for _, t := range c.StringSlice("type") {
+		for _, t := range strings.Split(t, ",") {
+			t = strings.ToUpper(strings.TrimSpace(t))
+			if t != "A" && t != "AAAA" {
+				return newCommandError(exitValidation, "--type must be A, AAAA or both")
+			}
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 {
+		types = []string{"A"}
+	}
+	interval, jitter := c.Duration("interval"), c.Duration("jitter")
+	if interval <= 0 || jitter < 0 {
+		return newCommandError(exitValidation, "--interval must be positive and --jitter must not be negative")
+	}
+	logger, err := commandLogger(c.String("log-format"))
+	if err != nil {
+		return wrapError(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+	zone, err := getZoneInfo(ctx, dnsClient, zonename)
+	if err != nil {
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
+	}
+	if strings.EqualFold(zone.Type, "ALIAS") {
+		return newCommandError(exitValidation, "Zone %s is an ALIAS zone and cannot have recordsets", zonename)
+	}
+
+	u := &ddnsUpdater{
+		dnsClient: dnsClient,
+		zone:      zonename,
+		name:      name,
+		types:     types,
+		ttl:       c.Int("ttl"),
+		iface:     c.String("interface"),
+		urls:      map[string]string{"A": c.String("ipv4-url"), "AAAA": c.String("ipv6-url")},
+		stateFile: c.String("state-file"),
+		log:       logger.With("zone", zonename, "name", name),
+		http:      &http.Client{Timeout: 10 * time.Second},
+		health:    DDNSHealth{Status: "starting", Zone: zonename, Name: name},
+	}
+	if err := u.loadState(); err != nil {
+		return newCommandError(exitValidation, "%v", err)
+	}
+
+	if addr := c.String("health-listen"); addr != "" && !c.Bool("once") {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return newCommandError(exitError, "Failed to listen on %s: %v", addr, err)
+		}
+		server := &http.Server{Handler: u, ReadHeaderTimeout: 10 * time.Second}
+		go server.Serve(listener)
+		defer server.Close()
+		u.log.Info("health endpoint listening", "address", "http://"+listener.Addr().String()+"/healthz")
+	}
+
+	u.log.Info("ddns started", "types", strings.Join(types, ","), "interval", interval.String(), "jitter", jitter.String())
+	for {
+		err := u.check(ctx)
+		if c.Bool("once") {
+			if err != nil {
+				return wrapError(err)
+			}
+			return nil
+		}
+
+		wait := interval
+		if jitter > 0 {
+			wait += rand.N(jitter)
+		}
+		select {
+		case <-ctx.Done():
+			u.log.Info("ddns stopped")
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Logger writing key=value text or JSON lines to STDERR
+func commandLogger(format string) (*slog.Logger, error) {
+	switch strings.ToLower(format) {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, nil)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil)), nil
+	}
+	return nil, newCommandError(exitValidation, "--log-format must be one of text or json")
+}
+
+// Detect the address of each type and publish the ones that changed. Errors are
+// logged and returned; the daemon carries on with the next check.
+func (u *ddnsUpdater) check(ctx context.Context) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	var errs []error
+	for _, rtype := range u.types {
+		if err := u.checkType(ctx, rtype); err != nil {
+			u.log.Error("check failed", "type", rtype, "error", err.Error())
+			errs = append(errs, fmt.Errorf("%s: %w", rtype, err))
+		}
+	}
+	err := errors.Join(errs...)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.health.LastCheck = now
+	if err != nil {
+		u.health.Status, u.health.LastError = "error", err.Error()
+	} else {
+		u.health.Status, u.health.LastError, u.health.LastSuccess = "ok", "", now
+	}
+	return err
+}
+
+func (u *ddnsUpdater) checkType(ctx context.Context, rtype string) error {
+	addr, err := u.detect(ctx, rtype)
+	if err != nil {
+		return err
+	}
+	if u.published(rtype) == addr {
+		u.log.Debug("address unchanged", "type", rtype, "address", addr)
+		return nil
+	}
+
+	// The state file may be missing or stale, so the live recordset decides
+	existing, err := u.dnsClient.GetRecord(ctx, dns.GetRecordRequest{Zone: u.zone, Name: u.name, RecordType: rtype})
+	var dnsErr *dns.Error
+	switch {
+	case err == nil && len(existing.Target) == 1 && existing.Target[0] == addr && existing.TTL == u.ttl:
+		u.log.Info("record already current", "type", rtype, "address", addr)
+		return u.setPublished(rtype, addr, false)
+	case err == nil:
+		defer zoneCache.invalidate(u.zone)
+		err = u.dnsClient.UpdateRecord(ctx, dns.UpdateRecordRequest{
+			Zone:   u.zone,
+			Record: &dns.RecordBody{Name: u.name, RecordType: rtype, TTL: u.ttl, Target: []string{addr}},
+		})
+		if err != nil {
+			return fmt.Errorf("recordset update failed: %w", err)
+		}
+		u.log.Info("record updated", "type", rtype, "old", strings.Join(existing.Target, ","), "address", addr)
+	case errors.As(err, &dnsErr) && dnsErr.StatusCode == http.StatusNotFound:
+		defer zoneCache.invalidate(u.zone)
+		err = u.dnsClient.CreateRecord(ctx, dns.CreateRecordRequest{
+			Zone:   u.zone,
+			Record: &dns.RecordBody{Name: u.name, RecordType: rtype, TTL: u.ttl, Target: []string{addr}},
+		})
+		if err != nil {
+			return fmt.Errorf("recordset create failed: %w", err)
+		}
+		u.log.Info("record created", "type", rtype, "address", addr)
+	default:
+		return fmt.Errorf("recordset retrieval failed: %w", err)
+	}
+	return u.setPublished(rtype, addr, true)
+}
+
+// Public address of a record type, from --interface when given, else from the
+// type's address URL
+func (u *ddnsUpdater) detect(ctx context.Context, rtype string) (string, error) {
+	if u.iface != "" {
+		return interfaceAddress(u.iface, rtype)
+	}
+	url := u.urls[rtype]
+	if url == "" {
+		return "", fmt.Errorf("no address URL for %s records", rtype)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := u.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("address lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if err != nil {
+		return "", fmt.Errorf("address lookup failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("address lookup failed: %s returned %s", url, resp.Status)
+	}
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil || (ip.To4() != nil) != (rtype == "A") {
+		return "", fmt.Errorf("%s did not return an %s address", url, ddnsFamily(rtype))
+	}
+	return ip.String(), nil
+}
+
+// First global unicast address of the type's family on a local interface
+func interfaceAddress(name, rtype string) (string, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return "", err
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the addresses of %s: %w", name, err)
+	}
+	for _, a := range addrs {
+		ipnet, ok := a.(*net.IPNet)
+		if !ok || !ipnet.IP.IsGlobalUnicast() || (ipnet.IP.To4() != nil) != (rtype == "A") {
+			continue
+		}
+		return ipnet.IP.String(), nil
+	}
+	return "", fmt.Errorf("interface %s has no %s address", name, ddnsFamily(rtype))
+}
+
+func ddnsFamily(rtype string) string {
+	if rtype == "A" {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+func (u *ddnsUpdater) published(rtype string) string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.state.Addresses[rtype]
+}
+
+// Record the published address for the health endpoint and the state file
+func (u *ddnsUpdater) setPublished(rtype, addr string, updated bool) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.state.Addresses[rtype] = addr
+	u.health.Addresses = u.state.Addresses
+	if updated {
+		u.state.Updated = time.Now().UTC().Format(time.RFC3339)
+		u.health.LastUpdate = u.state.Updated
+	}
+	if u.stateFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(u.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(u.stateFile, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// Load the state file. A missing file, or one written for another record, is
+// an empty state.
+func (u *ddnsUpdater) loadState() error {
+	u.state = DDNSState{Zone: u.zone, Name: u.name, Addresses: map[string]string{}}
+	if u.stateFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(u.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state file: %w", err)
+	}
+	var state DDNSState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid state file %s: %w", u.stateFile, err)
+	}
+	if state.Zone != u.zone || state.Name != u.name || state.Addresses == nil {
+		u.log.Warn("ignoring state file written for another record", "file", u.stateFile)
+		return nil
+	}
+	u.state = state
+	u.health.Addresses = state.Addresses
+	u.health.LastUpdate = state.Updated
+	return nil
+}
+
+// Health endpoint: 200 while the last check succeeded, 503 before the first
+// check and after a failed one
+func (u *ddnsUpdater) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/healthz" {
+		http.NotFound(w, r)
+		return
+	}
+	u.mu.Lock()
+	health := u.health
+	health.Addresses = make(map[string]string, len(u.health.Addresses))
+	for k, v := range u.health.Addresses {
+		health.Addresses[k] = v
+	}
+	u.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if health.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(health)
+}
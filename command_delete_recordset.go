@@ -17,6 +17,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
@@ -32,7 +33,7 @@ func cmdDeleteRecordset(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
@@ -40,40 +41,43 @@ func cmdDeleteRecordset(c *cli.Context) error {
 	// Validate zonename argument
 	if c.NArg() == 0 {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 	zonename := c.Args().First()
 
 	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
 	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and cannot have recordsets", zonename)), 1)
+		return newCommandError(exitValidation, "Zone %s is an ALIAS zone and cannot have recordsets", zonename)
 	}
 
 	// Validate required flags
 	if !c.IsSet("name") || !c.IsSet("type") {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("Recordset name and type field values are required"), 1)
+		return newCommandError(exitValidation, "Recordset name and type field values are required")
 	}
 	recordType := c.String("type")
 	recordName := c.String("name")
 
 	// Check if recordset exists
-	fmt.Println("Checking Recordset existance  ", "")
+	fmt.Fprintln(os.Stderr, "Checking Recordset existence")
 
-	_, err = dnsClient.GetRecord(ctx, dns.GetRecordRequest{
+	record, err := dnsClient.GetRecord(ctx, dns.GetRecordRequest{
 		Zone:       zonename,
 		Name:       recordName,
 		RecordType: recordType,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failure retrieving recordset. Error: %s", err)), 1)
+		return apiError(err, "Failure retrieving recordset. Error: %s", err)
+	}
+
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+		return err
 	}
+	defer zoneCache.invalidate(zonename)
 
 	// Delete recordset
 	err = dnsClient.DeleteRecord(ctx, dns.DeleteRecordRequest{
@@ -82,9 +86,11 @@ func cmdDeleteRecordset(c *cli.Context) error {
 		RecordType: recordType,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("failed to delete record: %s", err)), 1)
+		return apiError(err, "failed to delete record: %s", err)
 	}
 
-	fmt.Println(color.GreenString("Record Deleted Successfully"))
-	return nil
+	fmt.Fprintln(os.Stderr, color.GreenString("Record Deleted Successfully"))
+
+	// The result is the recordset as it was before the delete
+	return writeOutput(c, recordsetOutput(zonename, record))
 }
@@ -0,0 +1,83 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+func cmdDiffZone(c *cli.Context) error {
+
+	// Validate zonename argument
+	if c.NArg() == 0 {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "zonename is required")
+	}
+	zonename := c.Args().First()
+
+	// Initialize context and Edgegrid session
+	ctx := context.Background()
+
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+	// Check if the zone is an ALIAS zone
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
+	if err != nil {
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
+	}
+	if strings.EqualFold(zoneResp.Type, "ALIAS") {
+		return newCommandError(exitValidation, "Zone %s is an ALIAS zone and does not have recordsets", zonename)
+	}
+
+	inputRecordSets, err := loadZoneInput(c, zonename)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	// A master zone file replaces the zone unless it is merged
+	overwrite := c.Bool("overwrite") || (c.Bool("dns") && !c.Bool("merge"))
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving Existing Recordsets..."))
+	_, _, changes, err := planZoneUpdate(ctx, dnsClient, zonename, inputRecordSets, overwrite)
+	if err != nil {
+		return apiError(err, "Recordset list retrieval failed: %v", err)
+	}
+
+	return writeOutput(c, zonePlanOutput(zonename, changes))
+}
+
+// Command output for a zone plan, with unified text as an extra format
+func zonePlanOutput(zonename string, changes []RecordsetChange) *CommandOutput {
+	return &CommandOutput{
+		Value: ZonePlan{Zone: zonename, Changes: changes},
+		Table: func() string { return renderZonePlanTable(zonename, changes) },
+		Formats: map[string]func() (string, error){
+			"text": func() (string, error) { return renderZonePlanText(zonename, changes), nil },
+		},
+	}
+}
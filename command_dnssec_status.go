@@ -0,0 +1,227 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// DNSSecZoneStatus is the signing state of a zone and the DS records to publish
+type DNSSecZoneStatus struct {
+	Zone         string      `json:"zone"`
+	SignAndServe bool        `json:"signAndServe"`
+	Algorithm    string      `json:"signAndServeAlgorithm,omitempty"`
+	Keys         []DNSSecKey `json:"keys,omitempty"`
+	DSRecords    []string    `json:"dsRecords,omitempty"`
+	PendingDS    []string    `json:"pendingDsRecords,omitempty"`
+	Alerts       []string    `json:"alerts,omitempty"`
+	Nameserver   string      `json:"nameserver,omitempty"`
+	Expiration   *time.Time  `json:"signatureExpiration,omitempty"`
+	Expiring     bool        `json:"expiring,omitempty"`
+	Unknown      bool        `json:"expirationUnknown,omitempty"`
+}
+
+func cmdDNSSecStatus(c *cli.Context) error {
+
+	// Validate zonename arguments
+	if c.NArg() == 0 {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "at least one zonename is required")
+	}
+	zones := make([]string, 0, c.NArg())
+	for _, z := range c.Args() {
+		zones = append(zones, strings.ToLower(strings.TrimSuffix(z, ".")))
+	}
+
+	var window time.Duration
+	if c.IsSet("expiring-within") {
+		secs, ok := parseZoneTTL(c.String("expiring-within"))
+		if !ok {
+			return newCommandError(exitValidation, "Invalid --expiring-within value %q. Use a duration such as 30d or 2w", c.String("expiring-within"))
+		}
+		window = time.Duration(secs) * time.Second
+		if c.Bool("no-query") {
+			return newCommandError(exitValidation, "--expiring-within needs the name server query; it cannot be combined with --no-query")
+		}
+	}
+	timeout := time.Duration(c.Int("timeout")) * time.Second
+
+	// Initialize context and Edgegrid session
+	ctx := context.Background()
+
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving DNSSEC status..."))
+	statuses := make([]*DNSSecZoneStatus, 0, len(zones))
+	signed := []string{}
+	byZone := map[string]*DNSSecZoneStatus{}
+	for _, z := range zones {
+		zone, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: z})
+		if err != nil {
+			return apiError(err, "Failed to retrieve zone %s: %v", z, err)
+		}
+		st := &DNSSecZoneStatus{Zone: z, SignAndServe: zone.SignAndServe, Algorithm: zone.SignAndServeAlgorithm}
+		statuses = append(statuses, st)
+		byZone[z] = st
+		if zone.SignAndServe {
+			signed = append(signed, z)
+		}
+	}
+
+	if len(signed) > 0 {
+		resp, err := dnsClient.GetZonesDNSSecStatus(ctx, dns.GetZonesDNSSecStatusRequest{Zones: signed})
+		if err != nil {
+			return apiError(err, "DNSSEC status retrieval failed: %v", err)
+		}
+		for _, sec := range resp.DNSSecStatuses {
+			st, ok := byZone[strings.ToLower(strings.TrimSuffix(sec.Zone, "."))]
+			if !ok {
+				continue
+			}
+			if err := applySecStatus(st, sec); err != nil {
+				st.Alerts = append(st.Alerts, err.Error())
+			}
+		}
+	}
+
+	// Signature expirations come from the zone's authoritative name servers
+	if !c.Bool("no-query") {
+		for _, st := range statuses {
+			if !st.SignAndServe {
+				continue
+			}
+			servers := c.StringSlice("nameserver")
+			if len(servers) == 0 {
+				servers, err = dnsClient.GetRdata(ctx, dns.GetRdataRequest{Zone: st.Zone, Name: st.Zone, RecordType: "NS"})
+				if err != nil {
+					st.Alerts = append(st.Alerts, fmt.Sprintf("name server lookup failed: %v", err))
+					continue
+				}
+			}
+			queryZoneSignatures(st, servers, timeout)
+		}
+	}
+
+	// Flag zones with signatures expiring inside the window. A signed zone whose
+	// expiration could not be determined is reported too, so alerts fail closed.
+	expiring, unknown := 0, 0
+	if window > 0 {
+		deadline := time.Now().Add(window)
+		filtered := []*DNSSecZoneStatus{}
+		for _, st := range statuses {
+			switch {
+			case st.SignAndServe && st.Expiration == nil:
+				st.Unknown = true
+				unknown++
+				filtered = append(filtered, st)
+			case st.Expiration != nil && st.Expiration.Before(deadline):
+				st.Expiring = true
+				expiring++
+				filtered = append(filtered, st)
+			}
+		}
+		statuses = filtered
+	}
+
+	if err := writeOutput(c, &CommandOutput{
+		Value: statuses,
+		Table: func() string { return renderDNSSecStatusTable(statuses) },
+	}); err != nil {
+		return err
+	}
+
+	problems := []string{}
+	if expiring > 0 {
+		problems = append(problems, fmt.Sprintf("%d zone(s) have signatures expiring within %s", expiring, c.String("expiring-within")))
+	}
+	if unknown > 0 {
+		problems = append(problems, fmt.Sprintf("%d zone(s) have an unknown signature expiration", unknown))
+	}
+	if len(problems) > 0 {
+		return newCommandError(exitError, "%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Fill keys and DS records from the API DNSSEC status
+func applySecStatus(st *DNSSecZoneStatus, sec dns.SecStatus) error {
+	st.Alerts = append(st.Alerts, sec.Alerts...)
+	keys, err := parseDNSKeyRecords(sec.CurrentRecords.DNSKeyRecord)
+	if err != nil {
+		return err
+	}
+	st.Keys = keys
+	st.DSRecords = parseDSRecords(sec.CurrentRecords.DSRecord)
+
+	// New records are published ahead of a key rollover
+	if sec.NewRecords != nil {
+		pending, err := parseDNSKeyRecords(sec.NewRecords.DNSKeyRecord)
+		if err != nil {
+			return err
+		}
+		current := map[int]bool{}
+		for _, k := range keys {
+			current[k.KeyTag] = true
+		}
+		for _, k := range pending {
+			if !current[k.KeyTag] {
+				k.Pending = true
+				st.Keys = append(st.Keys, k)
+			}
+		}
+		st.PendingDS = parseDSRecords(sec.NewRecords.DSRecord)
+	}
+	return nil
+}
+
+// Query the name servers in turn until one answers and record per key expirations
+func queryZoneSignatures(st *DNSSecZoneStatus, servers []string, timeout time.Duration) {
+	var lastErr error
+	for _, server := range servers {
+		expirations, err := querySignatureExpirations(server, st.Zone, timeout)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		st.Nameserver = strings.TrimSuffix(server, ".")
+		for i := range st.Keys {
+			if exp, ok := expirations[st.Keys[i].KeyTag]; ok {
+				exp := exp
+				st.Keys[i].Expiration = &exp
+				if st.Expiration == nil || exp.Before(*st.Expiration) {
+					st.Expiration = &exp
+				}
+			}
+		}
+		return
+	}
+	if lastErr != nil {
+		st.Alerts = append(st.Alerts, fmt.Sprintf("signature query failed: %v", lastErr))
+	}
+}
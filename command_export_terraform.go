@@ -0,0 +1,220 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// terraformSource is a zone to export, from the API or a file
+type terraformSource struct {
+	Name       string
+	Zone       *dns.GetZoneResponse
+	RecordSets []dns.RecordSet
+}
+
+func cmdExportTerraform(c *cli.Context) error {
+	files := c.StringSlice("file")
+	if c.NArg() == 0 && len(files) == 0 && !c.IsSet("zones-file") && !c.IsSet("zone-search") {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "zonename is required")
+	}
+	if len(files) > 0 && c.IsSet("snapshot") {
+		return newCommandError(exitValidation, "--file and --snapshot cannot be combined")
+	}
+
+	var (
+		sources []terraformSource
+		err     error
+	)
+	switch {
+	case len(files) > 0:
+		if c.IsSet("zones-file") || c.IsSet("zone-search") {
+			return newCommandError(exitValidation, "--zones-file and --zone-search need the API; list the zones as arguments")
+		}
+		sources, err = terraformFileSources(files, c.Args())
+	case c.IsSet("snapshot"):
+		sources, err = terraformSnapshotSources(c)
+	default:
+		sources, err = terraformLiveSources(c)
+	}
+	if err != nil {
+		return wrapError(err)
+	}
+
+	filter := map[string]bool{}
+	for _, t := range c.StringSlice("filter") {
+		filter[strings.ToUpper(t)] = true
+	}
+	opts := TerraformExportOptions{
+		Group:   c.String("group"),
+		NoZone:  c.Bool("no-zone"),
+		Imports: !c.Bool("no-import"),
+	}
+	// The provider needs the group of every akamai_dns_zone resource
+	if opts.Group == "" && !opts.NoZone {
+		for _, src := range sources {
+			if src.Zone != nil {
+				return newCommandError(exitValidation, "--group is required to export zone resources; use --no-zone to export only the recordsets")
+			}
+		}
+	}
+
+	w := newTerraformWriter(opts)
+	exports := []ZoneExport{}
+	for _, src := range sources {
+		recordsets := []dns.RecordSet{}
+		for _, rs := range src.RecordSets {
+			if len(filter) == 0 || filter[strings.ToUpper(rs.Type)] {
+				recordsets = append(recordsets, rs)
+			}
+		}
+		w.addZone(src.Name, src.Zone, recordsets)
+		exports = append(exports, ZoneExport{Name: src.Name, Zone: src.Zone, RecordSets: recordsets})
+	}
+	hcl := strings.TrimSuffix(w.String(), "\n")
+	return writeOutput(c, &CommandOutput{
+		Value:   exports,
+		Table:   func() string { return hcl },
+		Formats: map[string]func() (string, error){formatHCL: func() (string, error) { return hcl, nil }},
+	})
+}
+
+// Zone configuration and recordsets from the API
+func terraformLiveSources(c *cli.Context) ([]terraformSource, error) {
+	ctx := context.Background()
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return nil, newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+	zones, err := zoneTargets(ctx, dnsClient, c, c.Args())
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving %d zone(s)...", len(zones)))
+	sources := []terraformSource{}
+	for _, zonename := range zones {
+		zone, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zonename})
+		if err != nil {
+			return nil, apiError(err, "Zone %s retrieval failed: %v", zonename, err)
+		}
+		src := terraformSource{Name: zonename, Zone: zone}
+		if hasRecordSets(zone.Type) {
+			resp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+				Zone:      zonename,
+				QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+			})
+			if err != nil {
+				return nil, apiError(err, "Recordset list retrieval failed for %s: %v", zonename, err)
+			}
+			src.RecordSets = resp.RecordSets
+		}
+		sources = append(sources, src)
+	}
+	return sources, nil
+}
+
+// Zones from saved snapshots: an id, latest, or a snapshot file per zone
+func terraformSnapshotSources(c *cli.Context) ([]terraformSource, error) {
+	if c.NArg() == 0 {
+		return nil, newCommandError(exitValidation, "--snapshot needs the zone names as arguments")
+	}
+	dir, err := snapshotDir(c)
+	if err != nil {
+		return nil, err
+	}
+	sources := []terraformSource{}
+	for _, zonename := range c.Args() {
+		snap, err := loadZoneSnapshot(dir, zonename, c.String("snapshot"))
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, terraformSource{Name: snap.Zone, Zone: snap.Config, RecordSets: snap.RecordSets})
+	}
+	return sources, nil
+}
+
+// terraformFile is any of the JSON files export-terraform reads: a snapshot,
+// retrieve-zone JSON output, or a recordsets file
+type terraformFile struct {
+	Zone       json.RawMessage      `json:"zone"`
+	Config     *dns.GetZoneResponse `json:"config"`
+	Records    []dns.RecordSet      `json:"records"`
+	RecordSets []dns.RecordSet      `json:"recordsets"`
+}
+
+// Zones from JSON files. A recordsets file carries no zone name, so it takes
+// the zone name argument in the same position.
+func terraformFileSources(files, args []string) ([]terraformSource, error) {
+	sources := []terraformSource{}
+	for i, path := range files {
+		data, err := os.ReadFile(filepath.FromSlash(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		var f terraformFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, newCommandError(exitValidation, "invalid JSON file %s: %v", path, err)
+		}
+
+		src := terraformSource{Zone: f.Config, RecordSets: f.RecordSets}
+		if f.Records != nil {
+			src.RecordSets = f.Records
+		}
+		var name string
+		if json.Unmarshal(f.Zone, &name) != nil && len(f.Zone) > 0 {
+			// retrieve-zone output holds the zone configuration under "zone"
+			zone := &dns.GetZoneResponse{}
+			if err := json.Unmarshal(f.Zone, zone); err != nil {
+				return nil, newCommandError(exitValidation, "invalid zone in %s: %v", path, err)
+			}
+			src.Zone, name = zone, zone.Zone
+		}
+		if name == "" && src.Zone != nil {
+			name = src.Zone.Zone
+		}
+		if i < len(args) {
+			if name != "" && !strings.EqualFold(normalizeDNSName(name), normalizeDNSName(args[i])) {
+				return nil, newCommandError(exitValidation, "%s is of zone %s, not %s", path, name, args[i])
+			}
+			name = args[i]
+		}
+		if name == "" {
+			return nil, newCommandError(exitValidation, "%s has no zone name; give it as an argument", path)
+		}
+		if src.Zone == nil && src.RecordSets == nil {
+			return nil, newCommandError(exitValidation, "%s holds neither a zone nor recordsets", path)
+		}
+		src.Name = name
+		sources = append(sources, src)
+	}
+	if len(args) > len(files) {
+		return nil, newCommandError(exitValidation, "more zone names than --file flags")
+	}
+	return sources, nil
+}
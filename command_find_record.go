@@ -0,0 +1,455 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// RecordMatch is a recordset found by find-record
+type RecordMatch struct {
+	Zone    string   `json:"zone"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     int      `json:"ttl"`
+	Rdata   []string `json:"rdata"`
+	Matched []string `json:"matched,omitempty"`
+}
+
+// RecordSearch holds the find-record criteria
+type RecordSearch struct {
+	Rdata     []string
+	Types     map[string]bool
+	NameRegex *regexp.Regexp
+}
+
+// Zone state kept from the search so a replacement can be planned without refetching
+type zoneSearchResult struct {
+	VersionID  string
+	Type       string
+	RecordSets []dns.RecordSet
+	Matches    []RecordMatch
+}
+
+func cmdFindRecord(c *cli.Context) error {
+
+	// Validate flags
+	search, err := recordSearchFromFlags(c)
+	if err != nil {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return wrapError(err)
+	}
+	replace := c.String("replace-with")
+	if c.IsSet("replace-with") && len(search.Rdata) == 0 {
+		return newCommandError(exitValidation, "--replace-with requires --rdata")
+	}
+	if _, err := outputFormat(c, recordMatchesOutput(nil)); err != nil {
+		return wrapError(err)
+	}
+	if c.IsSet("replace-with") {
+		profileHeader(c)
+	}
+
+	// Initialize context and Edgegrid session
+	ctx := context.Background()
+
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+	zones, err := zoneTargets(ctx, dnsClient, c, c.Args())
+	if err != nil {
+		return wrapError(err)
+	}
+	if c.NArg() == 0 && !c.IsSet("zones-file") && !c.IsSet("zone-search") {
+		if zones, err = allRecordZones(ctx, dnsClient); err != nil {
+			return wrapError(err)
+		}
+	}
+	if len(zones) == 0 {
+		return newCommandError(exitValidation, "no zones to search")
+	}
+
+	// Search the zones in the shared worker pool. Each job writes only its own slot.
+	fmt.Fprintln(os.Stderr, color.BlueString("Searching %d zone(s), %d at a time...", len(zones), c.Int("parallel")))
+	found := make([]*zoneSearchResult, len(zones))
+	index := make(map[string]int, len(zones))
+	for i, z := range zones {
+		index[z] = i
+	}
+	results := runZoneJobs(ctx, zones, c.Int("parallel"), func(ctx context.Context, zonename string) (string, error) {
+		r, err := searchZone(ctx, dnsClient, zonename, search, c.IsSet("replace-with"))
+		found[index[zonename]] = r
+		return "", err
+	})
+
+	matches := []RecordMatch{}
+	failed := 0
+	for i, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Fprintln(os.Stderr, color.RedString("%s: %s", r.Zone, zoneErrorText(r.Err)))
+			continue
+		}
+		matches = append(matches, found[i].Matches...)
+	}
+
+	// With --replace-with the plan is the result, so the matches are only counted
+	if !c.IsSet("replace-with") {
+		if err := writeOutput(c, recordMatchesOutput(matches)); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("%d matching recordset(s) in %d zone(s)", len(matches), len(zones)-failed))
+
+	if failed > 0 {
+		return newCommandError(partialExitCode(failed, len(zones)), "%d of %d zone(s) could not be searched", failed, len(zones))
+	}
+	if !c.IsSet("replace-with") || len(matches) == 0 {
+		return nil
+	}
+
+	// Turn the matches into an apply plan, one zone at a time
+	plan := &ApplyPlan{CreatedAt: time.Now().UTC().Format(time.RFC3339), Zones: []ZoneApplyPlan{}}
+	for i, r := range found {
+		if len(r.Matches) == 0 {
+			continue
+		}
+		zp, err := planRecordReplace(zones[i], r, search, replace)
+		if err != nil {
+			return wrapError(err)
+		}
+		if zp.Action != applyNoop {
+			plan.Zones = append(plan.Zones, *zp)
+		}
+	}
+	if len(plan.Zones) == 0 {
+		fmt.Fprintln(os.Stderr, color.GreenString("No changes. Matching records already use %s.", replace))
+		return nil
+	}
+
+	// Show the plan
+	if err := writeOutput(c, applyPlanOutput(plan)); err != nil {
+		return err
+	}
+	if c.Bool("dry-run") {
+		return nil
+	}
+
+	if !c.Bool("auto-approve") {
+		if c.Bool("non-interactive") {
+			return newCommandError(exitValidation, "Refusing to replace without --auto-approve in non-interactive mode")
+		}
+		fmt.Fprintf(os.Stderr, "Replace matching records in %d zone(s)? [y/N]: ", len(plan.Zones))
+		reader := bufio.NewReader(os.Stdin)
+		resp, _ := reader.ReadString('\n')
+		resp = strings.ToLower(strings.TrimSpace(resp))
+		if resp != "y" && resp != "yes" {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return nil
+		}
+	}
+
+	// Refuse the whole edit if any zone moved on since it was searched
+	for _, zp := range plan.Zones {
+		if err := checkZonePlanVersion(ctx, dnsClient, zp); err != nil {
+			return wrapError(err)
+		}
+	}
+	for _, zp := range plan.Zones {
+		if err := snapshotBeforeChange(ctx, dnsClient, c, zp.Zone); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, color.BlueString("Updating %s...", zp.Zone))
+		if err := applyZonePlan(ctx, dnsClient, zp); err != nil {
+			return apiError(err, "Update failed for zone %s: %v", zp.Zone, err)
+		}
+		fmt.Fprintln(os.Stderr, color.GreenString("Zone %s updated", zp.Zone))
+	}
+	return nil
+}
+
+// Build the search criteria from --rdata, --type and --name-regex. At least one of
+// --rdata and --name-regex is required so a search never lists every record.
+func recordSearchFromFlags(c *cli.Context) (*RecordSearch, error) {
+	search := &RecordSearch{Types: map[string]bool{}}
+	for _, v := range c.StringSlice("rdata") {
+		if v = strings.TrimSpace(v); v != "" {
+			search.Rdata = append(search.Rdata, v)
+		}
+	}
+	for _, t := range c.StringSlice("type") {
+		for _, v := range strings.Split(t, ",") {
+			if v = strings.ToUpper(strings.TrimSpace(v)); v != "" {
+				search.Types[v] = true
+			}
+		}
+	}
+	if c.IsSet("name-regex") {
+		re, err := regexp.Compile(c.String("name-regex"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --name-regex: %w", err)
+		}
+		search.NameRegex = re
+	}
+	if len(search.Rdata) == 0 && search.NameRegex == nil {
+		return nil, newCommandError(exitValidation, "--rdata or --name-regex is required")
+	}
+	return search, nil
+}
+
+// All zones that can hold recordsets
+func allRecordZones(ctx context.Context, dnsClient dns.DNS) ([]string, error) {
+	resp, err := dnsClient.ListZones(ctx, dns.ListZonesRequest{ShowAll: true, SortBy: "zone"})
+	if err != nil {
+		return nil, fmt.Errorf("zone list retrieval failed: %w", err)
+	}
+	zones := []string{}
+	for _, z := range resp.Zones {
+		if !strings.EqualFold(z.Type, "ALIAS") {
+			zones = append(zones, strings.ToLower(z.Zone))
+		}
+	}
+	return zones, nil
+}
+
+// Retrieve a zone's recordsets and collect the ones matching the search. A
+// search that plans a replacement needs the current version, so it skips the
+// zone cache.
+func searchZone(ctx context.Context, dnsClient dns.DNS, zonename string, search *RecordSearch, fresh bool) (*zoneSearchResult, error) {
+	lookup := getZoneInfo
+	if fresh {
+		lookup = fetchZoneInfo
+	}
+	zone, err := lookup(ctx, dnsClient, zonename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve zone: %w", err)
+	}
+	r := &zoneSearchResult{VersionID: zone.VersionID, Type: zone.Type}
+	if strings.EqualFold(zone.Type, "ALIAS") {
+		return r, nil
+	}
+	resp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+		Zone:      zonename,
+		QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve recordsets: %w", err)
+	}
+	r.RecordSets = resp.RecordSets
+	for _, rs := range resp.RecordSets {
+		if m, ok := search.match(zonename, rs); ok {
+			r.Matches = append(r.Matches, m)
+		}
+	}
+	return r, nil
+}
+
+// Match a recordset against the search. An rdata value matches when the value,
+// or one of its host name fields such as an MX or SRV target, equals one of
+// the searched values.
+func (s *RecordSearch) match(zonename string, rs dns.RecordSet) (RecordMatch, bool) {
+	m := RecordMatch{Zone: zonename, Name: rs.Name, Type: rs.Type, TTL: rs.TTL, Rdata: rs.Rdata}
+	if len(s.Types) > 0 && !s.Types[strings.ToUpper(rs.Type)] {
+		return m, false
+	}
+	if s.NameRegex != nil && !s.NameRegex.MatchString(rs.Name) {
+		return m, false
+	}
+	if len(s.Rdata) == 0 {
+		return m, true
+	}
+	for _, value := range rs.Rdata {
+		if len(rdataMatchSpans(rs.Type, value, s.Rdata)) > 0 {
+			m.Matched = append(m.Matched, value)
+		}
+	}
+	return m, len(m.Matched) > 0
+}
+
+// Byte ranges of the parts of an rdata value that equal a searched value: the
+// whole value, or else the host name fields of the type. Numeric fields such as
+// the MX preference or SRV weight never match.
+func rdataMatchSpans(rtype, value string, search []string) [][2]int {
+	for _, want := range search {
+		if rdataEqual(value, want) {
+			return [][2]int{{0, len(value)}}
+		}
+	}
+	fields := rdataFieldSpans(value)
+	spans := [][2]int{}
+	for _, idx := range rdataNameFields[strings.ToUpper(rtype)] {
+		if idx >= len(fields) {
+			continue
+		}
+		f := fields[idx]
+		for _, want := range search {
+			if rdataEqual(value[f[0]:f[1]], want) {
+				spans = append(spans, f)
+				break
+			}
+		}
+	}
+	return spans
+}
+
+// Byte ranges of the whitespace separated fields of an rdata value. Quoted
+// strings, as in NAPTR, are one field even when they contain spaces.
+func rdataFieldSpans(value string) [][2]int {
+	spans := [][2]int{}
+	for i := 0; i < len(value); {
+		if value[i] == ' ' || value[i] == '\t' {
+			i++
+			continue
+		}
+		start, quoted := i, value[i] == '"'
+		for i++; i < len(value); i++ {
+			if quoted {
+				if value[i] == '\\' {
+					i++
+				} else if value[i] == '"' {
+					i++
+					break
+				}
+			} else if value[i] == ' ' || value[i] == '\t' {
+				break
+			}
+		}
+		spans = append(spans, [2]int{start, min(i, len(value))})
+	}
+	return spans
+}
+
+func rdataEqual(a, b string) bool {
+	a = strings.TrimSuffix(strings.Trim(strings.TrimSpace(a), `"`), ".")
+	b = strings.TrimSuffix(strings.Trim(strings.TrimSpace(b), `"`), ".")
+	return strings.EqualFold(a, b)
+}
+
+// Replace the searched rdata in one rdata value. Only the matched parts are
+// rewritten, so the spacing inside quoted strings is kept. A replaced host name
+// keeps the trailing dot of the value it replaces.
+func replaceRdata(rtype, value string, search []string, replace string) string {
+	spans := rdataMatchSpans(rtype, value, search)
+	for i := len(spans) - 1; i >= 0; i-- {
+		sp := spans[i]
+		value = value[:sp[0]] + replaceField(value[sp[0]:sp[1]], replace) + value[sp[1]:]
+	}
+	return value
+}
+
+func replaceField(old, replace string) string {
+	if strings.HasPrefix(old, `"`) && !strings.HasPrefix(replace, `"`) {
+		return strconv.Quote(replace)
+	}
+	if strings.HasSuffix(old, ".") && !strings.HasSuffix(replace, ".") && net.ParseIP(replace) == nil {
+		return replace + "."
+	}
+	return replace
+}
+
+// Plan the update of one zone that replaces the searched rdata in its matching recordsets
+func planRecordReplace(zonename string, r *zoneSearchResult, search *RecordSearch, replace string) (*ZoneApplyPlan, error) {
+	matched := map[string]bool{}
+	for _, m := range r.Matches {
+		matched[recordSetKey(m.Name, m.Type)] = true
+	}
+
+	target := make([]dns.RecordSet, 0, len(r.RecordSets))
+	for _, rs := range r.RecordSets {
+		if rs.Type == "SOA" {
+			continue
+		}
+		rs = copyRecordSet(rs)
+		if matched[recordSetKey(rs.Name, rs.Type)] {
+			seen := map[string]bool{}
+			rdata := make([]string, 0, len(rs.Rdata))
+			for _, value := range rs.Rdata {
+				value = replaceRdata(rs.Type, value, search.Rdata, replace)
+				if err := validateReplacedRdata(rs.Type, value); err != nil {
+					return nil, fmt.Errorf("cannot replace %s %s in %s: %w", rs.Name, rs.Type, zonename, err)
+				}
+				if !seen[strings.ToLower(value)] {
+					seen[strings.ToLower(value)] = true
+					rdata = append(rdata, value)
+				}
+			}
+			rs.Rdata = rdata
+		}
+		target = append(target, rs)
+	}
+
+	zp := &ZoneApplyPlan{
+		Zone:      zonename,
+		Action:    applyNoop,
+		VersionID: r.VersionID,
+		Config:    &dns.ZoneCreate{Zone: zonename, Type: strings.ToUpper(r.Type)},
+	}
+	zp.RecordSets = desiredRecordSets(r.RecordSets, target)
+	zp.Changes = diffRecordSets(r.RecordSets, zp.RecordSets)
+	if len(zp.Changes) > 0 {
+		zp.Action = applyUpdate
+	}
+	return zp, nil
+}
+
+// Address records must keep an address of their own family
+func validateReplacedRdata(rtype, value string) error {
+	ip := net.ParseIP(value)
+	switch strings.ToUpper(rtype) {
+	case "A":
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%q is not an IPv4 address", value)
+		}
+	case "AAAA":
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%q is not an IPv6 address", value)
+		}
+	}
+	return nil
+}
+
+// Command output for the matches. CSV has one row per rdata value.
+func recordMatchesOutput(matches []RecordMatch) *CommandOutput {
+	return &CommandOutput{
+		Value: matches,
+		Table: func() string { return renderRecordMatchesTable(matches) },
+		CSV: func() [][]string {
+			rows := [][]string{{"zone", "name", "type", "ttl", "rdata"}}
+			for _, m := range matches {
+				for _, rdata := range m.Rdata {
+					rows = append(rows, []string{m.Zone, m.Name, m.Type, strconv.Itoa(m.TTL), rdata})
+				}
+			}
+			return rows
+		},
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+func cmdImportZone(c *cli.Context) error {
+	if c.NArg() != 1 {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "zonename is required")
+	}
+	zonename := normalizeDNSName(c.Args().First())
+	source := strings.ToLower(c.String("from"))
+	if source == "" {
+		return newCommandError(exitValidation, "--from is required: %s", strings.Join(zoneImporterNames(), ", "))
+	}
+	if !c.IsSet("file") {
+		return newCommandError(exitValidation, "Input file is required")
+	}
+	inputPath := filepath.FromSlash(c.String("file"))
+	data, err := os.ReadFile(inputPath)
+	if err != nil {
+		return newCommandError(exitValidation, "Failed to read input file: %v", err)
+	}
+
+	imp, err := importZone(source, data, zonename, inputPath, c.Int("default-ttl"))
+	if err != nil {
+		return wrapError(err)
+	}
+	// --strict fails before the report or anything else is written
+	if c.Bool("strict") && imp.Skipped() > 0 {
+		printImportReport(imp, "")
+		return newCommandError(exitValidation, "%d record(s) cannot be imported", imp.Skipped())
+	}
+	if c.String("report") != "" {
+		report, err := json.MarshalIndent(imp, "", "  ")
+		if err != nil {
+			return wrapError(err)
+		}
+		if err := writeFileAtomic(filepath.FromSlash(c.String("report")), append(report, '\n'), 0644); err != nil {
+			return apiError(err, "Failed to write report file: %v", err)
+		}
+	}
+	printImportReport(imp, c.String("report"))
+
+	if c.Bool("create") {
+		if err := createImportedZone(c, imp); err != nil {
+			return err
+		}
+	}
+	// The default output is the recordsets JSON create-recordsets reads
+	out := zoneExportOutput(&ZoneExport{Name: zonename, RecordSets: imp.RecordSets})
+	out.Table = nil
+	return writeOutput(c, out)
+}
+
+// Print the records left out or changed, and a summary, to STDERR
+func printImportReport(imp *ZoneImport, reportPath string) {
+	for _, issue := range imp.Issues {
+		label := "Changed"
+		if issue.Skipped {
+			label = "Skipped"
+		}
+		fmt.Fprintln(os.Stderr, color.YellowString("%s %s %s: %s", label, issue.Name, issue.Type, issue.Message))
+	}
+	summary := fmt.Sprintf("Converted %d recordset(s) from %s", len(imp.RecordSets), imp.Source)
+	if n := imp.Skipped(); n > 0 {
+		summary += fmt.Sprintf(", skipped %d record(s)", n)
+	}
+	if reportPath != "" {
+		summary += ", report written to " + filepath.FromSlash(reportPath)
+	}
+	fmt.Fprintln(os.Stderr, color.BlueString(summary))
+}
+
+// Create the zone when it doesn't exist, then the imported recordsets
+func createImportedZone(c *cli.Context, imp *ZoneImport) error {
+	ctx := context.Background()
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+	zonename := imp.Zone
+
+	info, err := fetchZoneInfo(ctx, dnsClient, zonename)
+	var dnsErr *dns.Error
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.StatusCode == http.StatusNotFound:
+		if c.String("contractid") == "" {
+			return newCommandError(exitValidation, "zone %s does not exist; --contractid is required to create it", zonename)
+		}
+		fmt.Fprintln(os.Stderr, color.BlueString("Creating zone %s...", zonename))
+		defer zoneCache.invalidate(zonename)
+		err = dnsClient.CreateZone(ctx, dns.CreateZoneRequest{
+			CreateZone: &dns.ZoneCreate{
+				Zone:       zonename,
+				Type:       "PRIMARY",
+				Comment:    "Imported from " + imp.Source,
+				ContractID: c.String("contractid"),
+			},
+			ZoneQueryString: dns.ZoneQueryString{Contract: c.String("contractid"), Group: c.String("groupid")},
+		})
+		if err != nil {
+			return apiError(err, "zone create failed: %s", err)
+		}
+		// Generate the default SOA and NS records, as create-zoneconfig --initialize does
+		if err := dnsClient.SaveChangeList(ctx, dns.SaveChangeListRequest{Zone: zonename}); err != nil {
+			return apiError(err, "failed to initialize zone records: %s", err)
+		}
+		if err := dnsClient.SubmitChangeList(ctx, dns.SubmitChangeListRequest{Zone: zonename}); err != nil {
+			return apiError(err, "failed to initialize zone records during submit changelist: %s", err)
+		}
+	case err != nil:
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
+	case !hasRecordSets(info.Type):
+		return newCommandError(exitValidation, "Zone %s is a %s zone and cannot have recordsets", zonename, info.Type)
+	default:
+		if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+			return err
+		}
+		defer zoneCache.invalidate(zonename)
+	}
+
+	if len(imp.RecordSets) == 0 {
+		fmt.Fprintln(os.Stderr, color.YellowString("No recordsets to create"))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, color.BlueString("Creating %d recordset(s) in %s...", len(imp.RecordSets), zonename))
+	err = dnsClient.CreateRecordSets(ctx, dns.CreateRecordSetsRequest{
+		Zone:       zonename,
+		RecordSets: &dns.RecordSets{RecordSets: imp.RecordSets},
+	})
+	if err != nil {
+		return apiError(err, "Failed to create recordsets: %v", err)
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("Imported %d recordset(s) into %s", len(imp.RecordSets), zonename))
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+func cmdLintZone(c *cli.Context) error {
+
+	// Validate zonename argument
+	if c.NArg() == 0 && !c.IsSet("zones-file") && !c.IsSet("zone-search") {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "zonename is required")
+	}
+
+	format, err := outputFormat(c, lintOutput("", "", nil))
+	if err != nil {
+		return wrapError(err)
+	}
+
+	var (
+		findings []LintFinding
+		zonename string
+		source   string
+	)
+
+	if c.IsSet("file") {
+		// Lint a recordsets JSON or master zone file without touching the API
+		if c.NArg() > 1 || c.IsSet("zones-file") || c.IsSet("zone-search") {
+			return newCommandError(exitValidation, "--file lints a single zone")
+		}
+		zonename = c.Args().First()
+		recordsets, err := loadZoneInput(c, zonename)
+		if err != nil {
+			return wrapError(err)
+		}
+		source = filepath.ToSlash(c.String("file"))
+		findings = lintRecordSets(zonename, recordsets, c.Int("min-ttl"))
+	} else {
+		if c.Bool("dns") {
+			return newCommandError(exitValidation, "--dns requires --file")
+		}
+
+		// Initialize context and Edgegrid session
+		ctx := context.Background()
+
+		sess, err := edgegrid.InitializeSession(c)
+		if err != nil {
+			return newCommandError(exitAuth, "session failed %v", err)
+		}
+		ctx = edgegrid.WithSession(ctx, sess)
+		dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+		zones, err := zoneTargets(ctx, dnsClient, c, c.Args())
+		if err != nil {
+			return wrapError(err)
+		}
+		if multiZone(c, zones) {
+			asJSON := format == formatJSON || format == "sarif"
+			return runZoneCommand(ctx, c, zones, asJSON, func(ctx context.Context, zonename string) (string, error) {
+				findings, err := lintLiveZone(ctx, dnsClient, c, zonename)
+				if err != nil {
+					return "", err
+				}
+				results, err := renderOutput(c, lintOutput(zonename, zonename, findings))
+				if err == nil && lintHasErrors(findings) {
+					err = fmt.Errorf("lint errors found")
+				}
+				return results, err
+			})
+		}
+
+		fmt.Fprintln(os.Stderr, color.BlueString("Retrieving Recordsets..."))
+		zonename, source = zones[0], zones[0]
+		findings, err = lintLiveZone(ctx, dnsClient, c, zonename)
+		if err != nil {
+			return wrapError(err)
+		}
+	}
+
+	if err := writeOutput(c, lintOutput(zonename, source, findings)); err != nil {
+		return err
+	}
+
+	if lintHasErrors(findings) {
+		return newCommandError(exitValidation, "lint errors found")
+	}
+	return nil
+}
+
+// Lint the recordsets of a live zone
+func lintLiveZone(ctx context.Context, dnsClient dns.DNS, c *cli.Context, zonename string) ([]LintFinding, error) {
+	resp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+		Zone:      zonename,
+		QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Recordset list retrieval failed: %w", err)
+	}
+	return lintRecordSets(zonename, resp.RecordSets, c.Int("min-ttl")), nil
+}
+
+// Command output for lint findings. The table format is the text report.
+func lintOutput(zonename, source string, findings []LintFinding) *CommandOutput {
+	if findings == nil {
+		findings = []LintFinding{}
+	}
+	text := func() (string, error) { return renderLintText(zonename, findings), nil }
+	return &CommandOutput{
+		Value: findings,
+		Table: func() string { return renderLintText(zonename, findings) },
+		CSV: func() [][]string {
+			rows := [][]string{{"severity", "rule", "name", "type", "message"}}
+			for _, f := range findings {
+				rows = append(rows, []string{f.Severity, f.Rule, f.Name, f.Type, f.Message})
+			}
+			return rows
+		},
+		Formats: map[string]func() (string, error){
+			"text": text,
+			"sarif": func() (string, error) {
+				results, err := renderLintSARIF(source, findings)
+				if err != nil {
+					return "", fmt.Errorf("Unable to format SARIF output")
+				}
+				return results, nil
+			},
+		},
+	}
+}
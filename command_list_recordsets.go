@@ -16,10 +16,8 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/akamai/cli-dns/edgegrid"
@@ -36,9 +34,9 @@ type RecordsetList struct {
 
 func cmdListRecordsets(c *cli.Context) error {
 	// Validate zonename argument
-	if c.NArg() == 0 {
+	if c.NArg() == 0 && !c.IsSet("zones-file") && !c.IsSet("zone-search") {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename required"), 1)
+		return newCommandError(exitValidation, "zonename required")
 	}
 
 	// Initialize context and Edgegrid session
@@ -46,27 +44,48 @@ func cmdListRecordsets(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
-	zonename := c.Args().First()
+	format, err := outputFormat(c, zoneExportOutput(&ZoneExport{}))
+	if err != nil {
+		return wrapError(err)
+	}
 
-	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	zones, err := zoneTargets(ctx, dnsClient, c, c.Args())
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return wrapError(err)
 	}
-	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and cannot have recordsets", zonename)), 1)
+	if multiZone(c, zones) {
+		return runZoneCommand(ctx, c, zones, format == formatJSON, func(ctx context.Context, zonename string) (string, error) {
+			out, err := listRecordsets(ctx, dnsClient, c, zonename)
+			if err != nil {
+				return "", err
+			}
+			return renderOutput(c, out)
+		})
 	}
 
-	outputPath := ""
-	if c.IsSet("output") {
-		outputPath = filepath.FromSlash(c.String("output"))
+	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving Recordsets List..."))
+	out, err := listRecordsets(ctx, dnsClient, c, zones[0])
+	if err != nil {
+		return wrapError(err)
+	}
+	return writeOutput(c, out)
+}
+
+// List a zone's recordsets
+func listRecordsets(ctx context.Context, dnsClient dns.DNS, c *cli.Context, zonename string) (*CommandOutput, error) {
+
+	// Check if the zone is an ALIAS zone
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve zone information for %s. Error: %w", zonename, err)
+	}
+	if strings.EqualFold(zoneResp.Type, "ALIAS") {
+		return nil, newCommandError(exitValidation, "Zone %s is an ALIAS zone and cannot have recordsets", zonename)
 	}
 
 	typeFilter := c.StringSlice("type")
@@ -76,7 +95,6 @@ func cmdListRecordsets(c *cli.Context) error {
 		sortby = "type"
 	}
 
-	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving Recordsets List..."))
 	req := dns.GetRecordSetsRequest{
 		Zone: zonename,
 		QueryArgs: &dns.RecordSetQueryArgs{
@@ -93,38 +111,12 @@ func cmdListRecordsets(c *cli.Context) error {
 	// Fetch recordsets
 	resp, err := dnsClient.GetRecordSets(ctx, req)
 	if err != nil {
-		return cli.NewExitError(color.RedString("Recordset List retrieval failed %s", err), 1)
+		return nil, fmt.Errorf("Recordset List retrieval failed %w", err)
 	}
 
-	recordsets := resp.RecordSets
-
-	// Format output (JSON or table)
-	var results string
-
-	if c.Bool("json") {
-		output := RecordsetList{Recordsets: recordsets}
-		b, err := json.MarshalIndent(output, "", " ")
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to format JSON"), 1)
-		}
-		results = string(b)
-	} else {
-		results = renderRecordsetListTable(zonename, recordsets)
-	}
-
-	if outputPath != "" {
-		f, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString("Failed to create output file: %s", err), 1)
-		}
-
-		defer f.Close()
-		f.WriteString(results)
-		f.Sync()
-		fmt.Fprintln(os.Stderr, color.GreenString("Output is written to %s", outputPath))
+	zone, err := zoneDetails(ctx, c, dnsClient, zoneResp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve zone information for %s. Error: %w", zonename, err)
 	}
-
-	fmt.Fprintln(c.App.Writer, "")
-	fmt.Fprintln(c.App.Writer, results)
-	return nil
+	return recordsetListOutput(zonename, zone, resp.RecordSets), nil
 }
@@ -16,14 +16,11 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/akamai/cli-dns/edgegrid"
-	"github.com/fatih/color"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
 	"github.com/urfave/cli"
@@ -51,7 +48,7 @@ func cmdListZoneconfig(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
@@ -77,54 +74,39 @@ func cmdListZoneconfig(c *cli.Context) error {
 	// Fetch zones from DNS client
 	resp, err := dnsClient.ListZones(ctx, query)
 	if err != nil {
-		return fmt.Errorf("zone list retrieval failed: %v", err)
+		return fmt.Errorf("zone list retrieval failed: %w", err)
 	}
 	zones := resp.Zones
-	var output string
 
-	// Format output in summary or table, optionally as JSON
+	// Summary or full zone list
 	if c.Bool("summary") {
-		if c.Bool("json") {
-			summaryList := ZoneSummaryList{}
-			for _, z := range zones {
-				summaryList.Zones = append(summaryList.Zones, &ZoneSummary{
-					Zone:            z.Zone,
-					Type:            z.Type,
-					ActivationState: z.ActivationState,
-					ContractId:      z.ContractID,
-				})
-			}
-			b, err := json.MarshalIndent(summaryList, "", " ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal summary JSON: %v", err)
-			}
-			output = string(b)
-		} else {
-			output = renderZoneSummaryListTable(zones)
-		}
-	} else {
-		if c.Bool("json") {
-			b, err := json.MarshalIndent(zones, "", " ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal full JSON: %v", err)
-			}
-			output = string(b)
-		} else {
-			output = renderZoneListTable(zones)
+		summaryList := ZoneSummaryList{}
+		for _, z := range zones {
+			summaryList.Zones = append(summaryList.Zones, &ZoneSummary{
+				Zone:            z.Zone,
+				Type:            z.Type,
+				ActivationState: z.ActivationState,
+				ContractId:      z.ContractID,
+			})
 		}
+		return writeOutput(c, &CommandOutput{
+			Value: summaryList,
+			Table: func() string { return renderZoneSummaryListTable(zones) },
+			CSV:   func() [][]string { return zoneListCSV(zones) },
+		})
 	}
+	return writeOutput(c, &CommandOutput{
+		Value: zones,
+		Table: func() string { return renderZoneListTable(zones, c) },
+		CSV:   func() [][]string { return zoneListCSV(zones) },
+	})
+}
 
-	// Write output to file or print to console
-	if outFile := c.String("output"); outFile != "" {
-		path := filepath.FromSlash(outFile)
-		if err := os.WriteFile(path, []byte(output), 0644); err != nil {
-			return fmt.Errorf("failed to write to output file %v", err)
-		}
-		fmt.Fprintln(c.App.Writer, color.GreenString("Output written to %s", path))
-	} else {
-		fmt.Fprintln(c.App.Writer, output)
+// One row per zone
+func zoneListCSV(zones []dns.ZoneResponse) [][]string {
+	rows := [][]string{{"zone", "type", "activationState", "contractId", "signAndServe", "versionId"}}
+	for _, z := range zones {
+		rows = append(rows, []string{z.Zone, z.Type, z.ActivationState, z.ContractID, strconv.FormatBool(z.SignAndServe), z.VersionID})
 	}
-
-	return nil
-
+	return rows
 }
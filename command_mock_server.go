@@ -0,0 +1,63 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+func cmdMockServer(c *cli.Context) error {
+	api, err := newMockAPI(c.String("data"))
+	if err != nil {
+		return newCommandError(exitValidation, "%v", err)
+	}
+
+	listener, err := net.Listen("tcp", c.String("listen"))
+	if err != nil {
+		return newCommandError(exitError, "Failed to listen on %s: %v", c.String("listen"), err)
+	}
+	server := &http.Server{Handler: api, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	// The address is printed on STDOUT so scripts can start the server on port 0
+	endpoint := fmt.Sprintf("http://%s", listener.Addr())
+	fmt.Fprintln(os.Stderr, color.GreenString("Mock Edge DNS API listening on %s", endpoint))
+	fmt.Fprintln(os.Stderr, color.BlueString("Point the CLI at it with --endpoint %s or AKAMAI_DNS_ENDPOINT=%s", endpoint, endpoint))
+	fmt.Println(endpoint)
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return newCommandError(exitError, "Mock server failed: %v", err)
+	}
+	return nil
+}
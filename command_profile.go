@@ -0,0 +1,103 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// Load the config and mark the profile selected by --profile or the config file
+func profileConfig(c *cli.Context) (*CLIConfig, string, error) {
+	cfg, err := loadCLIConfig()
+	if err != nil {
+		return nil, "", newCommandError(exitValidation, "%v", err)
+	}
+	active := c.GlobalString("profile")
+	if active == "" {
+		active = cfg.Current
+	}
+	if p, ok := cfg.Profiles[active]; ok {
+		p.Active = true
+	}
+	return cfg, active, nil
+}
+
+func cmdProfileList(c *cli.Context) error {
+	cfg, _, err := profileConfig(c)
+	if err != nil {
+		return err
+	}
+	profiles := make([]*Profile, 0, len(cfg.Profiles))
+	for _, name := range cfg.profileNames() {
+		profiles = append(profiles, cfg.Profiles[name])
+	}
+
+	return writeOutput(c, &CommandOutput{
+		Value: profiles,
+		Table: func() string { return renderProfileListTable(cfg.path, profiles) },
+		CSV: func() [][]string {
+			rows := [][]string{{"name", "active", "edgerc", "section", "accountKey", "contractId", "groupId", "format"}}
+			for _, p := range profiles {
+				rows = append(rows, []string{p.Name, fmt.Sprint(p.Active), p.Edgerc, p.Section, p.AccountKey, p.ContractID, p.GroupID, p.Format})
+			}
+			return rows
+		},
+	})
+}
+
+func cmdProfileShow(c *cli.Context) error {
+	cfg, name, err := profileConfig(c)
+	if err != nil {
+		return err
+	}
+	if c.NArg() > 0 {
+		name = c.Args().First()
+	}
+	if name == "" {
+		return newCommandError(exitNotFound, "no profile is in use; give a profile name or run profile use")
+	}
+	p, err := cfg.profile(name)
+	if err != nil {
+		return err
+	}
+	return writeOutput(c, &CommandOutput{
+		Value: p,
+		Table: func() string { return renderProfileTable(p) },
+	})
+}
+
+func cmdProfileUse(c *cli.Context) error {
+	if c.NArg() != 1 {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "a profile name is required")
+	}
+	name := c.Args().First()
+	cfg, err := loadCLIConfig()
+	if err != nil {
+		return newCommandError(exitValidation, "%v", err)
+	}
+	if _, err := cfg.profile(name); err != nil {
+		return err
+	}
+	if err := cfg.setCurrent(name); err != nil {
+		return wrapError(err)
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("Now using profile %s", name))
+	return nil
+}
@@ -16,10 +16,8 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/akamai/cli-dns/edgegrid"
@@ -34,43 +32,36 @@ func cmdRetrieveRecordset(c *cli.Context) error {
 	// Validate zonename argument
 	if c.NArg() == 0 {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 	zonename := c.Args().First()
 
 	// Validate required flags
 	if !c.IsSet("name") || !c.IsSet("type") {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("Recordset name and type are required"), 1)
+		return newCommandError(exitValidation, "Recordset name and type are required")
 	}
 
 	name := c.String("name")
 	rstype := c.String("type")
 
-	outputPath := ""
-	if c.IsSet("output") {
-		outputPath = filepath.FromSlash(c.String("output"))
-	}
-
 	// Initialize context and Edgegrid session
 	ctx := context.Background()
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
 	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and cannot have recordsets", zonename)), 1)
+		return newCommandError(exitValidation, "Zone %s is an ALIAS zone and cannot have recordsets", zonename)
 	}
 
 	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving Recordset..."))
@@ -81,42 +72,10 @@ func cmdRetrieveRecordset(c *cli.Context) error {
 	})
 	if err != nil {
 		if dnsErr, ok := err.(*dns.Error); ok && dnsErr.StatusCode == 404 {
-			return cli.NewExitError(color.RedString("Recordset not found"), 1)
-		}
-		return cli.NewExitError(color.RedString("Failed to retrieve recordset: %s", err), 1)
-	}
-
-	fmt.Fprintln(os.Stderr, color.BlueString("Assembling Recordset Output..."))
-	var results string
-	if c.Bool("json") {
-		rs := &dns.RecordSet{
-			Name:  record.Name,
-			Type:  record.RecordType,
-			TTL:   record.TTL,
-			Rdata: record.Target,
-		}
-		b, err := json.MarshalIndent(rs, "", " ")
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to format JSON: %s", err), 1)
+			return newCommandError(exitNotFound, "Recordset not found")
 		}
-		results = string(b)
-	} else {
-		results = renderRecordsetTable(zonename, record)
+		return apiError(err, "Failed to retrieve recordset: %s", err)
 	}
-	if outputPath != "" {
-		f, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString("Failed to create output file: %s", err), 1)
-		}
-		defer f.Close()
-		f.WriteString(results)
-		f.Sync()
-		fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
-		return nil
-	}
-
-	fmt.Fprintln(c.App.Writer, "")
-	fmt.Fprintln(c.App.Writer, results)
-	return nil
 
+	return writeOutput(c, recordsetOutput(zonename, record))
 }
@@ -16,8 +16,8 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/akamai/cli-dns/edgegrid"
@@ -34,42 +34,65 @@ func cmdRetrieveZone(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	// Validate zonename argument
-	if c.NArg() == 0 {
+	if c.NArg() == 0 && !c.IsSet("zones-file") && !c.IsSet("zone-search") {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 
-	zonename := c.Args().First()
+	format, err := outputFormat(c, zoneExportOutput(&ZoneExport{}))
+	if err != nil {
+		return wrapError(err)
+	}
 
-	fmt.Fprintln(c.App.Writer, color.BlueString("Fetching zone..."))
+	zones, err := zoneTargets(ctx, dnsClient, c, c.Args())
+	if err != nil {
+		return wrapError(err)
+	}
+	if multiZone(c, zones) {
+		return runZoneCommand(ctx, c, zones, format == formatJSON, func(ctx context.Context, zonename string) (string, error) {
+			out, err := retrieveZone(ctx, dnsClient, c, zonename)
+			if err != nil {
+				return "", err
+			}
+			return renderOutput(c, out)
+		})
+	}
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Fetching zone..."))
+	out, err := retrieveZone(ctx, dnsClient, c, zones[0])
+	if err != nil {
+		return wrapError(err)
+	}
+	return writeOutput(c, out)
+}
+
+// Retrieve a zone with its recordsets
+func retrieveZone(ctx context.Context, dnsClient dns.DNS, c *cli.Context, zonename string) (*CommandOutput, error) {
 
 	// Fetch zone details
 	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
 		Zone: zonename,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString("Zone not found "), 1)
-	}
-
-	//fmt.Fprintln(c.App.Writer, fmt.Sprintf(" [%s]", color.GreenString("OK")))
-
-	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		// Print zone details only
-		fmt.Fprintln(c.App.Writer, "")
-		renderZoneTable(zoneResp, nil, c)
-		return nil
+		return nil, newCommandError(exitNotFound, "Zone not found")
 	}
 
 	// Fetch all recordsets for the zone
-	recordsResp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{Zone: zonename})
-	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("failed to retrieve zone: %s", err)), 1)
+	recordsResp := &dns.GetRecordSetsResponse{}
+	if !strings.EqualFold(zoneResp.Type, "ALIAS") {
+		recordsResp, err = dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+			Zone:      zonename,
+			QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve zone: %w", err)
+		}
 	}
 
 	filterSlice := c.StringSlice("filter")
@@ -86,23 +109,11 @@ func cmdRetrieveZone(c *cli.Context) error {
 		}
 	}
 
-	// Output as JSON
-	if c.IsSet("json") && c.Bool("json") {
-		jsonObj := map[string]interface{}{
-			"zone":    zoneResp,
-			"records": filteredRecords,
-		}
-		out, err := json.MarshalIndent(jsonObj, "", " ")
-		if err != nil {
-			return cli.NewExitError("failed to marshal JSON output", 1)
-		}
-		fmt.Fprintln(c.App.Writer, string(out))
-		return nil
+	out := zoneExportOutput(&ZoneExport{Name: zonename, Zone: zoneResp, RecordSets: filteredRecords})
+	out.Value = map[string]interface{}{
+		"zone":    zoneResp,
+		"records": filteredRecords,
 	}
-
-	fmt.Fprintln(c.App.Writer, "")
-	renderZoneTable(zoneResp, filteredRecords, c)
-
-	return nil
-
+	out.Table = func() string { return "\n" + renderZoneTable(zoneResp, filteredRecords, c) }
+	return out, nil
 }
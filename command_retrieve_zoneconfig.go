@@ -16,10 +16,8 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/akamai/cli-dns/edgegrid"
@@ -38,7 +36,7 @@ func cmdRetrieveZoneconfig(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
@@ -48,30 +46,20 @@ func cmdRetrieveZoneconfig(c *cli.Context) error {
 	// Validate zonename argument
 	if zonename == "" {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename required"), 1)
+		return newCommandError(exitValidation, "zonename required")
 	}
 
-	var (
-		outputPath string
-		results    string
-	)
-
 	// Check if the --dns flag is set to retrieve zone as master file
 	isMasterfile := c.Bool("dns")
 
-	// Get the output file path if set
-	if c.IsSet("output") {
-		outputPath = filepath.FromSlash(c.String("output"))
-	}
-
 	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving Zone..."))
 
 	zone, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zonename})
 	if err != nil {
 		if dnsErr, ok := err.(*dns.Error); ok && dnsErr.StatusCode == 404 {
-			return cli.NewExitError(color.RedString("zone does not exist"), 1)
+			return newCommandError(exitNotFound, "zone does not exist")
 		}
-		return cli.NewExitError(fmt.Sprintf(color.RedString("failed to retrieve zone: %s", err)), 1)
+		return apiError(err, "failed to retrieve zone: %s", err)
 	}
 
 	// Retrieve zone as master zone file
@@ -79,56 +67,21 @@ func cmdRetrieveZoneconfig(c *cli.Context) error {
 
 		// ALIAS zones do not support master file view
 		if strings.EqualFold(zone.Type, "ALIAS") {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("zone %s is an ALIAS zone and does not support master file retrieval", zonename)), 1)
+			return newCommandError(exitValidation, "zone %s is an ALIAS zone and does not support master file retrieval", zonename)
 		}
 
 		content, err := dnsClient.GetMasterZoneFile(ctx, dns.GetMasterZoneFileRequest{Zone: zonename})
 		if err != nil {
 			if dnsErr, ok := err.(*dns.Error); ok && dnsErr.StatusCode == 404 {
-				return cli.NewExitError(color.RedString("zone doesn't exist"), 1)
-			}
-			return cli.NewExitError(fmt.Sprintf(color.RedString("failed to retrieve master file: %s", err)), 1)
-		}
-		results = content
-	} else {
-		// Retrieve zone in structured format
-		/*zone, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zonename})
-		if err != nil {
-			if dnsErr, ok := err.(*dns.Error); ok && dnsErr.StatusCode == 404 {
-				return cli.NewExitError(color.RedString("zone does not exist"), 1)
+				return newCommandError(exitNotFound, "zone doesn't exist")
 			}
-			return cli.NewExitError(fmt.Sprintf(color.RedString("failed to retrieve zone: %s", err)), 1)
-		}*/
-
-		// Output as JSON or table format
-		if c.Bool("json") {
-			b, err := json.MarshalIndent(zone, "", " ")
-			if err != nil {
-				return cli.NewExitError(color.RedString("failed to marshal zone JSON"), 1)
-			}
-			results = string(b)
-		} else {
-			results = renderZoneconfigTable(zone, c)
-		}
-	}
-
-	// Write output to file or console
-	if outputPath != "" {
-		//fmt.Fprintf(os.Stderr, color.GreenString("Writing output to %s...\n", outputPath))
-		file, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(fmt.Sprintf(color.RedString("failed to create output file: %s", err)), 1)
-		}
-		defer file.Close()
-
-		if _, err := file.WriteString(results); err != nil {
-			return cli.NewExitError(color.RedString("failed to write output to file"), 1)
+			return apiError(err, "failed to retrieve master file: %s", err)
 		}
-		fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
-		return nil
+		return writeOutputText(c, content)
 	}
 
-	fmt.Println()
-	fmt.Println(results)
-	return nil
+	return writeOutput(c, &CommandOutput{
+		Value: zone,
+		Table: func() string { return renderZoneconfigTable(zone, c) },
+	})
 }
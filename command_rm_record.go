@@ -34,42 +34,70 @@ func cmdRmRecord(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	// Validate record type and zone name arguments
-	if c.NArg() < 2 {
+	if c.NArg() < 2 && (c.NArg() < 1 || !c.IsSet("zones-file") && !c.IsSet("zone-search")) {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("record type and zonename are required"), 1)
+		return newCommandError(exitValidation, "record type and zonename are required")
 	}
 	recordType := strings.ToUpper(c.Args().Get(0))
-	zonename := c.Args().Get(1)
 
-	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	if !c.IsSet("name") {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "Record name (--name) is required")
+	}
+
+	zones, err := zoneTargets(ctx, dnsClient, c, c.Args().Tail())
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return wrapError(err)
 	}
-	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and does not have recordsets", zonename)), 1)
+	multi := multiZone(c, zones)
+	job := func(ctx context.Context, zonename string) (string, error) {
+		return rmRecord(ctx, dnsClient, c, recordType, zonename, zones, !multi)
+	}
+	if multi {
+		format, err := outputFormat(c, &CommandOutput{})
+		if err != nil {
+			return wrapError(err)
+		}
+		return runZoneCommand(ctx, c, zones, format == formatJSON, job)
 	}
 
-	if !c.IsSet("name") {
-		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("Record name (--name) is required"), 1)
+	results, err := job(ctx, zones[0])
+	if results != "" {
+		if werr := writeOutputText(c, results); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	if err != nil {
+		return wrapError(err)
+	}
+	return nil
+}
+
+// Delete the named records of a type from a zone. zones are all the zones the
+// command targets. Confirmation of multiple matches is only asked for when prompt is set.
+func rmRecord(ctx context.Context, dnsClient dns.DNS, c *cli.Context, recordType, zonename string, zones []string, prompt bool) (string, error) {
+
+	// Check if the zone is an ALIAS zone
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
+	if err != nil {
+		return "", fmt.Errorf("Failed to retrieve zone information for %s. Error: %w", zonename, err)
+	}
+	if strings.EqualFold(zoneResp.Type, "ALIAS") {
+		return "", fmt.Errorf("Zone %s is an ALIAS zone and does not have recordsets", zonename)
 	}
-	name := c.String("name")
 
-	fqdn := name
-	if !strings.HasSuffix(name, "."+zonename) {
-		fqdn = name + "." + zonename
+	fqdn, err := qualifyRecordName(c.String("name"), zonename, zones)
+	if err != nil {
+		return "", err
 	}
 
-	fmt.Println("Looking up records to delete...")
+	fmt.Fprintf(os.Stderr, "Looking up records to delete in %s...\n", zonename)
 
 	// Get list of recordsets matching the type and zone
 	listResp, err := dnsClient.GetRecordList(ctx, dns.GetRecordListRequest{
@@ -77,7 +105,7 @@ func cmdRmRecord(c *cli.Context) error {
 		RecordType: recordType,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to list records: %s", err)), 1)
+		return "", fmt.Errorf("Failed to list records: %w", err)
 	}
 
 	// Filter matching records by name
@@ -94,30 +122,37 @@ func cmdRmRecord(c *cli.Context) error {
 	}
 
 	if len(matching) == 0 {
-		return cli.NewExitError(color.RedString("No matching records found."), 1)
+		return "", newCommandError(exitNotFound, "No matching records found.")
 	}
 
 	// If multiple records match, ask user unless --force-multiple is set
 	if len(matching) > 1 && !c.Bool("force-multiple") {
-		if c.Bool("non-interactive") {
-			return cli.NewExitError(color.RedString("Multiple records found. Use --force-multiple in non-interactive mode."), 1)
+		if c.Bool("non-interactive") || !prompt {
+			return "", fmt.Errorf("Multiple records found. Use --force-multiple in non-interactive mode.")
 		}
 
-		fmt.Printf("Multiple records matched for %s %s:\n", recordType, fqdn)
+		fmt.Fprintf(os.Stderr, "Multiple records matched for %s %s:\n", recordType, fqdn)
 		for _, rec := range matching {
-			fmt.Printf("- TTL: %d, RDATA: %v\n", rec.TTL, rec.Target)
+			fmt.Fprintf(os.Stderr, "- TTL: %d, RDATA: %v\n", rec.TTL, rec.Target)
 		}
-		fmt.Print("Are you sure you want to delete all matching records? [y/N]: ")
+		fmt.Fprint(os.Stderr, "Are you sure you want to delete all matching records? [y/N]: ")
 		reader := bufio.NewReader(os.Stdin)
 		resp, _ := reader.ReadString('\n')
 		resp = strings.ToLower(strings.TrimSpace(resp))
 		if resp != "y" && resp != "yes" {
-			fmt.Println("Aborted.")
-			return nil
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return "", nil
 		}
 	}
 
-	// Delete each matching record
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+		return "", err
+	}
+	defer zoneCache.invalidate(zonename)
+
+	// Delete each matching record. The deleted recordsets are the result.
+	deleted := []dns.RecordSet{}
+	var deleteErr error
 	for _, rec := range matching {
 		err = dnsClient.DeleteRecord(ctx, dns.DeleteRecordRequest{
 			Zone:       zonename,
@@ -125,10 +160,27 @@ func cmdRmRecord(c *cli.Context) error {
 			RecordType: rec.RecordType,
 		})
 		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to delete record %s %s: %s", rec.RecordType, rec.Name, err)), 1)
+			deleteErr = fmt.Errorf("Failed to delete record %s %s: %w", rec.RecordType, rec.Name, err)
+			break
 		}
-		fmt.Println(color.GreenString(fmt.Sprintf("Deleted record: %s %s", rec.RecordType, rec.Name)))
+		deleted = append(deleted, dns.RecordSet{Name: rec.Name, Type: rec.RecordType, TTL: rec.TTL, Rdata: rec.Target})
+	}
+	if len(deleted) == 0 || c.Bool("suppress") {
+		return "", deleteErr
 	}
 
-	return nil
+	results, err := renderOutput(c, &CommandOutput{
+		Value: deleted,
+		Table: func() string {
+			lines := make([]string, len(deleted))
+			for i, rs := range deleted {
+				lines[i] = color.GreenString("Deleted record: %s %s", rs.Type, rs.Name)
+			}
+			return strings.Join(lines, "\n")
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return results, deleteErr
 }
@@ -0,0 +1,194 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+func cmdSnapshotZone(c *cli.Context) error {
+
+	// Validate zonename arguments
+	if c.NArg() == 0 {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "at least one zonename is required")
+	}
+	dir, err := snapshotDir(c)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	// Listing only reads the local snapshot directory
+	if c.Bool("list") {
+		all := []*ZoneSnapshot{}
+		tables := []string{}
+		for _, z := range c.Args() {
+			snaps, err := listZoneSnapshots(dir, z)
+			if err != nil {
+				return wrapError(err)
+			}
+			all = append(all, snaps...)
+			tables = append(tables, renderZoneSnapshotsTable(strings.TrimSuffix(z, "."), snaps))
+		}
+		return writeOutput(c, &CommandOutput{
+			Value: all,
+			Table: func() string { return strings.Join(tables, "\n") },
+			CSV: func() [][]string {
+				rows := [][]string{{"zone", "id", "created", "command", "recordsets"}}
+				for _, s := range all {
+					rows = append(rows, []string{s.Zone, s.ID, s.Created, s.Command, strconv.Itoa(len(s.RecordSets))})
+				}
+				return rows
+			},
+		})
+	}
+
+	// Initialize context and Edgegrid session
+	ctx := context.Background()
+
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+	// The result is the snapshot ids, which restore-zone --snapshot accepts
+	type snapshotTaken struct {
+		Zone string `json:"zone"`
+		ID   string `json:"id"`
+		Path string `json:"path"`
+	}
+	taken := []snapshotTaken{}
+	for _, z := range c.Args() {
+		fmt.Fprintln(os.Stderr, color.BlueString("Taking snapshot of %s...", z))
+		snap, path, err := takeZoneSnapshot(ctx, dnsClient, dir, z, c.Command.FullName())
+		if err != nil {
+			return apiError(err, "Snapshot failed: %v", err)
+		}
+		fmt.Fprintln(os.Stderr, color.GreenString("Snapshot %s written to %s", snap.ID, path))
+		taken = append(taken, snapshotTaken{Zone: snap.Zone, ID: snap.ID, Path: path})
+	}
+	return writeOutput(c, &CommandOutput{
+		Value: taken,
+		Table: func() string {
+			ids := make([]string, len(taken))
+			for i, t := range taken {
+				ids[i] = t.ID
+			}
+			return strings.Join(ids, "\n")
+		},
+		CSV: func() [][]string {
+			rows := [][]string{{"zone", "id", "path"}}
+			for _, t := range taken {
+				rows = append(rows, []string{t.Zone, t.ID, t.Path})
+			}
+			return rows
+		},
+	})
+}
+
+func cmdRestoreZone(c *cli.Context) error {
+
+	// Validate arguments
+	if c.NArg() == 0 {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "zonename is required")
+	}
+	if !c.IsSet("snapshot") {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "--snapshot is required")
+	}
+	zonename := strings.ToLower(strings.TrimSuffix(c.Args().First(), "."))
+	dir, err := snapshotDir(c)
+	if err != nil {
+		return wrapError(err)
+	}
+	snap, err := loadZoneSnapshot(dir, zonename, c.String("snapshot"))
+	if err != nil {
+		return wrapError(err)
+	}
+
+	// Initialize context and Edgegrid session
+	ctx := context.Background()
+
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	dnsClient := dns.Client(edgegrid.GetSession(ctx))
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Planning restore of %s to snapshot %s (%s)...", zonename, snap.ID, snap.Created))
+	zp, err := planZoneRestore(ctx, dnsClient, snap)
+	if err != nil {
+		return apiError(err, "Restore plan failed: %v", err)
+	}
+
+	// Show the plan
+	plan := &ApplyPlan{CreatedAt: snap.Created, Zones: []ZoneApplyPlan{*zp}}
+	if err := writeOutput(c, applyPlanOutput(plan)); err != nil {
+		return err
+	}
+
+	if zp.Action == applyNoop {
+		fmt.Fprintln(os.Stderr, color.GreenString("No changes. Zone %s matches snapshot %s.", zonename, snap.ID))
+		return nil
+	}
+	if c.Bool("dry-run") {
+		return nil
+	}
+
+	if !c.Bool("auto-approve") {
+		if c.Bool("non-interactive") {
+			return newCommandError(exitValidation, "Refusing to restore without --auto-approve in non-interactive mode")
+		}
+		fmt.Fprintf(os.Stderr, "Restore %s to snapshot %s? [y/N]: ", zonename, snap.ID)
+		reader := bufio.NewReader(os.Stdin)
+		resp, _ := reader.ReadString('\n')
+		resp = strings.ToLower(strings.TrimSpace(resp))
+		if resp != "y" && resp != "yes" {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return nil
+		}
+	}
+
+	// The state being replaced is kept so the restore itself can be undone
+	current, path, err := takeZoneSnapshot(ctx, dnsClient, dir, zonename, c.Command.FullName())
+	if err != nil {
+		return apiError(err, "Snapshot failed: %v", err)
+	}
+	fmt.Fprintln(os.Stderr, color.BlueString("Snapshot %s of the current state written to %s", current.ID, path))
+
+	if err := checkZonePlanVersion(ctx, dnsClient, *zp); err != nil {
+		return wrapError(err)
+	}
+	fmt.Fprintln(os.Stderr, color.BlueString("Restoring %s...", zonename))
+	if err := applyZonePlan(ctx, dnsClient, *zp); err != nil {
+		return apiError(err, "Restore failed for zone %s: %v", zonename, err)
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("Zone %s restored to snapshot %s", zonename, snap.ID))
+	return nil
+}
@@ -16,15 +16,12 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/akamai/cli-dns/edgegrid"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
-	"github.com/fatih/color"
 	"github.com/urfave/cli"
 )
 
@@ -35,13 +32,12 @@ func cmdStatusBulkZones(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	var (
-		outputPath string
 		requestids []string
 		op         = "create"
 	)
@@ -49,97 +45,46 @@ func cmdStatusBulkZones(c *cli.Context) error {
 	// Retrieve request IDs from CLI flags
 	requestids = c.StringSlice("requestid")
 	if len(requestids) < 1 {
-		return cli.NewExitError(color.RedString("requestid(s) required. "), 1)
+		return newCommandError(exitValidation, "requestid(s) required. ")
 	}
 
-	fmt.Println("Preparing bulk zones status request ", "")
+	fmt.Fprintln(os.Stderr, "Preparing bulk zones status request")
 
 	// Validate that either --create or --delete is set
 	if (c.IsSet("create") && c.IsSet("delete")) || (!c.IsSet("create") && !c.IsSet("delete")) {
-		return cli.NewExitError(color.RedString("Either create or delete arg is required. "), 1)
+		return newCommandError(exitValidation, "Either create or delete arg is required. ")
 	}
 	if c.IsSet("delete") {
 		op = "delete"
 	}
-	if c.IsSet("output") {
-		outputPath = c.String("output")
-		outputPath = filepath.FromSlash(outputPath)
+
+	// Poll until every request completes and print the results
+	if c.Bool("wait") {
+		results, failures, submitted, err := waitBulkZones(ctx, dnsClient, op, requestids, c)
+		if err != nil {
+			return wrapError(err)
+		}
+		if err := writeOutput(c, results); err != nil {
+			return err
+		}
+		return bulkWaitExitError(op, failures, submitted)
 	}
 
-	var statusResp *dns.BulkStatusResponse
 	statusRespList := make([]*dns.BulkStatusResponse, 0)
-	fmt.Println("Submitting Bulk Zones request(s)  ", "")
+	fmt.Fprintln(os.Stderr, "Submitting Bulk Zones request(s)")
 
 	// Loop through all provided request IDs
 	for _, requestid := range requestids {
-		if op == "create" {
-			// Get bulk zone create status
-			r, err := dnsClient.GetBulkZoneCreateStatus(ctx, dns.GetBulkZoneCreateStatusRequest{
-				RequestID: requestid,
-			})
-			if err != nil {
-				return cli.NewExitError(color.RedString(fmt.Sprintf("Bulk Zone Create Status query failed: %s", err)), 1)
-			}
-			statusResp = &dns.BulkStatusResponse{
-				RequestID:      r.RequestID,
-				ZonesSubmitted: r.ZonesSubmitted,
-				SuccessCount:   r.SuccessCount,
-				FailureCount:   r.FailureCount,
-				IsComplete:     r.IsComplete,
-				ExpirationDate: r.ExpirationDate,
-			}
-		} else {
-			// Get bulk zone delete status
-			r, err := dnsClient.GetBulkZoneDeleteStatus(ctx, dns.GetBulkZoneDeleteStatusRequest{
-				RequestID: requestid,
-			})
-			if err != nil {
-				return cli.NewExitError(color.RedString(fmt.Sprintf("Bulk Zone Delete Status query failed: %s", err)), 1)
-			}
-			statusResp = &dns.BulkStatusResponse{
-				RequestID:      r.RequestID,
-				ZonesSubmitted: r.ZonesSubmitted,
-				SuccessCount:   r.SuccessCount,
-				FailureCount:   r.FailureCount,
-				IsComplete:     r.IsComplete,
-				ExpirationDate: r.ExpirationDate,
-			}
-		}
-		statusRespList = append(statusRespList, statusResp)
-	}
-
-	results := ""
-	fmt.Println("Assembling Bulk Zone Response Content ", "")
-	if c.IsSet("json") && c.Bool("json") {
-		zjson, err := json.MarshalIndent(statusRespList, "", "  ")
+		statusResp, err := bulkZoneStatus(ctx, dnsClient, op, requestid)
 		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to process status response(s)"), 1)
+			return wrapError(err)
 		}
-		results = string(zjson)
-	} else {
-		results = renderBulkZonesStatusTable(statusRespList, c)
+		statusRespList = append(statusRespList, statusResp)
 	}
 
 	// Write output to file or console
-	if len(outputPath) > 1 {
-		//fmt.Printf("Writing Output to %s ", outputPath)
-		zfHandle, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to create output file. Error: %s", err.Error())), 1)
-		}
-		defer zfHandle.Close()
-		_, err = zfHandle.WriteString(string(results))
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to write zone output to file"), 1)
-		}
-		zfHandle.Sync()
-		fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
-		return nil
-	} else {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, results)
-	}
-
-	return nil
-
+	return writeOutput(c, &CommandOutput{
+		Value: statusRespList,
+		Table: func() string { return renderBulkZonesStatusTable(statusRespList, c) },
+	})
 }
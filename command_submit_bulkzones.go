@@ -48,205 +48,183 @@ func cmdSubmitBulkZones(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	var (
-		outputPath     string
 		contractid     string
 		groupid        string
 		inputPath      string
+		journalPath    string
 		bulkDeleteList *dns.ZoneNameListResponse
 		newBulkZones   *dns.BulkZonesCreate
+		journal        *BulkZonesJournal
 		op             string = "create"
 		bypass         bool
 		maxNumZones    int = 1000
 	)
 
-	fmt.Println("Preparing bulk zones submit request ", "")
-	queryArgs := dns.ZoneQueryString{}
-
-	if c.IsSet("contractid") {
-		contractid = c.String("contractid")
-		queryArgs.Contract = contractid
-	} else if c.IsSet("create") {
-		return cli.NewExitError(color.RedString("contractid is required"), 1)
-	}
-	if c.IsSet("groupid") {
-		groupid = c.String("groupid")
-		if groupid != "" {
-			queryArgs.Group = groupid
-			fmt.Println("Using groupid:", groupid)
-		} else {
-			fmt.Println("groupid flag set but empty; ignoring")
-		}
-	} else {
-		fmt.Println("groupid flag not set; proceeding without groupid")
-	}
-	if c.IsSet("bypassZoneSafety") && c.Bool("bypassZoneSafety") {
-		bypass = true
-	}
-
-	// Validate that only one operation is selected (create or delete)
-	if (c.IsSet("create") && c.IsSet("delete")) || (!c.IsSet("create") && !c.IsSet("delete")) {
-		return cli.NewExitError(color.RedString("Either create or delete arg is required. "), 1)
-	}
-
-	// Creating object based on operation type
-	if c.IsSet("delete") {
-		op = "delete"
-		bulkDeleteList = &dns.ZoneNameListResponse{}
-	} else {
-		newBulkZones = &dns.BulkZonesCreate{}
-	}
-	if op == "create" {
-		if bypass {
-			fmt.Println("Warning: bypassZoneSafety arg ignored")
-		}
-	} else {
-		if c.IsSet("contractid") {
-			fmt.Println("Warning: contractid arg ignored")
-		}
-		if c.IsSet("groupid") {
-			fmt.Println("Warning: groupid arg ignored")
-		}
-	}
-	if c.IsSet("file") {
-		inputPath = c.String("file")
-		inputPath = filepath.FromSlash(inputPath)
-	} else {
-		return cli.NewExitError(color.RedString(" Bulk create JSON source file must be specified"), 1)
-	}
-	if c.IsSet("output") {
-		outputPath = c.String("output")
-		outputPath = filepath.FromSlash(outputPath)
-	}
-
 	val, ok := os.LookupEnv("AKAMAI_ZONES_BATCH_SIZE")
 	if ok {
 		batchsize, err := strconv.Atoi(val)
 		if err != nil {
-			return cli.NewExitError(color.RedString(" Environ variable AKAMAI_ZONEBATCH has invalid value"), 1)
+			return newCommandError(exitValidation, "Invalid AKAMAI_ZONES_BATCH_SIZE value %q. Use a number of zones per batch", val)
 		}
 		maxNumZones = batchsize
 	}
-
-	data, err := os.ReadFile(inputPath)
-	if err != nil {
-		return cli.NewExitError(color.RedString("Failed to read input file"), 1)
-	}
-
-	if op == "create" {
-		err = json.Unmarshal(data, newBulkZones)
+	if c.IsSet("journal") {
+		journalPath = filepath.FromSlash(c.String("journal"))
 	} else {
-		err = json.Unmarshal(data, bulkDeleteList)
+		journalPath = fmt.Sprintf("Bulk_Submit_Journal_%d.json", time.Now().Unix())
 	}
-	if err != nil {
-		return cli.NewExitError(color.RedString("Failed to parse json file content into bulk zones object"), 1)
+	if c.IsSet("resume") && c.IsSet("retry-failed") {
+		return newCommandError(exitValidation, "Only one of resume or retry-failed may be set. ")
 	}
 
-	/*var (
-		submitStatusList []*dns.CreateBulkZonesResponse
-		deleteResp       *dns.DeleteBulkZonesResponse
-	)*/
+	switch {
+	case c.IsSet("resume"):
+		// Continue an interrupted submission from its journal
+		journal, err = loadBulkZonesJournal(filepath.FromSlash(c.String("resume")))
+		if err != nil {
+			return wrapError(err)
+		}
+		op = journal.Op
+		fmt.Fprintln(os.Stderr, "Resuming bulk zones submit request from", journal.path)
 
-	submitStatusList := make([]*dns.BulkZonesResponse, 0)
+	case c.IsSet("retry-failed"):
+		// Resubmit the zones that failed in a completed submission
+		prev, err := loadBulkZonesJournal(filepath.FromSlash(c.String("retry-failed")))
+		if err != nil {
+			return wrapError(err)
+		}
+		op = prev.Op
+		fmt.Fprintln(os.Stderr, "Collecting failed zones from", prev.path)
+		journal, err = bulkRetryJournal(ctx, dnsClient, prev, journalPath, maxNumZones)
+		if err != nil {
+			return wrapError(err)
+		}
 
-	fmt.Println("Submitting Bulk Zones request  ", "")
-
-	// Handling bulk create in batches
-	if op == "create" {
-		ZonesMax := len(newBulkZones.Zones)
-		numZones := ZonesMax
-		if ZonesMax > maxNumZones {
-			numZones = maxNumZones
-		}
-		bulkZonesList := make([]*dns.BulkZonesCreate, 0)
-		bulkZones := &dns.BulkZonesCreate{Zones: make([]dns.ZoneCreate, 0)}
-
-		for _, zone := range newBulkZones.Zones {
-			bulkZones.Zones = append(bulkZones.Zones, zone)
-			numZones--
-			ZonesMax--
-			if numZones == 0 {
-				bulkZonesList = append(bulkZonesList, bulkZones)
-				bulkZones = &dns.BulkZonesCreate{Zones: make([]dns.ZoneCreate, 0)}
-				numZones = ZonesMax
-				if ZonesMax > maxNumZones {
-					numZones = maxNumZones
-				}
-			}
+	default:
+		fmt.Fprintln(os.Stderr, "Preparing bulk zones submit request")
 
+		contractid = contractIDFlag(c)
+		if contractid == "" && c.IsSet("create") {
+			return newCommandError(exitValidation, "contractid is required")
+		}
+		groupid = groupIDFlag(c)
+		if groupid != "" {
+			fmt.Fprintln(os.Stderr, "Using groupid:", groupid)
+		} else if c.IsSet("groupid") {
+			fmt.Fprintln(os.Stderr, "groupid flag set but empty; ignoring")
+		} else {
+			fmt.Fprintln(os.Stderr, "groupid flag not set; proceeding without groupid")
 		}
-		if len(bulkZones.Zones) > 0 {
-			bulkZonesList = append(bulkZonesList, bulkZones)
+		if c.IsSet("bypassZoneSafety") && c.Bool("bypassZoneSafety") {
+			bypass = true
 		}
 
-		// Submit each batch to API
-		for _, zonesRequest := range bulkZonesList {
-			req := dns.CreateBulkZonesRequest{
-				BulkZones:       zonesRequest,
-				ZoneQueryString: queryArgs,
+		// Validate that only one operation is selected (create or delete)
+		if (c.IsSet("create") && c.IsSet("delete")) || (!c.IsSet("create") && !c.IsSet("delete")) {
+			return newCommandError(exitValidation, "Either create or delete arg is required. ")
+		}
+
+		// Creating object based on operation type
+		if c.IsSet("delete") {
+			op = "delete"
+			bulkDeleteList = &dns.ZoneNameListResponse{}
+		} else {
+			newBulkZones = &dns.BulkZonesCreate{}
+		}
+		if op == "create" {
+			if bypass {
+				fmt.Fprintln(os.Stderr, "Warning: bypassZoneSafety arg ignored")
+			}
+		} else {
+			if c.IsSet("contractid") {
+				fmt.Fprintln(os.Stderr, "Warning: contractid arg ignored")
 			}
-			resp, err := dnsClient.CreateBulkZones(ctx, req)
-			if err != nil {
-				return cli.NewExitError(color.RedString("bulk zone submit request failed: %s", err), 1)
+			if c.IsSet("groupid") {
+				fmt.Fprintln(os.Stderr, "Warning: groupid arg ignored")
 			}
-			submitStatusList = append(submitStatusList, &dns.BulkZonesResponse{
-				RequestID:      resp.RequestID,
-				ExpirationDate: resp.ExpirationDate,
-			})
 		}
-	} else {
-		// Handle bulk zone DELETE request
-		resp, err := dnsClient.DeleteBulkZones(ctx, dns.DeleteBulkZonesRequest{
-			ZonesList:          bulkDeleteList,
-			BypassSafetyChecks: &bypass,
-		})
+		if c.IsSet("file") {
+			inputPath = c.String("file")
+			inputPath = filepath.FromSlash(inputPath)
+		} else {
+			return newCommandError(exitValidation, " Bulk create JSON source file must be specified")
+		}
+
+		data, err := os.ReadFile(inputPath)
 		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Bulk Zone Request submit failed. Error: %s", err.Error())), 1)
+			return newCommandError(exitValidation, "Failed to read input file")
 		}
-		submitStatusList = append(submitStatusList, &dns.BulkZonesResponse{
-			RequestID:      resp.RequestID,
-			ExpirationDate: resp.ExpirationDate,
-		})
-	}
 
-	// Format results in JSON or table format
-	results := ""
-	if c.IsSet("json") && c.Bool("json") {
-		jsonBytes, err := json.MarshalIndent(submitStatusList, "", " ")
+		if op == "create" {
+			err = json.Unmarshal(data, newBulkZones)
+		} else {
+			err = json.Unmarshal(data, bulkDeleteList)
+		}
 		if err != nil {
-			return cli.NewExitError(color.RedString("unable to marshal"), 1)
+			return newCommandError(exitValidation, "Failed to parse json file content into bulk zones object")
+		}
+
+		// Handling bulk create in batches
+		if op == "create" {
+			journal = newBulkCreateJournal(journalPath, newBulkZones.Zones, maxNumZones, contractid, groupid)
+		} else {
+			journal = newBulkDeleteJournal(journalPath, bulkDeleteList.Zones, bypass)
 		}
-		results = string(jsonBytes)
-	} else {
-		results = renderBulkZonesRequestStatusTable(submitStatusList, c)
 	}
 
-	// Write output either to output file or console
-	if len(outputPath) < 1 {
-		outputPath = fmt.Sprintf("Bulk_Submit_Request_Status_%d.json", time.Now().Unix())
+	pending := 0
+	for _, b := range journal.Batches {
+		if b.RequestID == "" && len(b.Zones)+len(b.ZoneNames) > 0 {
+			pending++
+		}
+	}
+	if len(journal.requestIDs()) == 0 && pending == 0 {
+		fmt.Fprintln(os.Stderr, color.GreenString("No zones to submit"))
+		return nil
 	}
 
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return cli.NewExitError(color.RedString("failed to create output file: %s", err), 1)
+	// The journal is written before the first request so every batch is accounted for
+	if err := journal.save(); err != nil {
+		return wrapError(err)
 	}
-	defer file.Close()
+	fmt.Fprintln(os.Stderr, color.BlueString("Journal written to %s", journal.path))
 
-	if _, err := file.WriteString(results); err != nil {
-		return cli.NewExitError(color.RedString("failed to write output"), 1)
+	fmt.Fprintln(os.Stderr, "Submitting Bulk Zones request")
+	// With --snapshot each zone is saved before its delete batch is submitted
+	snapshot := func(zones ...string) error { return snapshotBeforeChange(ctx, dnsClient, c, zones...) }
+	if err := submitBulkZonesJournal(ctx, dnsClient, journal, snapshot); err != nil {
+		return apiError(err, "%s. Continue with --resume %s", err, journal.path)
 	}
 
-	if c.IsSet("suppress") && c.Bool("suppress") {
-		return nil
+	submitStatusList := make([]*dns.BulkZonesResponse, 0)
+	for _, b := range journal.Batches {
+		submitStatusList = append(submitStatusList, &dns.BulkZonesResponse{
+			RequestID:      b.RequestID,
+			ExpirationDate: b.ExpirationDate,
+		})
 	}
 
-	fmt.Fprintln(c.App.Writer, "")
-	fmt.Fprintln(c.App.Writer, results)
-	fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
-	return nil
+	// With --wait only the final results are written; the request ids are in the journal
+	if !c.Bool("wait") {
+		return writeOutput(c, &CommandOutput{
+			Value: submitStatusList,
+			Table: func() string { return renderBulkZonesRequestStatusTable(submitStatusList, c) },
+		})
+	}
+
+	// Poll until every batch completes and print the results
+	results, failures, submitted, err := waitBulkZones(ctx, dnsClient, op, journal.requestIDs(), c)
+	if err != nil {
+		return wrapError(err)
+	}
+	if err := writeOutput(c, results); err != nil {
+		return err
+	}
+	return bulkWaitExitError(op, failures, submitted)
 }
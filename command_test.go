@@ -0,0 +1,1085 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/session"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/urfave/cli"
+)
+
+// End to end tests of the commands against the mock API
+
+// cliResult is the exit code and output of one CLI run
+type cliResult struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+// Start a mock API for the test and point the cache and config at temporary files
+func startMockAPI(t *testing.T) string {
+	t.Helper()
+	api, err := newMockAPI("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	isolateCLI(t)
+	return srv.URL
+}
+
+// Keep the zone cache and config of the test runs in temporary files
+func isolateCLI(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("AKAMAI_DNS_CACHE_DIR", filepath.Join(dir, "cache"))
+	t.Setenv("AKAMAI_DNS_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("AKAMAI_DNS_PROFILE", "")
+}
+
+// Run the CLI against endpoint, capturing STDOUT and STDERR. Without an endpoint
+// the arguments choose where requests go, such as --replay.
+func runCLI(t *testing.T, endpoint string, args ...string) cliResult {
+	t.Helper()
+	stdout, stderr := captureFile(t), captureFile(t)
+	origStdout, origStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+
+	if endpoint != "" {
+		args = append([]string{"--endpoint", endpoint}, args...)
+	}
+	app := newApp()
+	app.ExitErrHandler = func(*cli.Context, error) {}
+	err := app.Run(append([]string{"akamai-dns"}, args...))
+	os.Stdout, os.Stderr = origStdout, origStderr
+
+	res := cliResult{stdout: readCapture(t, stdout), stderr: readCapture(t, stderr)}
+	if err != nil {
+		res.code = wrapError(err).Code
+		res.stderr += err.Error()
+	}
+	return res
+}
+
+func captureFile(t *testing.T) *os.File {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func readCapture(t *testing.T, f *os.File) string {
+	t.Helper()
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// Run the CLI and fail the test unless it exits with code
+func mustRun(t *testing.T, endpoint string, code int, args ...string) cliResult {
+	t.Helper()
+	res := runCLI(t, endpoint, args...)
+	if res.code != code {
+		t.Fatalf("%s: exit code %d, want %d\nstdout: %s\nstderr: %s", strings.Join(args, " "), res.code, code, res.stdout, res.stderr)
+	}
+	return res
+}
+
+func decodeOutput(t *testing.T, res cliResult, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(res.stdout), v); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, res.stdout)
+	}
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Recordsets of a zone by recordSetKey
+func zoneRecordSets(t *testing.T, endpoint, zone string) map[string]dns.RecordSet {
+	t.Helper()
+	var list ZoneExport
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "list-recordsets", zone, "--json"), &list)
+	sets := map[string]dns.RecordSet{}
+	for _, rs := range list.RecordSets {
+		sets[recordSetKey(rs.Name, rs.Type)] = rs
+	}
+	return sets
+}
+
+func createTestZone(t *testing.T, endpoint, zone string) {
+	t.Helper()
+	mustRun(t, endpoint, exitOK, "create-zoneconfig", zone, "--type", "PRIMARY", "--contractid", "C-1", "--initialize", "--suppress")
+}
+
+// API session for the mock API, set up the way the commands set up theirs, for
+// tests that drive a server command's handler directly
+func testSession(t *testing.T, endpoint string) session.Session {
+	t.Helper()
+	var sess session.Session
+	app := newApp()
+	app.ExitErrHandler = func(*cli.Context, error) {}
+	app.Commands = []cli.Command{{
+		Name: "session",
+		Action: func(c *cli.Context) (err error) {
+			sess, err = edgegrid.InitializeSession(c)
+			return err
+		},
+	}}
+	if err := app.Run([]string{"akamai-dns", "--endpoint", endpoint, "session"}); err != nil {
+		t.Fatal(err)
+	}
+	return sess
+}
+
+func TestZoneConfigCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+
+	var created dns.ZoneResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "create-zoneconfig", "example.com", "--type", "PRIMARY",
+		"--contractid", "C-1", "--comment", "first", "--initialize", "--json"), &created)
+	if created.Zone != "example.com" || created.Type != "PRIMARY" || created.Comment != "first" {
+		t.Errorf("create-zoneconfig returned %+v", created)
+	}
+	mustRun(t, endpoint, exitConflict, "create-zoneconfig", "example.com", "--type", "PRIMARY", "--contractid", "C-1")
+	mustRun(t, endpoint, exitValidation, "create-zoneconfig", "bad.com", "--type", "PRIMARY")
+
+	var updated dns.ZoneResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "update-zoneconfig", "example.com", "--type", "PRIMARY", "--comment", "second", "--json"), &updated)
+	if updated.Comment != "second" || updated.VersionID == created.VersionID {
+		t.Errorf("update-zoneconfig returned %+v", updated)
+	}
+
+	var retrieved dns.ZoneResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "retrieve-zoneconfig", "example.com", "--json"), &retrieved)
+	if retrieved.Comment != "second" {
+		t.Errorf("retrieve-zoneconfig comment %q, want second", retrieved.Comment)
+	}
+	mustRun(t, endpoint, exitNotFound, "retrieve-zoneconfig", "missing.com", "--json")
+
+	createTestZone(t, endpoint, "example.org")
+	var list []dns.ZoneResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "list-zoneconfig", "--json"), &list)
+	if len(list) != 2 {
+		t.Errorf("list-zoneconfig returned %d zones, want 2", len(list))
+	}
+
+	// The initialized zone has its SOA and apex NS records
+	sets := zoneRecordSets(t, endpoint, "example.com")
+	if _, ok := sets[recordSetKey("example.com", "SOA")]; !ok {
+		t.Error("initialized zone has no SOA record")
+	}
+	if _, ok := sets[recordSetKey("example.com", "NS")]; !ok {
+		t.Error("initialized zone has no NS records")
+	}
+}
+
+func TestRecordsetCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+
+	mustRun(t, endpoint, exitOK, "create-recordset", "example.com", "--name", "www.example.com", "--type", "A",
+		"--ttl", "300", "--rdata", "192.0.2.1", "--suppress")
+	mustRun(t, endpoint, exitConflict, "create-recordset", "example.com", "--name", "www.example.com", "--type", "A",
+		"--ttl", "300", "--rdata", "192.0.2.1", "--suppress")
+
+	var rs dns.RecordSet
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "retrieve-recordset", "example.com", "--name", "www.example.com", "--type", "A", "--json"), &rs)
+	if rs.TTL != 300 || len(rs.Rdata) != 1 || rs.Rdata[0] != "192.0.2.1" {
+		t.Errorf("retrieve-recordset returned %+v", rs)
+	}
+
+	mustRun(t, endpoint, exitOK, "update-recordset", "example.com", "--name", "www.example.com", "--type", "A",
+		"--ttl", "600", "--rdata", "192.0.2.1", "--rdata", "192.0.2.2", "--suppress")
+	if got := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; got.TTL != 600 || len(got.Rdata) != 2 {
+		t.Errorf("updated recordset is %+v", got)
+	}
+
+	file := writeTestFile(t, "recordsets.json", `{"recordsets": [
+		{"name": "mail.example.com", "type": "MX", "ttl": 300, "rdata": ["10 mx.example.net."]},
+		{"name": "txt.example.com", "type": "TXT", "ttl": 300, "rdata": ["\"hello world\""]}
+	]}`)
+	mustRun(t, endpoint, exitOK, "create-recordsets", "example.com", "--file", file, "--suppress")
+	sets := zoneRecordSets(t, endpoint, "example.com")
+	for _, key := range []string{recordSetKey("mail.example.com", "MX"), recordSetKey("txt.example.com", "TXT")} {
+		if _, ok := sets[key]; !ok {
+			t.Errorf("create-recordsets did not create %s", key)
+		}
+	}
+
+	mustRun(t, endpoint, exitOK, "delete-recordset", "example.com", "--name", "www.example.com", "--type", "A", "--suppress")
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; ok {
+		t.Error("delete-recordset left the recordset")
+	}
+	mustRun(t, endpoint, exitNotFound, "retrieve-recordset", "example.com", "--name", "www.example.com", "--type", "A", "--json")
+}
+
+func TestZoneCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+
+	// A merged master file keeps the existing recordsets
+	mustRun(t, endpoint, exitOK, "create-recordset", "example.com", "--name", "old.example.com", "--type", "A",
+		"--ttl", "300", "--rdata", "192.0.2.9", "--suppress")
+	zonefile := writeTestFile(t, "example.com.zone", `$ORIGIN example.com.
+$TTL 300
+www   IN A     192.0.2.1
+WWW   IN A     192.0.2.2
+txt   IN TXT   "two  spaces"
+`)
+	var plan ZonePlan
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "update-zone", "example.com", "--dns", "--merge", "--file", zonefile, "--plan", "--json"), &plan)
+	if added, _, _ := summarizeChanges(plan.Changes); added != 2 {
+		t.Errorf("update-zone --plan adds %d recordsets, want 2: %+v", added, plan.Changes)
+	}
+	mustRun(t, endpoint, exitOK, "update-zone", "example.com", "--dns", "--merge", "--file", zonefile, "--suppress")
+
+	sets := zoneRecordSets(t, endpoint, "example.com")
+	if www := sets[recordSetKey("www.example.com", "A")]; len(www.Rdata) != 2 {
+		t.Errorf("www.example.com A is %+v, want two addresses", www)
+	}
+	if txt := sets[recordSetKey("txt.example.com", "TXT")]; len(txt.Rdata) != 1 || txt.Rdata[0] != `"two  spaces"` {
+		t.Errorf("txt.example.com TXT is %+v", txt)
+	}
+	if _, ok := sets[recordSetKey("old.example.com", "A")]; !ok {
+		t.Error("update-zone --merge removed old.example.com")
+	}
+
+	var zone struct {
+		Zone    *dns.GetZoneResponse `json:"zone"`
+		Records []dns.RecordSet      `json:"records"`
+	}
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "retrieve-zone", "example.com", "--json"), &zone)
+	if zone.Zone == nil || zone.Zone.Zone != "example.com" || len(zone.Records) != len(sets) {
+		t.Errorf("retrieve-zone returned %d recordsets, want %d", len(zone.Records), len(sets))
+	}
+	bind := mustRun(t, endpoint, exitOK, "retrieve-zone", "example.com", "--format", "bind")
+	if !strings.Contains(bind.stdout, "192.0.2.9") {
+		t.Errorf("retrieve-zone --format bind is missing old.example.com:\n%s", bind.stdout)
+	}
+
+	// A replacing upload sends the parsed recordsets, with $INCLUDE resolved locally
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hosts.zone"), []byte("host A 192.0.2.7\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	replaced := filepath.Join(dir, "example.com.zone")
+	soa := sets[recordSetKey("example.com", "SOA")]
+	ns := sets[recordSetKey("example.com", "NS")]
+	content := fmt.Sprintf("$TTL 300\n@ SOA %s\n@ NS %s\n$INCLUDE hosts.zone lab\n", soa.Rdata[0], ns.Rdata[0])
+	if err := os.WriteFile(replaced, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	mustRun(t, endpoint, exitOK, "update-zone", "example.com", "--dns", "--file", replaced, "--no-lint", "--suppress")
+	sets = zoneRecordSets(t, endpoint, "example.com")
+	if _, ok := sets[recordSetKey("host.lab.example.com", "A")]; !ok {
+		t.Errorf("update-zone --dns did not resolve $INCLUDE: %v", sets)
+	}
+	if _, ok := sets[recordSetKey("old.example.com", "A")]; ok {
+		t.Error("update-zone --dns kept old.example.com")
+	}
+}
+
+func TestChangeListCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+
+	mustRun(t, endpoint, exitNotFound, "changelist", "show", "example.com", "--json")
+	mustRun(t, endpoint, exitOK, "changelist", "create", "example.com", "--suppress")
+	mustRun(t, endpoint, exitConflict, "changelist", "create", "example.com", "--suppress")
+
+	var view ChangeListView
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "changelist", "add", "example.com", "--op", "add",
+		"--name", "www", "--type", "A", "--ttl", "300", "--rdata", "192.0.2.1", "--json"), &view)
+	found := false
+	for _, rs := range view.RecordSets {
+		found = found || recordSetKey(rs.Name, rs.Type) == recordSetKey("www.example.com", "A")
+	}
+	if !found {
+		t.Errorf("changelist add result has no www.example.com A: %+v", view.RecordSets)
+	}
+
+	var diff ZonePlan
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "changelist", "diff", "example.com", "--json"), &diff)
+	if added, removed, changed := summarizeChanges(diff.Changes); added != 1 || removed != 0 || changed != 0 {
+		t.Errorf("changelist diff is %d added, %d removed, %d changed", added, removed, changed)
+	}
+
+	// The staged record is only live after submit
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; ok {
+		t.Error("staged recordset is live before submit")
+	}
+	var submitted ZonePlan
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "changelist", "submit", "example.com", "--json"), &submitted)
+	if len(submitted.Changes) != 1 {
+		t.Errorf("changelist submit reported %d changes, want 1", len(submitted.Changes))
+	}
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; !ok {
+		t.Error("submitted recordset is not live")
+	}
+	mustRun(t, endpoint, exitNotFound, "changelist", "show", "example.com", "--json")
+
+	// A discarded change list leaves the zone alone
+	mustRun(t, endpoint, exitOK, "changelist", "create", "example.com", "--suppress")
+	mustRun(t, endpoint, exitOK, "changelist", "add", "example.com", "--op", "delete", "--name", "www", "--type", "A", "--suppress")
+	var discarded ChangeListView
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "changelist", "discard", "example.com", "--json"), &discarded)
+	if discarded.Zone != "example.com" {
+		t.Errorf("changelist discard returned %+v", discarded)
+	}
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; !ok {
+		t.Error("discarded change was applied")
+	}
+	mustRun(t, endpoint, exitNotFound, "changelist", "discard", "example.com", "--suppress")
+}
+
+func TestBulkZoneCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	dir := t.TempDir()
+	createFile := writeTestFile(t, "create.json", `{"zones": [
+		{"zone": "a.example", "type": "PRIMARY", "contractId": "C-1"},
+		{"zone": "b.example", "type": "PRIMARY", "contractId": "C-1"}
+	]}`)
+
+	var submitted []dns.BulkZonesResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "submit-bulkzones", "--create", "--contractid", "C-1", "--groupid", "1",
+		"--file", createFile, "--journal", filepath.Join(dir, "create.json"), "--json"), &submitted)
+	if len(submitted) != 1 || submitted[0].RequestID == "" {
+		t.Fatalf("submit-bulkzones returned %+v", submitted)
+	}
+	id := submitted[0].RequestID
+
+	var status []dns.BulkStatusResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "status-bulkzones", "--create", "--requestid", id, "--json"), &status)
+	if len(status) != 1 || !status[0].IsComplete || status[0].SuccessCount != 2 {
+		t.Errorf("status-bulkzones returned %+v", status)
+	}
+
+	var results []dns.BulkCreateResultResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "result-bulkzones", "--create", "--requestid", id, "--json"), &results)
+	if len(results) != 1 || len(results[0].SuccessfullyCreatedZones) != 2 {
+		t.Errorf("result-bulkzones returned %+v", results)
+	}
+	mustRun(t, endpoint, exitOK, "retrieve-zoneconfig", "a.example", "--suppress")
+
+	// Creating the same zones again fails for both
+	res := mustRun(t, endpoint, exitError, "submit-bulkzones", "--create", "--contractid", "C-1", "--groupid", "1",
+		"--file", createFile, "--journal", filepath.Join(dir, "again.json"), "--wait", "--poll-interval", "1", "--json")
+	if !strings.Contains(res.stdout, "already exists") {
+		t.Errorf("submit-bulkzones --wait output has no failure reason:\n%s", res.stdout)
+	}
+
+	deleteFile := writeTestFile(t, "delete.json", `{"zones": ["a.example", "b.example"]}`)
+	snapDir := t.TempDir()
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "submit-bulkzones", "--delete", "--bypasszonesafety",
+		"--file", deleteFile, "--journal", filepath.Join(dir, "delete.json"), "--snapshot", "--snapshot-dir", snapDir, "--json"), &submitted)
+	for _, z := range []string{"a.example", "b.example"} {
+		if snaps, err := listZoneSnapshots(snapDir, z); err != nil || len(snaps) != 1 {
+			t.Errorf("submit-bulkzones --snapshot saved %d snapshots of %s: %v", len(snaps), z, err)
+		}
+	}
+	var deleted []dns.BulkDeleteResultResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "result-bulkzones", "--delete", "--requestid", submitted[0].RequestID, "--json"), &deleted)
+	if len(deleted) != 1 || len(deleted[0].SuccessfullyDeletedZones) != 2 {
+		t.Errorf("result-bulkzones --delete returned %+v", deleted)
+	}
+	mustRun(t, endpoint, exitNotFound, "retrieve-zoneconfig", "a.example", "--suppress")
+}
+
+// Per zone result of a command run against several zones with --json
+type zoneResultOutput struct {
+	Zone   string          `json:"zone"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+func TestAddRmRecordCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+	createTestZone(t, endpoint, "example.org")
+
+	// A relative name is qualified in each zone
+	var results []zoneResultOutput
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "add-record", "A", "example.com", "example.org",
+		"--name", "www", "--ttl", "300", "--rdata", "192.0.2.1", "--json"), &results)
+	if len(results) != 2 {
+		t.Fatalf("add-record returned %d zone results, want 2", len(results))
+	}
+	for _, r := range results {
+		var rs dns.RecordSet
+		if err := json.Unmarshal(r.Result, &rs); err != nil || rs.Name != "www."+r.Zone {
+			t.Errorf("add-record in %s created %s (%v)", r.Zone, rs.Name, err)
+		}
+	}
+
+	// Adding to an existing recordset merges the rdata
+	var rs dns.RecordSet
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "add-record", "A", "example.com",
+		"--name", "www.example.com", "--ttl", "300", "--rdata", "192.0.2.2", "--json"), &rs)
+	if len(rs.Rdata) != 2 {
+		t.Errorf("add-record to an existing recordset returned %+v", rs)
+	}
+	mustRun(t, endpoint, exitValidation, "add-record", "A", "example.com", "--name", "www.example.net.", "--ttl", "300", "--rdata", "192.0.2.1")
+	mustRun(t, endpoint, exitValidation, "add-record", "A", "example.com", "--name", "www")
+
+	// The preflight lint refuses a CNAME next to other data
+	mustRun(t, endpoint, exitValidation, "add-record", "CNAME", "example.com", "--name", "www", "--ttl", "300", "--rdata", "other.example.net.")
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "CNAME")]; ok {
+		t.Error("add-record created a CNAME that failed lint")
+	}
+
+	var deleted []dns.RecordSet
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "rm-record", "A", "example.com", "--name", "www", "--json"), &deleted)
+	if len(deleted) != 1 || deleted[0].Name != "www.example.com" {
+		t.Errorf("rm-record returned %+v", deleted)
+	}
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; ok {
+		t.Error("rm-record left the recordset")
+	}
+	if _, ok := zoneRecordSets(t, endpoint, "example.org")[recordSetKey("www.example.org", "A")]; !ok {
+		t.Error("rm-record removed the recordset from another zone")
+	}
+	mustRun(t, endpoint, exitNotFound, "rm-record", "A", "example.com", "--name", "www", "--suppress")
+	mustRun(t, endpoint, exitValidation, "rm-record", "A", "example.com", "--name", "www.example.org.", "--suppress")
+}
+
+func TestLintDiffZoneCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+	mustRun(t, endpoint, exitOK, "create-recordset", "example.com", "--name", "old.example.com", "--type", "A",
+		"--ttl", "300", "--rdata", "192.0.2.9", "--suppress")
+
+	bad := writeTestFile(t, "bad.zone", `$ORIGIN example.com.
+$TTL 300
+www  CNAME  other.example.net.
+www  A      192.0.2.1
+`)
+	var findings []LintFinding
+	decodeOutput(t, mustRun(t, endpoint, exitValidation, "lint-zone", "example.com", "--dns", "--file", bad, "--json"), &findings)
+	found := false
+	for _, f := range findings {
+		found = found || f.Rule == "cname-coexist" && f.Severity == lintError
+	}
+	if !found {
+		t.Errorf("lint-zone findings have no cname-coexist error: %+v", findings)
+	}
+	mustRun(t, endpoint, exitValidation, "lint-zone", "example.com", "--dns")
+
+	// The live zone is clean
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "lint-zone", "example.com", "--json"), &findings)
+	if lintHasErrors(findings) {
+		t.Errorf("lint-zone found errors in the live zone: %+v", findings)
+	}
+
+	good := writeTestFile(t, "good.zone", `$ORIGIN example.com.
+$TTL 300
+www  A  192.0.2.1
+`)
+	var plan ZonePlan
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "diff-zone", "example.com", "--dns", "--merge", "--file", good, "--json"), &plan)
+	if added, removed, _ := summarizeChanges(plan.Changes); added != 1 || removed != 0 {
+		t.Errorf("diff-zone --merge is %d added, %d removed: %+v", added, removed, plan.Changes)
+	}
+
+	// Without --merge the master file replaces the zone
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "diff-zone", "example.com", "--dns", "--file", good, "--json"), &plan)
+	removedOld := false
+	for _, ch := range plan.Changes {
+		removedOld = removedOld || ch.Action == changeRemoved && recordSetKey(ch.Name, ch.Type) == recordSetKey("old.example.com", "A")
+	}
+	if !removedOld {
+		t.Errorf("diff-zone --dns does not remove old.example.com: %+v", plan.Changes)
+	}
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; ok {
+		t.Error("diff-zone changed the zone")
+	}
+	mustRun(t, endpoint, exitNotFound, "diff-zone", "missing.com", "--dns", "--file", good, "--json")
+}
+
+func TestApplyCommand(t *testing.T) {
+	endpoint := startMockAPI(t)
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "manifests", "example.com.json")
+	writeManifest := func(address string) {
+		t.Helper()
+		data := fmt.Sprintf(`{"zone": {"zone": "example.com", "type": "primary", "contractId": "C-1"}, "groupId": "1",
+			"recordsets": [{"name": "www.example.com", "type": "A", "ttl": 300, "rdata": [%q]}]}`, address)
+		if err := os.MkdirAll(filepath.Dir(manifest), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(manifest, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeManifest("192.0.2.1")
+
+	// A saved plan is applied later without planning again
+	planFile := filepath.Join(dir, "plan.json")
+	var plan ApplyPlan
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "apply", "--manifest", filepath.Dir(manifest), "--plan-out", planFile, "--json"), &plan)
+	if len(plan.Zones) != 1 || plan.Zones[0].Action != applyCreate {
+		t.Fatalf("apply --plan-out planned %+v", plan.Zones)
+	}
+	mustRun(t, endpoint, exitNotFound, "retrieve-zoneconfig", "example.com", "--suppress")
+	mustRun(t, endpoint, exitValidation, "apply", "--plan", planFile, "--non-interactive", "--suppress")
+	mustRun(t, endpoint, exitOK, "apply", "--plan", planFile, "--auto-approve", "--suppress")
+
+	sets := zoneRecordSets(t, endpoint, "example.com")
+	if www := sets[recordSetKey("www.example.com", "A")]; len(www.Rdata) != 1 || www.Rdata[0] != "192.0.2.1" {
+		t.Errorf("applied www.example.com A is %+v", www)
+	}
+	if _, ok := sets[recordSetKey("example.com", "SOA")]; !ok {
+		t.Error("created zone has no SOA record")
+	}
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "apply", "--manifest", manifest, "--auto-approve", "--json"), &plan)
+	if plan.Zones[0].Action != applyNoop {
+		t.Errorf("apply of an applied manifest plans %s: %+v", plan.Zones[0].Action, plan.Zones[0].Changes)
+	}
+
+	// A plan is refused once the zone changes under it
+	writeManifest("192.0.2.2")
+	mustRun(t, endpoint, exitOK, "apply", "--manifest", manifest, "--plan-out", planFile, "--suppress")
+	mustRun(t, endpoint, exitOK, "create-recordset", "example.com", "--name", "mail.example.com", "--type", "A",
+		"--ttl", "300", "--rdata", "192.0.2.25", "--suppress")
+	mustRun(t, endpoint, exitConflict, "apply", "--plan", planFile, "--auto-approve", "--suppress")
+	mustRun(t, endpoint, exitOK, "apply", "--manifest", manifest, "--auto-approve", "--suppress")
+	sets = zoneRecordSets(t, endpoint, "example.com")
+	if www := sets[recordSetKey("www.example.com", "A")]; len(www.Rdata) != 1 || www.Rdata[0] != "192.0.2.2" {
+		t.Errorf("applied www.example.com A is %+v", www)
+	}
+	if _, ok := sets[recordSetKey("mail.example.com", "A")]; ok {
+		t.Error("apply kept a recordset missing from the manifest")
+	}
+}
+
+func TestSnapshotRestoreCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+	dir := t.TempDir()
+
+	var taken []struct {
+		Zone string `json:"zone"`
+		ID   string `json:"id"`
+		Path string `json:"path"`
+	}
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "snapshot-zone", "example.com", "--snapshot-dir", dir, "--json"), &taken)
+	if len(taken) != 1 || taken[0].Zone != "example.com" || taken[0].ID == "" {
+		t.Fatalf("snapshot-zone returned %+v", taken)
+	}
+
+	// Mutating commands take a snapshot first when asked to
+	mustRun(t, endpoint, exitOK, "add-record", "A", "example.com", "--name", "www", "--ttl", "300", "--rdata", "192.0.2.1",
+		"--snapshot", "--snapshot-dir", dir, "--suppress")
+	var snaps []ZoneSnapshot
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "snapshot-zone", "example.com", "--list", "--snapshot-dir", dir, "--json"), &snaps)
+	if len(snaps) != 2 || snaps[0].ID != taken[0].ID {
+		t.Fatalf("snapshot-zone --list returned %d snapshots, want 2", len(snaps))
+	}
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; !ok {
+		t.Fatal("add-record --snapshot did not add the record")
+	}
+
+	var plan ApplyPlan
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "restore-zone", "example.com", "--snapshot", taken[0].ID,
+		"--snapshot-dir", dir, "--dry-run", "--json"), &plan)
+	if added, removed, _ := summarizeChanges(plan.Zones[0].Changes); added != 0 || removed != 1 {
+		t.Errorf("restore-zone --dry-run is %d added, %d removed", added, removed)
+	}
+	mustRun(t, endpoint, exitValidation, "restore-zone", "example.com", "--snapshot", taken[0].ID, "--snapshot-dir", dir, "--non-interactive", "--suppress")
+	mustRun(t, endpoint, exitOK, "restore-zone", "example.com", "--snapshot", taken[0].Path, "--snapshot-dir", dir, "--auto-approve", "--suppress")
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; ok {
+		t.Error("restore-zone kept the record added after the snapshot")
+	}
+
+	// The restore keeps the replaced state, so it can be undone
+	mustRun(t, endpoint, exitOK, "restore-zone", "example.com", "--snapshot", "latest", "--snapshot-dir", dir, "--auto-approve", "--suppress")
+	if _, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("www.example.com", "A")]; !ok {
+		t.Error("restoring the latest snapshot did not undo the restore")
+	}
+	mustRun(t, endpoint, exitNotFound, "restore-zone", "example.org", "--snapshot", "latest", "--snapshot-dir", dir, "--suppress")
+}
+
+func TestFindRecordCommand(t *testing.T) {
+	endpoint := startMockAPI(t)
+	for _, zone := range []string{"example.com", "example.org"} {
+		createTestZone(t, endpoint, zone)
+		mustRun(t, endpoint, exitOK, "create-recordset", zone, "--name", "www."+zone, "--type", "A",
+			"--ttl", "300", "--rdata", "192.0.2.1", "--suppress")
+	}
+	mustRun(t, endpoint, exitOK, "create-recordset", "example.com", "--name", "example.com", "--type", "MX",
+		"--ttl", "300", "--rdata", "10 mx.old.example.net.", "--suppress")
+
+	var matches []RecordMatch
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "find-record", "--rdata", "192.0.2.1", "--json"), &matches)
+	if len(matches) != 2 {
+		t.Errorf("find-record --rdata found %d recordsets, want 2: %+v", len(matches), matches)
+	}
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "find-record", "example.com", "--rdata", "mx.old.example.net", "--type", "MX", "--json"), &matches)
+	if len(matches) != 1 || matches[0].Type != "MX" {
+		t.Errorf("find-record of an MX target returned %+v", matches)
+	}
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "find-record", "--name-regex", `^www\.example\.org$`, "--json"), &matches)
+	if len(matches) != 1 || matches[0].Zone != "example.org" {
+		t.Errorf("find-record --name-regex returned %+v", matches)
+	}
+	mustRun(t, endpoint, exitValidation, "find-record", "--replace-with", "192.0.2.2")
+
+	// Replacing shows the plan first and changes nothing with --dry-run
+	var plan ApplyPlan
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "find-record", "--rdata", "192.0.2.1", "--replace-with", "192.0.2.2", "--dry-run", "--json"), &plan)
+	if len(plan.Zones) != 2 {
+		t.Errorf("find-record --replace-with plans %d zones, want 2", len(plan.Zones))
+	}
+	mustRun(t, endpoint, exitValidation, "find-record", "--rdata", "192.0.2.1", "--replace-with", "192.0.2.2", "--non-interactive", "--suppress")
+	mustRun(t, endpoint, exitOK, "find-record", "--rdata", "192.0.2.1", "--replace-with", "192.0.2.2", "--auto-approve", "--suppress")
+	for _, zone := range []string{"example.com", "example.org"} {
+		if www := zoneRecordSets(t, endpoint, zone)[recordSetKey("www."+zone, "A")]; len(www.Rdata) != 1 || www.Rdata[0] != "192.0.2.2" {
+			t.Errorf("www.%s A is %+v after the replacement", zone, www)
+		}
+	}
+}
+
+func TestTSIGCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	const secret = "c2VjcmV0c2VjcmV0c2VjcmV0"
+	for _, zone := range []string{"a.example", "b.example"} {
+		mustRun(t, endpoint, exitOK, "create-zoneconfig", zone, "--type", "SECONDARY", "--contractid", "C-1", "--master", "192.0.2.53",
+			"--tsigname", "xfr-key", "--tsigalgorithm", "hmac-sha256", "--tsigsecret", secret, "--suppress")
+	}
+
+	// Secrets are masked unless asked for
+	var keys []dns.TSIGKeyResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "tsig", "list", "--json"), &keys)
+	if len(keys) != 1 || keys[0].Name != "xfr-key" || keys[0].ZoneCount != 2 || keys[0].Secret != "" {
+		t.Errorf("tsig list returned %+v", keys)
+	}
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "--show-secrets", "tsig", "list", "--json"), &keys)
+	if len(keys) != 1 || keys[0].Secret != secret {
+		t.Errorf("tsig list --show-secrets returned %+v", keys)
+	}
+
+	var used TSIGKeyZones
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "tsig", "zones", "--key", "xfr-key", "--json"), &used)
+	if strings.Join(used.Zones, ",") != "a.example,b.example" || used.Key.Secret != "" {
+		t.Errorf("tsig zones returned %+v", used)
+	}
+	mustRun(t, endpoint, exitNotFound, "tsig", "zones", "--key", "missing-key", "--json")
+
+	// Secondary zones keep the key they transfer with unless named
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "tsig", "delete", "--key", "xfr-key", "--json"), &used)
+	if len(used.Zones) != 0 {
+		t.Errorf("tsig delete removed the key from %v", used.Zones)
+	}
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "tsig", "delete", "--key", "xfr-key", "--zone", "a.example", "--json"), &used)
+	if strings.Join(used.Zones, ",") != "a.example" {
+		t.Errorf("tsig delete --zone removed the key from %v", used.Zones)
+	}
+	mustRun(t, endpoint, exitNotFound, "tsig", "delete", "--key", "xfr-key", "--zone", "a.example", "--suppress")
+
+	// A generated secret is printed once, in BIND format
+	res := mustRun(t, endpoint, exitOK, "tsig", "rotate", "--key", "xfr-key", "--generate")
+	if !strings.Contains(res.stdout, `key "xfr-key" {`) {
+		t.Errorf("tsig rotate --generate printed %q", res.stdout)
+	}
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "--show-secrets", "tsig", "zones", "--key", "xfr-key", "--json"), &used)
+	if used.Key.Secret == secret || !strings.Contains(res.stdout, used.Key.Secret) || strings.Join(used.Zones, ",") != "b.example" {
+		t.Errorf("rotated key is %+v", used)
+	}
+	mustRun(t, endpoint, exitValidation, "tsig", "rotate", "--key", "xfr-key", "--secret", secret, "--generate")
+}
+
+func TestDNSSecStatusCommand(t *testing.T) {
+	endpoint := startMockAPI(t)
+	mustRun(t, endpoint, exitOK, "create-zoneconfig", "signed.example", "--type", "PRIMARY", "--contractid", "C-1",
+		"--signandserve", "--algorithm", "ECDSA_P256_SHA256", "--initialize", "--suppress")
+	createTestZone(t, endpoint, "plain.example")
+
+	var statuses []DNSSecZoneStatus
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "dnssec-status", "signed.example", "plain.example", "--no-query", "--json"), &statuses)
+	if len(statuses) != 2 {
+		t.Fatalf("dnssec-status returned %d zones, want 2", len(statuses))
+	}
+	signed, plain := statuses[0], statuses[1]
+	if !signed.SignAndServe || len(signed.Keys) != 2 || len(signed.DSRecords) != 1 {
+		t.Errorf("signed zone status is %+v", signed)
+	}
+	if plain.SignAndServe || len(plain.Keys) != 0 {
+		t.Errorf("unsigned zone status is %+v", plain)
+	}
+
+	// A signed zone whose expiration cannot be read is reported, failing closed
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := conn.LocalAddr().String()
+	conn.Close()
+	decodeOutput(t, mustRun(t, endpoint, exitError, "dnssec-status", "signed.example", "plain.example", "--expiring-within", "30d",
+		"--nameserver", closed, "--timeout", "1", "--json"), &statuses)
+	if len(statuses) != 1 || statuses[0].Zone != "signed.example" || !statuses[0].Unknown {
+		t.Errorf("dnssec-status --expiring-within returned %+v", statuses)
+	}
+	mustRun(t, endpoint, exitValidation, "dnssec-status", "signed.example", "--expiring-within", "30d", "--no-query")
+	mustRun(t, endpoint, exitNotFound, "dnssec-status", "missing.example", "--no-query")
+}
+
+func TestImportZoneCommand(t *testing.T) {
+	endpoint := startMockAPI(t)
+	export := writeTestFile(t, "route53.json", `{"ResourceRecordSets": [
+		{"Name": "example.com.", "Type": "NS", "TTL": 172800, "ResourceRecords": [{"Value": "ns-1.awsdns-00.com."}]},
+		{"Name": "www.example.com.", "Type": "A", "TTL": 300, "ResourceRecords": [{"Value": "192.0.2.1"}]},
+		{"Name": "\\052.example.com.", "Type": "CNAME", "TTL": 60, "ResourceRecords": [{"Value": "www.example.com"}]},
+		{"Name": "lb.example.com.", "Type": "A", "AliasTarget": {"DNSName": "lb-1.elb.amazonaws.com."}}
+	]}`)
+
+	var imported ZoneExport
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "import-zone", "example.com", "--from", "route53", "--file", export, "--json"), &imported)
+	sets := map[string]dns.RecordSet{}
+	for _, rs := range imported.RecordSets {
+		sets[recordSetKey(rs.Name, rs.Type)] = rs
+	}
+	if _, ok := sets[recordSetKey("www.example.com", "A")]; !ok {
+		t.Errorf("import-zone did not convert www.example.com A: %+v", imported.RecordSets)
+	}
+	if _, ok := sets[recordSetKey("*.example.com", "CNAME")]; !ok {
+		t.Errorf("import-zone did not unescape the wildcard: %+v", imported.RecordSets)
+	}
+	if _, ok := sets[recordSetKey("lb.example.com", "A")]; ok {
+		t.Error("import-zone converted a Route 53 alias")
+	}
+	report := filepath.Join(t.TempDir(), "report.json")
+	mustRun(t, endpoint, exitValidation, "import-zone", "example.com", "--from", "route53", "--file", export, "--strict", "--report", report, "--suppress")
+	if _, err := os.Stat(report); !os.IsNotExist(err) {
+		t.Errorf("import-zone --strict wrote the report: %v", err)
+	}
+	mustRun(t, endpoint, exitValidation, "import-zone", "example.com", "--file", export)
+
+	// --create makes the zone and its recordsets, and the report lists the alias
+	mustRun(t, endpoint, exitValidation, "import-zone", "example.com", "--from", "route53", "--file", export, "--create", "--suppress")
+	mustRun(t, endpoint, exitOK, "import-zone", "example.com", "--from", "route53", "--file", export,
+		"--create", "--contractid", "C-1", "--report", report, "--suppress")
+	live := zoneRecordSets(t, endpoint, "example.com")
+	for _, key := range []string{recordSetKey("www.example.com", "A"), recordSetKey("*.example.com", "CNAME"), recordSetKey("example.com", "SOA")} {
+		if _, ok := live[key]; !ok {
+			t.Errorf("import-zone --create did not create %s", key)
+		}
+	}
+	data, err := os.ReadFile(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var imp ZoneImport
+	if err := json.Unmarshal(data, &imp); err != nil {
+		t.Fatal(err)
+	}
+	if imp.Skipped() != 1 || imp.Source != "route53" {
+		t.Errorf("import report is %+v", imp)
+	}
+}
+
+func TestExportTerraformCommand(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+	mustRun(t, endpoint, exitOK, "create-recordset", "example.com", "--name", "www.example.com", "--type", "A",
+		"--ttl", "300", "--rdata", "192.0.2.1", "--suppress")
+
+	mustRun(t, endpoint, exitValidation, "export-terraform", "example.com")
+	hcl := mustRun(t, endpoint, exitOK, "export-terraform", "example.com", "--group", "1", "--format", "hcl").stdout
+	for _, want := range []string{
+		`resource "akamai_dns_zone" "example_com" {`,
+		`resource "akamai_dns_record" "www_example_com_a" {`,
+		`id = "example.com#www.example.com#A"`,
+		`"192.0.2.1"`,
+	} {
+		if !strings.Contains(hcl, want) {
+			t.Errorf("export-terraform output has no %s:\n%s", want, hcl)
+		}
+	}
+
+	// A snapshot exports the same recordsets without the API
+	dir := t.TempDir()
+	mustRun(t, endpoint, exitOK, "snapshot-zone", "example.com", "--snapshot-dir", dir, "--suppress")
+	var exports []ZoneExport
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "export-terraform", "example.com", "--snapshot", "latest", "--snapshot-dir", dir,
+		"--no-zone", "--filter", "A", "--json"), &exports)
+	if len(exports) != 1 || len(exports[0].RecordSets) != 1 || exports[0].RecordSets[0].Name != "www.example.com" {
+		t.Errorf("export-terraform --snapshot --filter A returned %+v", exports)
+	}
+	hcl = mustRun(t, endpoint, exitOK, "export-terraform", "example.com", "--no-zone", "--no-import", "--format", "hcl").stdout
+	if strings.Contains(hcl, "akamai_dns_zone") || strings.Contains(hcl, "import {") {
+		t.Errorf("export-terraform --no-zone --no-import output:\n%s", hcl)
+	}
+}
+
+func TestProfileCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	t.Cleanup(func() { activeProfile = nil })
+	config := `# CLI profiles
+profiles:
+  staging:
+    contractId: C-1
+    groupId: "1"
+  prod:
+    contractId: C-2
+    format: json
+`
+	if err := os.WriteFile(os.Getenv("AKAMAI_DNS_CONFIG"), []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var profiles []Profile
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "profile", "list", "--json"), &profiles)
+	if len(profiles) != 2 || profiles[0].Name != "prod" || profiles[0].Active || profiles[1].Active {
+		t.Fatalf("profile list returned %+v", profiles)
+	}
+	mustRun(t, endpoint, exitNotFound, "profile", "show")
+	mustRun(t, endpoint, exitNotFound, "profile", "use", "missing")
+	mustRun(t, endpoint, exitOK, "profile", "use", "staging")
+
+	data, err := os.ReadFile(os.Getenv("AKAMAI_DNS_CONFIG"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "current: staging\n# CLI profiles\n") {
+		t.Errorf("profile use wrote:\n%s", data)
+	}
+	var p Profile
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "profile", "show", "--json"), &p)
+	if p.Name != "staging" || !p.Active || p.ContractID != "C-1" {
+		t.Errorf("profile show returned %+v", p)
+	}
+
+	// Commands take the contract from the profile and name it before changing anything
+	res := mustRun(t, endpoint, exitOK, "create-zoneconfig", "example.com", "--type", "PRIMARY", "--suppress")
+	if !strings.Contains(res.stderr, "Profile: staging") {
+		t.Errorf("create-zoneconfig did not print the profile:\n%s", res.stderr)
+	}
+	var zone dns.ZoneResponse
+	decodeOutput(t, mustRun(t, endpoint, exitOK, "--profile", "prod", "retrieve-zoneconfig", "example.com"), &zone)
+	if zone.ContractID != "C-1" {
+		t.Errorf("zone created with the staging profile has contract %s", zone.ContractID)
+	}
+}
+
+func TestACMEHookCommands(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+	createTestZone(t, endpoint, "sub.example.com")
+	challenge := recordSetKey("_acme-challenge.www.sub.example.com", "TXT")
+
+	// The closest zone holds the challenge, and a name and its wildcard share the recordset
+	mustRun(t, endpoint, exitOK, "acme-hook", "present", "www.sub.example.com", "token-1")
+	mustRun(t, endpoint, exitOK, "acme-hook", "present", "*.www.sub.example.com.", "token-2")
+	mustRun(t, endpoint, exitOK, "acme-hook", "present", "www.sub.example.com", "token-2")
+	rs := zoneRecordSets(t, endpoint, "sub.example.com")[challenge]
+	if rs.TTL != acmeTTL || strings.Join(rs.Rdata, " ") != `"token-1" "token-2"` {
+		t.Errorf("challenge recordset is %+v", rs)
+	}
+
+	// certbot passes the challenge in the environment
+	t.Setenv("CERTBOT_DOMAIN", "www.sub.example.com")
+	t.Setenv("CERTBOT_VALIDATION", "token-1")
+	mustRun(t, endpoint, exitOK, "acme-hook", "cleanup")
+	if rs := zoneRecordSets(t, endpoint, "sub.example.com")[challenge]; strings.Join(rs.Rdata, " ") != `"token-2"` {
+		t.Errorf("challenge recordset after cleanup is %+v", rs)
+	}
+	t.Setenv("CERTBOT_DOMAIN", "")
+	mustRun(t, endpoint, exitOK, "acme-hook", "cleanup", "www.sub.example.com", "token-2", "--zone", "sub.example.com")
+	if _, ok := zoneRecordSets(t, endpoint, "sub.example.com")[challenge]; ok {
+		t.Error("cleanup of the last value left the challenge recordset")
+	}
+
+	mustRun(t, endpoint, exitValidation, "acme-hook", "present", "www.example.net", "token", "--zone", "example.com")
+	mustRun(t, endpoint, exitValidation, "acme-hook", "present", "www.example.com", "bad token")
+	mustRun(t, endpoint, exitValidation, "acme-hook", "present")
+	mustRun(t, endpoint, exitNotFound, "acme-hook", "present", "www.example.net", "token")
+}
+
+func TestDDNSCommand(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+	var address atomic.Value
+	address.Store("192.0.2.10")
+	lookup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, address.Load())
+	}))
+	t.Cleanup(lookup.Close)
+
+	stateFile := filepath.Join(t.TempDir(), "ddns.json")
+	ddns := func(code int) {
+		t.Helper()
+		mustRun(t, endpoint, code, "ddns", "--zone", "example.com", "--name", "home", "--ttl", "120",
+			"--ipv4-url", lookup.URL, "--state-file", stateFile, "--once")
+	}
+	check := func(want string) {
+		t.Helper()
+		rs := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("home.example.com", "A")]
+		if rs.TTL != 120 || strings.Join(rs.Rdata, ",") != want {
+			t.Errorf("home.example.com A is %+v, want %s", rs, want)
+		}
+		data, err := os.ReadFile(stateFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var state DDNSState
+		if err := json.Unmarshal(data, &state); err != nil || state.Addresses["A"] != want {
+			t.Errorf("state file is %s", data)
+		}
+	}
+
+	ddns(exitOK)
+	check("192.0.2.10")
+	ddns(exitOK)
+	check("192.0.2.10")
+	address.Store("192.0.2.11")
+	ddns(exitOK)
+	check("192.0.2.11")
+
+	// A lookup that returns the wrong family changes nothing
+	address.Store("2001:db8::1")
+	ddns(exitError)
+	check("192.0.2.11")
+
+	mustRun(t, endpoint, exitValidation, "ddns", "--zone", "example.com", "--name", "home", "--type", "MX", "--once")
+	mustRun(t, endpoint, exitValidation, "ddns", "--name", "home", "--once")
+	mustRun(t, endpoint, exitValidation, "ddns", "--zone", "example.com", "--name", "host.example.net.", "--ipv4-url", lookup.URL, "--once")
+	mustRun(t, endpoint, exitNotFound, "ddns", "--zone", "missing.com", "--name", "home", "--ipv4-url", lookup.URL, "--once")
+}
+
+func TestWebhookServerCommand(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+	createTestZone(t, endpoint, "example.org")
+	mustRun(t, endpoint, exitOK, "create-recordset", "example.com", "--name", "manual.example.com", "--type", "A",
+		"--ttl", "300", "--rdata", "192.0.2.99", "--suppress")
+	mustRun(t, endpoint, exitValidation, "webhook-server", "--txt-owner-id", "")
+	mustRun(t, endpoint, exitValidation, "webhook-server", "--log-format", "xml")
+
+	// The handler the command serves, with the settings its flags make
+	sess := testSession(t, endpoint)
+	for _, changeList := range []bool{false, true} {
+		t.Run(fmt.Sprintf("changelist=%v", changeList), func(t *testing.T) {
+			srv := httptest.NewServer(&externalDNSProvider{
+				dnsClient:  dns.Client(sess),
+				sess:       sess,
+				filter:     ExternalDNSDomainFilter{Include: []string{"example.com"}},
+				ownerID:    "default",
+				defaultTTL: 300,
+				changeList: changeList,
+				log:        discardLogger(),
+			})
+			t.Cleanup(srv.Close)
+			webhook := func(method, path string, body, out interface{}) int {
+				t.Helper()
+				data, _ := json.Marshal(body)
+				req, err := http.NewRequest(method, srv.URL+path, bytes.NewReader(data))
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Set("Accept", externalDNSMediaType)
+				req.Header.Set("Content-Type", externalDNSMediaType)
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer resp.Body.Close()
+				if out != nil {
+					if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+						t.Fatalf("%s %s: %v", method, path, err)
+					}
+				}
+				return resp.StatusCode
+			}
+
+			var filter ExternalDNSDomainFilter
+			if webhook(http.MethodGet, "/", nil, &filter) != http.StatusOK || strings.Join(filter.Include, ",") != "example.com" {
+				t.Errorf("negotiation returned %+v", filter)
+			}
+
+			name := fmt.Sprintf("app%v.example.com", changeList)
+			registry := &ExternalDNSEndpoint{DNSName: "a-" + name, RecordType: "TXT", Targets: []string{"heritage=external-dns,external-dns/owner=default"}}
+			app := &ExternalDNSEndpoint{DNSName: name, RecordType: "A", Targets: []string{"192.0.2.1"}}
+			outside := &ExternalDNSEndpoint{DNSName: "app.example.org", RecordType: "A", Targets: []string{"192.0.2.1"}}
+			manual := &ExternalDNSEndpoint{DNSName: "manual.example.com", RecordType: "A", Targets: []string{"192.0.2.99"}}
+			if code := webhook(http.MethodPost, "/records", ExternalDNSChanges{
+				Create: []*ExternalDNSEndpoint{app, registry, outside},
+				Delete: []*ExternalDNSEndpoint{manual},
+			}, nil); code != http.StatusNoContent {
+				t.Fatalf("apply changes returned %d", code)
+			}
+			sets := zoneRecordSets(t, endpoint, "example.com")
+			if rs := sets[recordSetKey(name, "A")]; rs.TTL != 300 || strings.Join(rs.Rdata, ",") != "192.0.2.1" {
+				t.Errorf("%s A is %+v", name, rs)
+			}
+			if _, ok := sets[recordSetKey("manual.example.com", "A")]; !ok {
+				t.Error("a recordset not owned by the owner id was deleted")
+			}
+			if _, ok := zoneRecordSets(t, endpoint, "example.org")[recordSetKey("app.example.org", "A")]; ok {
+				t.Error("a change outside the domain filter was applied")
+			}
+
+			var endpoints []*ExternalDNSEndpoint
+			webhook(http.MethodGet, "/records", nil, &endpoints)
+			listed := map[string]bool{}
+			for _, ep := range endpoints {
+				listed[recordSetKey(ep.DNSName, ep.RecordType)] = true
+			}
+			if !listed[recordSetKey(name, "A")] || !listed[recordSetKey("a-"+name, "TXT")] || listed[recordSetKey("example.com", "SOA")] {
+				t.Errorf("records returned %v", listed)
+			}
+
+			updated := *app
+			updated.Targets = []string{"192.0.2.2"}
+			webhook(http.MethodPost, "/records", ExternalDNSChanges{UpdateOld: []*ExternalDNSEndpoint{app}, UpdateNew: []*ExternalDNSEndpoint{&updated}}, nil)
+			if rs := zoneRecordSets(t, endpoint, "example.com")[recordSetKey(name, "A")]; strings.Join(rs.Rdata, ",") != "192.0.2.2" {
+				t.Errorf("updated %s A is %+v", name, rs)
+			}
+			webhook(http.MethodPost, "/records", ExternalDNSChanges{Delete: []*ExternalDNSEndpoint{&updated, registry}}, nil)
+			sets = zoneRecordSets(t, endpoint, "example.com")
+			if _, ok := sets[recordSetKey(name, "A")]; ok {
+				t.Errorf("deleted %s A is still in the zone", name)
+			}
+			if _, ok := sets[recordSetKey("a-"+name, "TXT")]; ok {
+				t.Errorf("deleted registry record of %s is still in the zone", name)
+			}
+		})
+	}
+}
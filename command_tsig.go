@@ -0,0 +1,334 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// Secret sizes generated by tsig rotate --generate, matching the HMAC output length
+var tsigSecretSizes = map[string]int{
+	"hmac-md5":    16,
+	"hmac-sha1":   20,
+	"hmac-sha224": 28,
+	"hmac-sha256": 32,
+	"hmac-sha384": 48,
+	"hmac-sha512": 64,
+}
+
+// TSIGKeyZones is a TSIG key with the zones that use it
+type TSIGKeyZones struct {
+	Key   dns.TSIGKey `json:"key"`
+	Zones []string    `json:"zones"`
+}
+
+// Set up the Edgegrid session for the tsig commands
+func tsigInit(c *cli.Context) (context.Context, dns.DNS, error) {
+	ctx := context.Background()
+
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return nil, nil, newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+	return ctx, dns.Client(edgegrid.GetSession(ctx)), nil
+}
+
+// Find a TSIG key by name. The algorithm is only needed when several keys share the name.
+func findTSIGKey(ctx context.Context, dnsClient dns.DNS, name, algorithm string) (*dns.TSIGKey, error) {
+	resp, err := dnsClient.ListTSIGKeys(ctx, dns.ListTSIGKeysRequest{
+		TsigQuery: &dns.TSIGQueryString{Search: name},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("TSIG key list retrieval failed: %w", err)
+	}
+
+	matches := []dns.TSIGKey{}
+	for _, k := range resp.Keys {
+		if !strings.EqualFold(strings.TrimSuffix(k.Name, "."), strings.TrimSuffix(name, ".")) {
+			continue
+		}
+		if algorithm != "" && !strings.EqualFold(k.Algorithm, algorithm) {
+			continue
+		}
+		matches = append(matches, k.TSIGKey)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, newCommandError(exitNotFound, "TSIG key %s not found", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d TSIG keys are named %s; select one with --algorithm", len(matches), name)
+	}
+}
+
+// Zones that use a TSIG key, sorted
+func tsigKeyZones(ctx context.Context, dnsClient dns.DNS, key *dns.TSIGKey) ([]string, error) {
+	resp, err := dnsClient.GetTSIGKeyZones(ctx, dns.GetTSIGKeyZonesRequest{TsigKey: key})
+	if err != nil {
+		return nil, fmt.Errorf("TSIG key zone list retrieval failed: %w", err)
+	}
+	zones := append([]string(nil), resp.Zones...)
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// Key for structured output, with the secret blanked unless --show-secrets is set
+func tsigKeyOutput(key dns.TSIGKey, c *cli.Context) dns.TSIGKey {
+	if !c.GlobalBool("show-secrets") {
+		key.Secret = ""
+	}
+	return key
+}
+
+// Generate a random base64 secret sized for the algorithm
+func generateTSIGSecret(algorithm string) (string, error) {
+	size, ok := tsigSecretSizes[strings.ToLower(strings.TrimSuffix(algorithm, "."))]
+	if !ok {
+		return "", newCommandError(exitValidation, "cannot generate a secret for algorithm %s", algorithm)
+	}
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func cmdTSIGList(c *cli.Context) error {
+	ctx, dnsClient, err := tsigInit(c)
+	if err != nil {
+		return err
+	}
+
+	query := &dns.TSIGQueryString{
+		ContractIDs: c.StringSlice("contractid"),
+		Search:      c.String("search"),
+	}
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Retrieving TSIG keys..."))
+	resp, err := dnsClient.ListTSIGKeys(ctx, dns.ListTSIGKeysRequest{TsigQuery: query})
+	if err != nil {
+		return apiError(err, "TSIG key list retrieval failed: %v", err)
+	}
+
+	keys := make([]dns.TSIGKeyResponse, len(resp.Keys))
+	for i, k := range resp.Keys {
+		keys[i] = k
+		keys[i].TSIGKey = tsigKeyOutput(k.TSIGKey, c)
+	}
+	return writeOutput(c, &CommandOutput{
+		Value: keys,
+		Table: func() string { return renderTSIGKeyListTable(resp.Keys, c) },
+		CSV: func() [][]string {
+			rows := [][]string{{"name", "algorithm", "zones"}}
+			for _, k := range resp.Keys {
+				rows = append(rows, []string{k.Name, k.Algorithm, strconv.FormatInt(k.ZoneCount, 10)})
+			}
+			return rows
+		},
+	})
+}
+
+func cmdTSIGZones(c *cli.Context) error {
+	if !c.IsSet("key") {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "--key is required")
+	}
+
+	ctx, dnsClient, err := tsigInit(c)
+	if err != nil {
+		return err
+	}
+
+	key, err := findTSIGKey(ctx, dnsClient, c.String("key"), c.String("algorithm"))
+	if err != nil {
+		return wrapError(err)
+	}
+	zones, err := tsigKeyZones(ctx, dnsClient, key)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return writeOutput(c, &CommandOutput{
+		Value: TSIGKeyZones{Key: tsigKeyOutput(*key, c), Zones: zones},
+		Table: func() string { return renderTSIGKeyZonesTable(key, zones, c) },
+	})
+}
+
+func cmdTSIGRotate(c *cli.Context) error {
+	if !c.IsSet("key") {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "--key is required")
+	}
+	if c.IsSet("secret") == c.Bool("generate") {
+		return newCommandError(exitValidation, "Either --secret or --generate is required")
+	}
+
+	ctx, dnsClient, err := tsigInit(c)
+	if err != nil {
+		return err
+	}
+
+	current, err := findTSIGKey(ctx, dnsClient, c.String("key"), c.String("algorithm"))
+	if err != nil {
+		return wrapError(err)
+	}
+	zones, err := tsigKeyZones(ctx, dnsClient, current)
+	if err != nil {
+		return wrapError(err)
+	}
+	if len(zones) == 0 {
+		return newCommandError(exitNotFound, "TSIG key %s is not used by any zone", current.Name)
+	}
+
+	newKey := &dns.TSIGKey{
+		Name:      current.Name,
+		Algorithm: current.Algorithm,
+		Secret:    c.String("secret"),
+	}
+	if c.IsSet("new-name") {
+		newKey.Name = c.String("new-name")
+	}
+	if c.IsSet("new-algorithm") {
+		newKey.Algorithm = strings.ToLower(c.String("new-algorithm"))
+	}
+	if c.Bool("generate") {
+		newKey.Secret, err = generateTSIGSecret(newKey.Algorithm)
+		if err != nil {
+			return wrapError(err)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Rotating TSIG key %s (%s) on %d zone(s):\n", current.Name, current.Algorithm, len(zones))
+	for _, z := range zones {
+		fmt.Fprintf(os.Stderr, "  %s\n", z)
+	}
+	if c.Bool("dry-run") {
+		return nil
+	}
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zones...); err != nil {
+		return err
+	}
+	defer zoneCache.invalidate(zones...)
+
+	// One bulk request switches every zone to the new key
+	err = dnsClient.UpdateTSIGKeyBulk(ctx, dns.UpdateTSIGKeyBulkRequest{
+		TSIGKeyBulk: &dns.TSIGKeyBulkPost{Key: newKey, Zones: zones},
+	})
+	if err != nil {
+		return apiError(err, "TSIG key rotation failed: %v", err)
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("TSIG key rotated on %d zone(s)", len(zones)))
+
+	// A generated secret has to be configured on the primary name servers as well
+	if c.Bool("generate") {
+		return writeOutputText(c, fmt.Sprintf("key %q {\n    algorithm %s;\n    secret %q;\n};", newKey.Name, newKey.Algorithm, newKey.Secret))
+	}
+	return nil
+}
+
+// A key is only used for zone transfers on SECONDARY zones. It is removed from
+// the other zones that carry it, and from a secondary zone only when --zone names it.
+func cmdTSIGDelete(c *cli.Context) error {
+	if !c.IsSet("key") {
+		cli.ShowCommandHelp(c, c.Command.Name)
+		return newCommandError(exitValidation, "--key is required")
+	}
+
+	ctx, dnsClient, err := tsigInit(c)
+	if err != nil {
+		return err
+	}
+
+	key, err := findTSIGKey(ctx, dnsClient, c.String("key"), c.String("algorithm"))
+	if err != nil {
+		return wrapError(err)
+	}
+	zones, err := tsigKeyZones(ctx, dnsClient, key)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	// Restrict to the requested zones
+	explicit := c.IsSet("zone")
+	if explicit {
+		using := map[string]bool{}
+		for _, z := range zones {
+			using[strings.ToLower(z)] = true
+		}
+		zones = nil
+		for _, z := range c.StringSlice("zone") {
+			z = strings.ToLower(strings.TrimSuffix(z, "."))
+			if !using[z] {
+				return newCommandError(exitNotFound, "Zone %s does not use TSIG key %s", z, key.Name)
+			}
+			zones = append(zones, z)
+		}
+	}
+
+	targets := []string{}
+	for _, z := range zones {
+		zone, err := getZoneInfo(ctx, dnsClient, z)
+		if err != nil {
+			return apiError(err, "Failed to retrieve zone %s: %v", z, err)
+		}
+		if strings.EqualFold(zone.Type, "SECONDARY") && !explicit {
+			fmt.Fprintln(os.Stderr, color.YellowString("Skipping %s: secondary zone uses the key for zone transfers (name it with --zone to remove it)", z))
+			continue
+		}
+		targets = append(targets, z)
+	}
+
+	if len(targets) == 0 {
+		fmt.Fprintln(os.Stderr, color.BlueString("No zones to remove TSIG key %s from", key.Name))
+	}
+
+	if !c.Bool("dry-run") && len(targets) > 0 {
+		if err := snapshotBeforeChange(ctx, dnsClient, c, targets...); err != nil {
+			return err
+		}
+		defer zoneCache.invalidate(targets...)
+	}
+	for _, z := range targets {
+		if c.Bool("dry-run") {
+			fmt.Fprintf(os.Stderr, "Would remove TSIG key %s from %s\n", key.Name, z)
+			continue
+		}
+		if err := dnsClient.DeleteTSIGKey(ctx, dns.DeleteTSIGKeyRequest{Zone: z}); err != nil {
+			return apiError(err, "TSIG key delete failed for zone %s: %v", z, err)
+		}
+		fmt.Fprintln(os.Stderr, color.GreenString("Removed TSIG key %s from %s", key.Name, z))
+	}
+
+	// The result is the key and the zones it was, or with --dry-run would be, removed from
+	return writeOutput(c, &CommandOutput{
+		Value: TSIGKeyZones{Key: tsigKeyOutput(*key, c), Zones: targets},
+		Table: func() string { return renderTSIGKeyZonesTable(key, targets, c) },
+	})
+}
@@ -0,0 +1,643 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/urfave/cli"
+)
+
+const (
+	// Seconds of clock skew accepted in signed requests
+	tsigFudge = 300
+
+	updateGatewayTCPTimeout = 30 * time.Second
+)
+
+// updateGateway answers RFC 2136 UPDATE requests by applying them to Edge DNS
+// primary zones through the record API
+type updateGateway struct {
+	dnsClient     dns.DNS
+	keys          map[string]*tsigKey
+	zones         []string
+	allowUnsigned bool
+	log           *slog.Logger
+
+	// Updates are read-modify-write, so they are applied one at a time
+	mu sync.Mutex
+}
+
+func cmdUpdateGateway(c *cli.Context) error {
+	logger, err := commandLogger(c.String("log-format"))
+	if err != nil {
+		return wrapError(err)
+	}
+	keys := map[string]*tsigKey{}
+	for _, v := range c.StringSlice("tsig-key") {
+		key, err := parseTSIGKeyFlag(v)
+		if err != nil {
+			return newCommandError(exitValidation, "%v", err)
+		}
+		keys[key.Name] = key
+	}
+	for _, path := range c.StringSlice("tsig-key-file") {
+		fileKeys, err := readTSIGKeyFile(path)
+		if err != nil {
+			return newCommandError(exitValidation, "%v", err)
+		}
+		for _, key := range fileKeys {
+			keys[key.Name] = key
+		}
+	}
+	if len(keys) == 0 && !c.Bool("allow-unsigned") {
+		return newCommandError(exitValidation, "at least one --tsig-key or --tsig-key-file is required, or --allow-unsigned")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+
+	gw := &updateGateway{
+		dnsClient:     dns.Client(edgegrid.GetSession(ctx)),
+		keys:          keys,
+		allowUnsigned: c.Bool("allow-unsigned"),
+		log:           logger,
+	}
+	for _, z := range c.StringSlice("zone") {
+		gw.zones = append(gw.zones, normalizeDNSName(z))
+	}
+
+	addr := c.String("listen")
+	udpConn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return newCommandError(exitError, "Failed to listen on %s: %v", addr, err)
+	}
+	// TCP shares the UDP port, which matters when port 0 picked it
+	tcpListener, err := net.Listen("tcp", udpConn.LocalAddr().String())
+	if err != nil {
+		udpConn.Close()
+		return newCommandError(exitError, "Failed to listen on %s: %v", addr, err)
+	}
+	go func() {
+		<-ctx.Done()
+		udpConn.Close()
+		tcpListener.Close()
+	}()
+
+	keyNames := make([]string, 0, len(keys))
+	for name := range keys {
+		keyNames = append(keyNames, name)
+	}
+	sort.Strings(keyNames)
+	logger.Info("update gateway listening", "address", udpConn.LocalAddr().String(), "keys", keyNames,
+		"zones", gw.zones, "allowUnsigned", gw.allowUnsigned)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		gw.serveUDP(ctx, udpConn)
+	}()
+	go func() {
+		defer wg.Done()
+		gw.serveTCP(ctx, tcpListener)
+	}()
+	wg.Wait()
+	return nil
+}
+
+func (g *updateGateway) serveUDP(ctx context.Context, conn net.PacketConn) {
+	buf := make([]byte, 65535)
+	for {
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() == nil {
+				g.log.Error("UDP read failed", "error", err.Error())
+			}
+			return
+		}
+		if resp := g.handle(ctx, append([]byte(nil), buf[:n]...), peer.String()); resp != nil {
+			conn.WriteTo(resp, peer)
+		}
+	}
+}
+
+func (g *updateGateway) serveTCP(ctx context.Context, listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				g.log.Error("TCP accept failed", "error", err.Error())
+			}
+			return
+		}
+		go g.serveTCPConn(ctx, conn)
+	}
+}
+
+// Answer length-prefixed messages until the client closes the connection or idles
+func (g *updateGateway) serveTCPConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	for {
+		conn.SetDeadline(time.Now().Add(updateGatewayTCPTimeout))
+		var length uint16
+		if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+			return
+		}
+		msg := make([]byte, length)
+		if _, err := io.ReadFull(conn, msg); err != nil {
+			return
+		}
+		resp := g.handle(ctx, msg, conn.RemoteAddr().String())
+		if resp == nil {
+			return
+		}
+		if _, err := conn.Write(binary.BigEndian.AppendUint16(nil, uint16(len(resp)))); err != nil {
+			return
+		}
+		if _, err := conn.Write(resp); err != nil {
+			return
+		}
+	}
+}
+
+// Answer one message. Returns nil when no response should be sent.
+func (g *updateGateway) handle(ctx context.Context, msg []byte, client string) []byte {
+	m, err := parseUpdateMessage(msg)
+	if m == nil || msg[2]&0x80 != 0 {
+		return nil
+	}
+	if m.Opcode != dnsOpcodeUpdate {
+		g.log.Warn("request refused", "client", client, "rcode", "NOTIMP", "reason", fmt.Sprintf("opcode %d is not UPDATE", m.Opcode))
+		return dnsResponse(m, dnsRcodeNotImp)
+	}
+	if err != nil {
+		g.log.Warn("malformed request", "client", client, "rcode", "FORMERR", "error", err.Error())
+		m.Zones = nil
+		return dnsResponse(m, dnsRcodeFormErr)
+	}
+
+	var key *tsigKey
+	if m.TSIG != nil {
+		var resp []byte
+		if key, resp = g.verify(m, client); resp != nil {
+			return resp
+		}
+	} else if !g.allowUnsigned {
+		g.log.Warn("request refused", "client", client, "rcode", "REFUSED", "reason", "request is not signed")
+		return dnsResponse(m, dnsRcodeRefused)
+	}
+
+	rcode := dnsRcodeNoError
+	zone := ""
+	if len(m.Zones) == 1 {
+		zone = m.Zones[0].Name
+	}
+	changes, err := g.update(ctx, m)
+	var uerr *updateError
+	switch {
+	case errors.As(err, &uerr):
+		rcode = uerr.rcode
+		g.log.Warn("update rejected", "client", client, "zone", zone, "rcode", dnsRcodeName(rcode), "reason", uerr.reason)
+	case err != nil:
+		rcode = dnsRcodeServFail
+		g.log.Error("update failed", "client", client, "zone", zone, "rcode", "SERVFAIL", "error", err.Error())
+	default:
+		g.log.Info("update applied", "client", client, "zone", zone, "key", keyName(key), "changes", changes)
+	}
+	return g.sign(dnsResponse(m, rcode), m, key, 0)
+}
+
+func keyName(key *tsigKey) string {
+	if key == nil {
+		return ""
+	}
+	return key.Name
+}
+
+// Check the TSIG record of a request. Returns the key, or the error response
+// to send when the request fails verification.
+func (g *updateGateway) verify(m *dnsUpdateMessage, client string) (*tsigKey, []byte) {
+	key := g.keys[m.TSIG.KeyName]
+	alg, err := tsigAlgorithmName(m.TSIG.Algorithm)
+	switch {
+	case key == nil || err != nil || alg != key.Algorithm:
+		g.log.Warn("request refused", "client", client, "rcode", "NOTAUTH", "reason", "unknown TSIG key", "key", m.TSIG.KeyName)
+		return nil, g.tsigError(m, tsigErrBadKey)
+	case !key.verify(m):
+		g.log.Warn("request refused", "client", client, "rcode", "NOTAUTH", "reason", "bad TSIG signature", "key", key.Name)
+		return nil, g.tsigError(m, tsigErrBadSig)
+	}
+	now := tsigNow()
+	if now > m.TSIG.TimeSigned+uint64(m.TSIG.Fudge) || m.TSIG.TimeSigned > now+uint64(m.TSIG.Fudge) {
+		g.log.Warn("request refused", "client", client, "rcode", "NOTAUTH", "reason", "TSIG time outside the fudge window", "key", key.Name)
+		return nil, g.sign(dnsResponse(m, dnsRcodeNotAuth), m, key, tsigErrBadTime)
+	}
+	return key, nil
+}
+
+// NOTAUTH response with an unsigned TSIG record carrying the error
+func (g *updateGateway) tsigError(m *dnsUpdateMessage, tsigErr uint16) []byte {
+	return appendTSIG(dnsResponse(m, dnsRcodeNotAuth), &dnsTSIG{
+		KeyName:    m.TSIG.KeyName,
+		Algorithm:  m.TSIG.Algorithm,
+		TimeSigned: m.TSIG.TimeSigned,
+		Fudge:      m.TSIG.Fudge,
+		OrigID:     m.ID,
+		Error:      tsigErr,
+	})
+}
+
+// Sign a response with the key of the request. BADTIME responses keep the
+// request time and carry the server time in the other data.
+func (g *updateGateway) sign(resp []byte, m *dnsUpdateMessage, key *tsigKey, tsigErr uint16) []byte {
+	if key == nil {
+		return resp
+	}
+	t := &dnsTSIG{
+		KeyName:    key.Name,
+		Algorithm:  key.Algorithm,
+		TimeSigned: tsigNow(),
+		Fudge:      tsigFudge,
+		OrigID:     m.ID,
+		Error:      tsigErr,
+	}
+	if tsigErr == tsigErrBadTime {
+		t.Other = tsigTime(t.TimeSigned)
+		t.TimeSigned = m.TSIG.TimeSigned
+	}
+	t.MAC = key.mac(resp, t, m.TSIG.MAC)
+	return appendTSIG(resp, t)
+}
+
+// Check the zone and the prerequisites, then apply the update section.
+// Returns the number of recordsets changed.
+func (g *updateGateway) update(ctx context.Context, m *dnsUpdateMessage) (int, error) {
+	if len(m.Zones) != 1 || m.Zones[0].Type != dnsTypeSOA {
+		return 0, newUpdateError(dnsRcodeFormErr, "the zone section must hold one SOA entry")
+	}
+	zone := m.Zones[0].Name
+	if m.Zones[0].Class != dnsClassIN {
+		return 0, newUpdateError(dnsRcodeNotAuth, "only class IN zones are served")
+	}
+	if len(g.zones) > 0 && enclosingZone(g.zones, zone) != zone {
+		return 0, newUpdateError(dnsRcodeNotAuth, "zone %s is not served by this gateway", zone)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	info, err := getZoneInfo(ctx, g.dnsClient, zone)
+	var dnsErr *dns.Error
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.StatusCode == http.StatusNotFound:
+		return 0, newUpdateError(dnsRcodeNotAuth, "zone %s does not exist", zone)
+	case err != nil:
+		return 0, fmt.Errorf("zone retrieval failed: %w", err)
+	case !hasRecordSets(info.Type):
+		return 0, newUpdateError(dnsRcodeNotAuth, "zone %s is a %s zone", zone, info.Type)
+	}
+	resp, err := g.dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+		Zone:      zone,
+		QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("recordset list retrieval failed: %w", err)
+	}
+	current := resp.RecordSets
+
+	if err := checkUpdatePrereqs(m, zone, current); err != nil {
+		return 0, err
+	}
+	desired, err := applyUpdateSection(m, zone, current)
+	if err != nil {
+		return 0, err
+	}
+	changes := diffRecordSets(current, desired)
+	if len(changes) == 0 {
+		return 0, nil
+	}
+	defer zoneCache.invalidate(zone)
+	return len(changes), g.apply(ctx, zone, changes)
+}
+
+func (g *updateGateway) apply(ctx context.Context, zone string, changes []RecordsetChange) error {
+	return applyRecordsetChanges(ctx, g.dnsClient, g.log, zone, changes)
+}
+
+// Apply recordset changes through the record API: removals first, so a
+// CNAME can replace other data, then changes, then additions. Only the
+// changed recordsets are written, so other changes to the zone are kept.
+// When a call fails, the changes already made are undone where possible.
+func applyRecordsetChanges(ctx context.Context, dnsClient dns.DNS, log *slog.Logger, zone string, changes []RecordsetChange) error {
+	applied := []RecordsetChange{}
+	for _, action := range []string{changeRemoved, changeChanged, changeAdded} {
+		for _, ch := range changes {
+			if ch.Action != action {
+				continue
+			}
+			if err := applyRecordsetChange(ctx, dnsClient, zone, ch); err != nil {
+				rollbackRecordsetChanges(ctx, dnsClient, log, zone, applied)
+				return fmt.Errorf("%s %s recordset %s failed: %w", ch.Name, ch.Type, action, err)
+			}
+			log.Debug("recordset "+action, "zone", zone, "name", ch.Name, "type", ch.Type)
+			applied = append(applied, ch)
+		}
+	}
+	return nil
+}
+
+func applyRecordsetChange(ctx context.Context, dnsClient dns.DNS, zone string, ch RecordsetChange) error {
+	switch ch.Action {
+	case changeRemoved:
+		return dnsClient.DeleteRecord(ctx, dns.DeleteRecordRequest{Zone: zone, Name: ch.Name, RecordType: ch.Type})
+	case changeChanged:
+		return dnsClient.UpdateRecord(ctx, dns.UpdateRecordRequest{Zone: zone, Record: recordBody(ch.After)})
+	default:
+		return dnsClient.CreateRecord(ctx, dns.CreateRecordRequest{Zone: zone, Record: recordBody(ch.After)})
+	}
+}
+
+// Undo applied changes in reverse order, so a failed update leaves the zone as it was
+func rollbackRecordsetChanges(ctx context.Context, dnsClient dns.DNS, log *slog.Logger, zone string, applied []RecordsetChange) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		ch := applied[i]
+		undo := RecordsetChange{Name: ch.Name, Type: ch.Type, Before: ch.After, After: ch.Before}
+		switch ch.Action {
+		case changeRemoved:
+			undo.Action = changeAdded
+		case changeChanged:
+			undo.Action = changeChanged
+		default:
+			undo.Action = changeRemoved
+		}
+		if err := applyRecordsetChange(ctx, dnsClient, zone, undo); err != nil {
+			log.Error("recordset rollback failed", "zone", zone, "name", ch.Name, "type", ch.Type, "error", err.Error())
+		}
+	}
+}
+
+func recordBody(rs *dns.RecordSet) *dns.RecordBody {
+	return &dns.RecordBody{Name: rs.Name, RecordType: rs.Type, TTL: rs.TTL, Target: rs.Rdata}
+}
+
+func inZone(name, zone string) bool {
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+// Check the prerequisite section against the current recordsets (RFC 2136 3.2)
+func checkUpdatePrereqs(m *dnsUpdateMessage, zone string, current []dns.RecordSet) error {
+	names := map[string]bool{}
+	existing := map[string]dns.RecordSet{}
+	for _, rs := range current {
+		names[normalizeDNSName(rs.Name)] = true
+		existing[recordSetKey(rs.Name, rs.Type)] = rs
+	}
+
+	// Value-dependent prerequisites are compared as whole recordsets
+	required := map[string]map[string]bool{}
+	requiredKeys := []string{}
+	for _, rr := range m.Prereqs {
+		rtype := dnsTypeName(rr.Type)
+		if rr.TTL != 0 {
+			return newUpdateError(dnsRcodeFormErr, "prerequisite %s %s has a non-zero TTL", rr.Name, rtype)
+		}
+		if !inZone(rr.Name, zone) {
+			return newUpdateError(dnsRcodeNotZone, "prerequisite %s is outside zone %s", rr.Name, zone)
+		}
+		_, exists := existing[recordSetKey(rr.Name, rtype)]
+		switch rr.Class {
+		case dnsClassAny:
+			switch {
+			case rr.rdataLen != 0:
+				return newUpdateError(dnsRcodeFormErr, "prerequisite %s %s has rdata", rr.Name, rtype)
+			case rr.Type == dnsTypeAny && !names[rr.Name]:
+				return newUpdateError(dnsRcodeNXDomain, "name %s is not in use", rr.Name)
+			case rr.Type != dnsTypeAny && !exists:
+				return newUpdateError(dnsRcodeNXRRSet, "recordset %s %s does not exist", rr.Name, rtype)
+			}
+		case dnsClassNone:
+			switch {
+			case rr.rdataLen != 0:
+				return newUpdateError(dnsRcodeFormErr, "prerequisite %s %s has rdata", rr.Name, rtype)
+			case rr.Type == dnsTypeAny && names[rr.Name]:
+				return newUpdateError(dnsRcodeYXDomain, "name %s is in use", rr.Name)
+			case rr.Type != dnsTypeAny && exists:
+				return newUpdateError(dnsRcodeYXRRSet, "recordset %s %s exists", rr.Name, rtype)
+			}
+		case m.Zones[0].Class:
+			if isMetaType(rr.Type) {
+				return newUpdateError(dnsRcodeFormErr, "prerequisite %s has meta type %s", rr.Name, rtype)
+			}
+			rdata, err := rdataString(m.raw, rr)
+			if err != nil {
+				return asUpdateError(err)
+			}
+			key := recordSetKey(rr.Name, rtype)
+			if required[key] == nil {
+				required[key] = map[string]bool{}
+				requiredKeys = append(requiredKeys, key)
+			}
+			required[key][rdataKey(rtype, rdata)] = true
+		default:
+			return newUpdateError(dnsRcodeFormErr, "prerequisite %s has class %d", rr.Name, rr.Class)
+		}
+	}
+
+	for _, key := range requiredKeys {
+		rs, ok := existing[key]
+		have := map[string]bool{}
+		for _, rdata := range rs.Rdata {
+			have[rdataKey(rs.Type, rdata)] = true
+		}
+		want := required[key]
+		match := ok && len(have) == len(want)
+		for v := range want {
+			match = match && have[v]
+		}
+		if !match {
+			return newUpdateError(dnsRcodeNXRRSet, "recordset %s does not have the required values", strings.Replace(key, "|", " ", 1))
+		}
+	}
+	return nil
+}
+
+// Malformed rdata is a format error; unsupported types keep their RCODE
+func asUpdateError(err error) error {
+	var uerr *updateError
+	if errors.As(err, &uerr) {
+		return err
+	}
+	return newUpdateError(dnsRcodeFormErr, "%v", err)
+}
+
+// Check the whole update section, then apply it in order to a copy of the
+// recordsets (RFC 2136 3.4). The SOA is managed by Edge DNS and never changed,
+// and the apex NS recordset is never removed.
+func applyUpdateSection(m *dnsUpdateMessage, zone string, current []dns.RecordSet) ([]dns.RecordSet, error) {
+	zoneClass := m.Zones[0].Class
+	for _, rr := range m.Updates {
+		rtype := dnsTypeName(rr.Type)
+		if !inZone(rr.Name, zone) {
+			return nil, newUpdateError(dnsRcodeNotZone, "update %s is outside zone %s", rr.Name, zone)
+		}
+		switch rr.Class {
+		case zoneClass:
+			if isMetaType(rr.Type) {
+				return nil, newUpdateError(dnsRcodeFormErr, "cannot add %s records", rtype)
+			}
+			if _, err := rdataString(m.raw, rr); err != nil {
+				return nil, asUpdateError(err)
+			}
+		case dnsClassAny:
+			if rr.TTL != 0 || rr.rdataLen != 0 || (isMetaType(rr.Type) && rr.Type != dnsTypeAny) {
+				return nil, newUpdateError(dnsRcodeFormErr, "malformed delete of %s %s", rr.Name, rtype)
+			}
+		case dnsClassNone:
+			if rr.TTL != 0 || isMetaType(rr.Type) {
+				return nil, newUpdateError(dnsRcodeFormErr, "malformed delete of %s %s", rr.Name, rtype)
+			}
+			if _, err := rdataString(m.raw, rr); err != nil {
+				return nil, asUpdateError(err)
+			}
+		default:
+			return nil, newUpdateError(dnsRcodeFormErr, "update %s has class %d", rr.Name, rr.Class)
+		}
+	}
+
+	sets := map[string]*dns.RecordSet{}
+	order := []string{}
+	for _, rs := range current {
+		cp := copyRecordSet(rs)
+		key := recordSetKey(rs.Name, rs.Type)
+		sets[key] = &cp
+		order = append(order, key)
+	}
+	atName := func(name string) []string {
+		keys := []string{}
+		for _, key := range order {
+			if rs := sets[key]; rs != nil && normalizeDNSName(rs.Name) == name {
+				keys = append(keys, key)
+			}
+		}
+		return keys
+	}
+	protected := func(name, rtype string) bool {
+		return rtype == "SOA" || (name == zone && rtype == "NS")
+	}
+
+	for _, rr := range m.Updates {
+		rtype := dnsTypeName(rr.Type)
+		key := recordSetKey(rr.Name, rtype)
+		switch rr.Class {
+		case zoneClass:
+			if rtype == "SOA" {
+				continue
+			}
+			rdata, _ := rdataString(m.raw, rr)
+			others := atName(rr.Name)
+			hasCNAME, hasOther := false, false
+			for _, k := range others {
+				if sets[k].Type == "CNAME" {
+					hasCNAME = true
+				} else {
+					hasOther = true
+				}
+			}
+			// CNAME and other data can't share a name; the add is ignored
+			if (rtype == "CNAME" && hasOther) || (rtype != "CNAME" && hasCNAME) {
+				continue
+			}
+			rs := sets[key]
+			if rs == nil {
+				rs = &dns.RecordSet{Name: rr.Name, Type: rtype}
+				sets[key] = rs
+				order = append(order, key)
+			}
+			rs.TTL = int(rr.TTL)
+			if rtype == "CNAME" {
+				rs.Rdata = []string{rdata}
+				continue
+			}
+			dup := false
+			for _, v := range rs.Rdata {
+				dup = dup || rdataKey(rtype, v) == rdataKey(rtype, rdata)
+			}
+			if !dup {
+				rs.Rdata = append(rs.Rdata, rdata)
+			}
+		case dnsClassAny:
+			if rr.Type != dnsTypeAny {
+				if !protected(rr.Name, rtype) {
+					delete(sets, key)
+				}
+				continue
+			}
+			for _, k := range atName(rr.Name) {
+				if !protected(rr.Name, sets[k].Type) {
+					delete(sets, k)
+				}
+			}
+		case dnsClassNone:
+			rs := sets[key]
+			if rs == nil || rtype == "SOA" {
+				continue
+			}
+			rdata, _ := rdataString(m.raw, rr)
+			kept := []string{}
+			for _, v := range rs.Rdata {
+				if rdataKey(rtype, v) != rdataKey(rtype, rdata) {
+					kept = append(kept, v)
+				}
+			}
+			switch {
+			case len(kept) > 0:
+				rs.Rdata = kept
+			case !protected(rr.Name, rtype):
+				delete(sets, key)
+			}
+		}
+	}
+
+	desired := []dns.RecordSet{}
+	for _, key := range order {
+		if rs, ok := sets[key]; ok {
+			desired = append(desired, *rs)
+			delete(sets, key)
+		}
+	}
+	return desired, nil
+}
@@ -0,0 +1,423 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// Current recordsets the prerequisite and update tests run against
+func gatewayTestZone() []dns.RecordSet {
+	return []dns.RecordSet{
+		{Name: "example.com", Type: "SOA", TTL: 3600, Rdata: []string{"ns1.example.com. hostmaster.example.com. 1 3600 600 604800 300"}},
+		{Name: "example.com", Type: "NS", TTL: 3600, Rdata: []string{"ns1.example.com."}},
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1", "192.0.2.2"}},
+		{Name: "alias.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www.example.com."}},
+	}
+}
+
+func rcodeOf(err error) int {
+	var uerr *updateError
+	if errors.As(err, &uerr) {
+		return uerr.rcode
+	}
+	if err != nil {
+		return -1
+	}
+	return dnsRcodeNoError
+}
+
+func TestCheckUpdatePrereqs(t *testing.T) {
+	a := func(ip byte) []byte { return []byte{192, 0, 2, ip} }
+	tests := []struct {
+		name    string
+		prereqs [][]byte
+		rcode   int
+	}{
+		{"none", nil, dnsRcodeNoError},
+		{"name in use", [][]byte{testRR("www.example.com", dnsTypeAny, dnsClassAny, 0, nil)}, dnsRcodeNoError},
+		{"name in use fails", [][]byte{testRR("new.example.com", dnsTypeAny, dnsClassAny, 0, nil)}, dnsRcodeNXDomain},
+		{"name not in use", [][]byte{testRR("new.example.com", dnsTypeAny, dnsClassNone, 0, nil)}, dnsRcodeNoError},
+		{"name not in use fails", [][]byte{testRR("www.example.com", dnsTypeAny, dnsClassNone, 0, nil)}, dnsRcodeYXDomain},
+		{"rrset exists", [][]byte{testRR("www.example.com", testTypeA, dnsClassAny, 0, nil)}, dnsRcodeNoError},
+		{"rrset exists fails", [][]byte{testRR("www.example.com", testTypeAAAA, dnsClassAny, 0, nil)}, dnsRcodeNXRRSet},
+		{"rrset does not exist", [][]byte{testRR("www.example.com", testTypeAAAA, dnsClassNone, 0, nil)}, dnsRcodeNoError},
+		{"rrset does not exist fails", [][]byte{testRR("www.example.com", testTypeA, dnsClassNone, 0, nil)}, dnsRcodeYXRRSet},
+		{"rrset values match", [][]byte{
+			testRR("www.example.com", testTypeA, dnsClassIN, 0, a(2)),
+			testRR("WWW.example.com", testTypeA, dnsClassIN, 0, a(1)),
+		}, dnsRcodeNoError},
+		{"rrset values are a subset", [][]byte{testRR("www.example.com", testTypeA, dnsClassIN, 0, a(1))}, dnsRcodeNXRRSet},
+		{"rrset values differ", [][]byte{
+			testRR("www.example.com", testTypeA, dnsClassIN, 0, a(1)),
+			testRR("www.example.com", testTypeA, dnsClassIN, 0, a(3)),
+		}, dnsRcodeNXRRSet},
+		{"outside the zone", [][]byte{testRR("www.example.net", dnsTypeAny, dnsClassAny, 0, nil)}, dnsRcodeNotZone},
+		{"non-zero TTL", [][]byte{testRR("www.example.com", testTypeA, dnsClassAny, 60, nil)}, dnsRcodeFormErr},
+		{"rdata on an existence check", [][]byte{testRR("www.example.com", testTypeA, dnsClassAny, 0, a(1))}, dnsRcodeFormErr},
+		{"meta type value", [][]byte{testRR("www.example.com", dnsTypeAny, dnsClassIN, 0, nil)}, dnsRcodeFormErr},
+		{"unknown class", [][]byte{testRR("www.example.com", testTypeA, 3, 0, nil)}, dnsRcodeFormErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mustParseUpdate(t, testUpdateMessage(1, "example.com", tt.prereqs, nil))
+			err := checkUpdatePrereqs(m, "example.com", gatewayTestZone())
+			if got := rcodeOf(err); got != tt.rcode {
+				t.Errorf("got %s (%v), want %s", dnsRcodeName(got), err, dnsRcodeName(tt.rcode))
+			}
+		})
+	}
+}
+
+func TestApplyUpdateSection(t *testing.T) {
+	a := func(ip byte) []byte { return []byte{192, 0, 2, ip} }
+	tests := []struct {
+		name    string
+		updates [][]byte
+		rcode   int
+		changed map[string][]string // recordSetKey to the rdata after the update, nil when removed
+	}{
+		{
+			name:    "add to a recordset",
+			updates: [][]byte{testRR("www.example.com", testTypeA, dnsClassIN, 60, a(3))},
+			changed: map[string][]string{"www.example.com|A": {"192.0.2.1", "192.0.2.2", "192.0.2.3"}},
+		},
+		{
+			name:    "add a duplicate",
+			updates: [][]byte{testRR("www.example.com", testTypeA, dnsClassIN, 300, a(1))},
+		},
+		{
+			name:    "add a new name",
+			updates: [][]byte{testRR("New.example.com", testTypeTXT, dnsClassIN, 60, []byte{2, 'h', 'i'})},
+			changed: map[string][]string{"new.example.com|TXT": {`"hi"`}},
+		},
+		{
+			name:    "delete a value",
+			updates: [][]byte{testRR("www.example.com", testTypeA, dnsClassNone, 0, a(2))},
+			changed: map[string][]string{"www.example.com|A": {"192.0.2.1"}},
+		},
+		{
+			name: "delete every value",
+			updates: [][]byte{
+				testRR("www.example.com", testTypeA, dnsClassNone, 0, a(1)),
+				testRR("www.example.com", testTypeA, dnsClassNone, 0, a(2)),
+			},
+			changed: map[string][]string{"www.example.com|A": nil},
+		},
+		{
+			name:    "delete a recordset",
+			updates: [][]byte{testRR("alias.example.com", testTypeCNAME, dnsClassAny, 0, nil)},
+			changed: map[string][]string{"alias.example.com|CNAME": nil},
+		},
+		{
+			name:    "delete a name",
+			updates: [][]byte{testRR("www.example.com", dnsTypeAny, dnsClassAny, 0, nil)},
+			changed: map[string][]string{"www.example.com|A": nil},
+		},
+		{
+			name: "apex NS and SOA are kept",
+			updates: [][]byte{
+				testRR("example.com", dnsTypeAny, dnsClassAny, 0, nil),
+				testRR("example.com", testTypeNS, dnsClassNone, 0, packDNSName("ns1.example.com")),
+			},
+		},
+		{
+			name:    "CNAME does not replace other data",
+			updates: [][]byte{testRR("www.example.com", testTypeCNAME, dnsClassIN, 60, packDNSName("other.example.net"))},
+		},
+		{
+			name: "delete then add in order",
+			updates: [][]byte{
+				testRR("www.example.com", testTypeA, dnsClassAny, 0, nil),
+				testRR("www.example.com", testTypeCNAME, dnsClassIN, 60, packDNSName("other.example.net")),
+			},
+			changed: map[string][]string{"www.example.com|A": nil, "www.example.com|CNAME": {"other.example.net."}},
+		},
+		{
+			name:    "outside the zone",
+			updates: [][]byte{testRR("www.example.net", testTypeA, dnsClassIN, 60, a(1))},
+			rcode:   dnsRcodeNotZone,
+		},
+		{
+			name: "checked before applying",
+			updates: [][]byte{
+				testRR("ok.example.com", testTypeA, dnsClassIN, 60, a(1)),
+				testRR("bad.example.com", testTypeA, dnsClassIN, 60, a(1)[:3]),
+			},
+			rcode: dnsRcodeFormErr,
+		},
+		{
+			name:    "delete with a TTL",
+			updates: [][]byte{testRR("www.example.com", testTypeA, dnsClassAny, 60, nil)},
+			rcode:   dnsRcodeFormErr,
+		},
+		{
+			name:    "add of an unsupported type",
+			updates: [][]byte{testRR("www.example.com", 44, dnsClassIN, 60, []byte{1, 1, 0})},
+			rcode:   dnsRcodeNotImp,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mustParseUpdate(t, testUpdateMessage(1, "example.com", nil, tt.updates))
+			current := gatewayTestZone()
+			desired, err := applyUpdateSection(m, "example.com", current)
+			if got := rcodeOf(err); got != tt.rcode {
+				t.Fatalf("got %s (%v), want %s", dnsRcodeName(got), err, dnsRcodeName(tt.rcode))
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(current, gatewayTestZone()) {
+				t.Error("the current recordsets were modified")
+			}
+			changed := map[string][]string{}
+			for _, ch := range diffRecordSets(current, desired) {
+				key := recordSetKey(ch.Name, ch.Type)
+				changed[key] = nil
+				if ch.After != nil {
+					changed[key] = ch.After.Rdata
+				}
+			}
+			if len(changed) != len(tt.changed) {
+				t.Fatalf("changed %v, want %v", changed, tt.changed)
+			}
+			for key, want := range tt.changed {
+				if got, ok := changed[key]; !ok || !reflect.DeepEqual(got, want) {
+					t.Errorf("%s is %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+// Parse a response and check the RCODE and the TSIG error it carries
+func checkGatewayResponse(t *testing.T, resp []byte, rcode int, tsigErr uint16) *dnsUpdateMessage {
+	t.Helper()
+	if len(resp) < dnsHeaderSize || resp[2]&0x80 == 0 {
+		t.Fatalf("not a response: %x", resp)
+	}
+	if got := int(resp[3] & 0xf); got != rcode {
+		t.Fatalf("RCODE %s, want %s", dnsRcodeName(got), dnsRcodeName(rcode))
+	}
+	m := mustParseUpdate(t, resp)
+	if m.TSIG == nil {
+		t.Fatal("response has no TSIG record")
+	}
+	if m.TSIG.Error != tsigErr {
+		t.Errorf("TSIG error %d, want %d", m.TSIG.Error, tsigErr)
+	}
+	return m
+}
+
+// Check the signature of a response to a request signed with key
+func verifyGatewayResponse(t *testing.T, key *tsigKey, request []byte, resp *dnsUpdateMessage) {
+	t.Helper()
+	signed := append([]byte(nil), resp.raw[:resp.tsigOff]...)
+	binary.BigEndian.PutUint16(signed[10:], binary.BigEndian.Uint16(signed[10:])-1)
+	if !hmac.Equal(key.mac(signed, resp.TSIG, mustParseUpdate(t, request).TSIG.MAC), resp.TSIG.MAC) {
+		t.Error("response signature does not verify")
+	}
+}
+
+func TestUpdateGatewayTSIG(t *testing.T) {
+	key, _ := newTSIGKey("ddns-key", "hmac-sha256", "c2VjcmV0")
+	wrongSecret, _ := newTSIGKey("ddns-key", "hmac-sha256", "b3RoZXI=")
+	unknown, _ := newTSIGKey("other-key", "hmac-sha256", "c2VjcmV0")
+	wrongAlg, _ := newTSIGKey("ddns-key", "hmac-sha1", "c2VjcmV0")
+	g := &updateGateway{keys: map[string]*tsigKey{key.Name: key}, log: discardLogger()}
+	msg := testUpdateMessage(9, "example.com", nil, [][]byte{testRR("www.example.com", testTypeA, dnsClassIN, 60, []byte{192, 0, 2, 1})})
+
+	tests := []struct {
+		name    string
+		key     *tsigKey
+		time    uint64
+		tsigErr uint16
+		signed  bool
+	}{
+		{"unknown key", unknown, tsigNow(), tsigErrBadKey, false},
+		{"algorithm mismatch", wrongAlg, tsigNow(), tsigErrBadKey, false},
+		{"wrong secret", wrongSecret, tsigNow(), tsigErrBadSig, false},
+		{"too old", key, tsigNow() - tsigFudge - 60, tsigErrBadTime, true},
+		{"too new", key, tsigNow() + tsigFudge + 60, tsigErrBadTime, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := testSign(msg, tt.key, tt.time)
+			resp := checkGatewayResponse(t, g.handle(context.Background(), request, "test"), dnsRcodeNotAuth, tt.tsigErr)
+			if resp.ID != 9 || len(resp.Zones) != 1 {
+				t.Errorf("response header %+v", resp)
+			}
+			if !tt.signed {
+				if len(resp.TSIG.MAC) != 0 {
+					t.Error("BADKEY and BADSIG responses must not be signed")
+				}
+				return
+			}
+			verifyGatewayResponse(t, key, request, resp)
+			if len(resp.TSIG.Other) != 6 {
+				t.Errorf("BADTIME response other data %x, want the server time", resp.TSIG.Other)
+			}
+		})
+	}
+
+	// Unsigned requests are refused unless allowed
+	resp := g.handle(context.Background(), msg, "test")
+	if len(resp) < dnsHeaderSize || int(resp[3]&0xf) != dnsRcodeRefused {
+		t.Errorf("unsigned request answered %x, want REFUSED", resp)
+	}
+	// Responses are never answered
+	response := append([]byte(nil), msg...)
+	response[2] |= 0x80
+	if resp := g.handle(context.Background(), response, "test"); resp != nil {
+		t.Errorf("response answered with %x", resp)
+	}
+}
+
+// Record client that fails CreateRecord, to check that applied changes are undone
+type failingRecordClient struct {
+	dns.DNS
+	calls []string
+}
+
+func (f *failingRecordClient) CreateRecord(_ context.Context, req dns.CreateRecordRequest) error {
+	f.calls = append(f.calls, "create "+req.Record.Name+" "+req.Record.RecordType)
+	if req.Record.Name == "new.example.com" {
+		return errors.New("create failed")
+	}
+	return nil
+}
+
+func (f *failingRecordClient) UpdateRecord(_ context.Context, req dns.UpdateRecordRequest) error {
+	f.calls = append(f.calls, fmt.Sprintf("update %s %s %v", req.Record.Name, req.Record.RecordType, req.Record.Target))
+	return nil
+}
+
+func (f *failingRecordClient) DeleteRecord(_ context.Context, req dns.DeleteRecordRequest) error {
+	f.calls = append(f.calls, "delete "+req.Name+" "+req.RecordType)
+	return nil
+}
+
+func TestUpdateGatewayApplyRollback(t *testing.T) {
+	client := &failingRecordClient{}
+	g := &updateGateway{dnsClient: client, log: discardLogger()}
+	current := gatewayTestZone()
+	desired := []dns.RecordSet{
+		current[0], current[1],
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.9"}},
+		{Name: "new.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}},
+	}
+	if err := g.apply(context.Background(), "example.com", diffRecordSets(current, desired)); err == nil {
+		t.Fatal("apply succeeded with a failing create")
+	}
+	want := []string{
+		"delete alias.example.com CNAME",
+		"update www.example.com A [192.0.2.9]",
+		"create new.example.com A",
+		"update www.example.com A [192.0.2.1 192.0.2.2]",
+		"create alias.example.com CNAME",
+	}
+	if !reflect.DeepEqual(client.calls, want) {
+		t.Errorf("calls %q\nwant %q", client.calls, want)
+	}
+}
+
+// Send a request to a UDP server and return the response
+func exchangeUDP(t *testing.T, addr string, msg []byte) []byte {
+	t.Helper()
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	if _, err := conn.Write(msg); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 65535)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf[:n]
+}
+
+func TestUpdateGatewayRoundTrip(t *testing.T) {
+	endpoint := startMockAPI(t)
+	createTestZone(t, endpoint, "example.com")
+
+	// The command refuses to accept unsigned updates unless told to
+	mustRun(t, endpoint, exitValidation, "update-gateway", "--listen", "127.0.0.1:0")
+
+	key, _ := newTSIGKey("ddns-key", "hmac-sha256", "c2VjcmV0")
+	g := &updateGateway{
+		dnsClient: dns.Client(testSession(t, endpoint)),
+		keys:      map[string]*tsigKey{key.Name: key},
+		zones:     []string{"example.com"},
+		log:       discardLogger(),
+	}
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.serveUDP(ctx, conn)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		conn.Close()
+		<-done
+	})
+	addr := conn.LocalAddr().String()
+
+	// Add a host, but only while the name is free
+	notInUse := testRR("host.example.com", dnsTypeAny, dnsClassNone, 0, nil)
+	add := testRR("host.example.com", testTypeA, dnsClassIN, 120, []byte{192, 0, 2, 10})
+	request := testSign(testUpdateMessage(100, "example.com", [][]byte{notInUse}, [][]byte{add}), key, tsigNow())
+	resp := checkGatewayResponse(t, exchangeUDP(t, addr, request), dnsRcodeNoError, 0)
+	verifyGatewayResponse(t, key, request, resp)
+
+	rs, ok := zoneRecordSets(t, endpoint, "example.com")[recordSetKey("host.example.com", "A")]
+	if !ok || rs.TTL != 120 || !reflect.DeepEqual(rs.Rdata, []string{"192.0.2.10"}) {
+		t.Errorf("host.example.com A is %+v", rs)
+	}
+
+	// The same request now fails its prerequisite
+	request = testSign(testUpdateMessage(101, "example.com", [][]byte{notInUse}, [][]byte{add}), key, tsigNow())
+	verifyGatewayResponse(t, key, request, checkGatewayResponse(t, exchangeUDP(t, addr, request), dnsRcodeYXDomain, 0))
+
+	// Zones that are not served are refused
+	request = testSign(testUpdateMessage(102, "example.org", nil, nil), key, tsigNow())
+	checkGatewayResponse(t, exchangeUDP(t, addr, request), dnsRcodeNotAuth, 0)
+}
@@ -25,7 +25,6 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
 	"github.com/akamai/cli-dns/edgegrid"
-	"github.com/fatih/color"
 	"github.com/urfave/cli"
 )
 
@@ -36,45 +35,38 @@ func cmdUpdateRecordset(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	var (
-		zonename   string
-		outputPath string
-		inputPath  string
+		zonename  string
+		inputPath string
 	)
 
 	// Validate zonename argument
 	if c.NArg() == 0 {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 
 	zonename = c.Args().First()
 
 	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
 	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and does not have recordsets", zonename)), 1)
+		return newCommandError(exitValidation, "Zone %s is an ALIAS zone and does not have recordsets", zonename)
 	}
 
 	if c.IsSet("file") {
 		inputPath = c.String("file")
 		inputPath = filepath.FromSlash(inputPath)
 	}
-	if c.IsSet("output") {
-		outputPath = c.String("output")
-		outputPath = filepath.FromSlash(outputPath)
-	}
-	fmt.Println("Preparing recordset ", "")
+	fmt.Fprintln(os.Stderr, "Preparing recordset")
 	newrecord := &dns.RecordBody{}
 	setchange := false
 	if c.IsSet("file") {
@@ -82,12 +74,12 @@ func cmdUpdateRecordset(c *cli.Context) error {
 		newrecordset := &dns.RecordSet{}
 		data, err := os.ReadFile(filepath.FromSlash(inputPath))
 		if err != nil {
-			return cli.NewExitError(color.RedString("Failed to read input file"), 1)
+			return newCommandError(exitValidation, "Failed to read input file")
 		}
 
 		err = json.Unmarshal(data, &newrecordset)
 		if err != nil {
-			return cli.NewExitError(color.RedString("Failed to parse json file content into recordset"), 1)
+			return newCommandError(exitValidation, "Failed to parse json file content into recordset")
 		}
 		newrecord.Name = newrecordset.Name
 		newrecord.RecordType = newrecordset.Type
@@ -116,7 +108,7 @@ func cmdUpdateRecordset(c *cli.Context) error {
 		}
 	} else {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("Recordset field values or input file are required"), 1)
+		return newCommandError(exitValidation, "Recordset field values or input file are required")
 	}
 
 	// Retrieve recordset for updation
@@ -126,7 +118,7 @@ func cmdUpdateRecordset(c *cli.Context) error {
 		RecordType: newrecord.RecordType,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failure retrieving recordset. Error: %s", err)), 1)
+		return apiError(err, "Failure retrieving recordset. Error: %s", err)
 	}
 
 	if !c.IsSet("file") {
@@ -139,11 +131,16 @@ func cmdUpdateRecordset(c *cli.Context) error {
 	}
 
 	if !setchange {
-		fmt.Fprintln(c.App.Writer, "No recordset change detected")
+		fmt.Fprintln(os.Stderr, "No recordset change detected")
 		return nil
 	}
 
-	fmt.Println("Updating Recordset  ", "")
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+		return err
+	}
+	defer zoneCache.invalidate(zonename)
+
+	fmt.Fprintln(os.Stderr, "Updating Recordset")
 
 	// Update recordset
 	err = dnsClient.UpdateRecord(ctx, dns.UpdateRecordRequest{
@@ -151,11 +148,11 @@ func cmdUpdateRecordset(c *cli.Context) error {
 		Record: newrecord,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset update failed. Error: %s", err.Error())), 1)
+		return apiError(err, "Recordset update failed. Error: %s", err)
 	}
 
 	// Fetching updated recordset
-	fmt.Println("Verifying Recordset  ", "")
+	fmt.Fprintln(os.Stderr, "Verifying Recordset")
 
 	updatedRecord, err := dnsClient.GetRecord(ctx, dns.GetRecordRequest{
 		Zone:       zonename,
@@ -163,50 +160,8 @@ func cmdUpdateRecordset(c *cli.Context) error {
 		RecordType: newrecord.RecordType,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to read recordset content. Error: %s", err)), 1)
-	}
-
-	if c.IsSet("suppress") && c.Bool("suppress") {
-		return nil
-	}
-	results := ""
-	fmt.Fprintln(os.Stderr, color.BlueString("Assembling Recordset Content...\n"))
-
-	// Output either as JSON or table format
-	if c.IsSet("json") && c.Bool("json") {
-		recordset := &dns.RecordSet{}
-		recordset.Name = record.Name
-		recordset.Type = updatedRecord.RecordType
-		recordset.TTL = updatedRecord.TTL
-		recordset.Rdata = updatedRecord.Target
-		zjson, err := json.MarshalIndent(recordset, "", "  ")
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to marshal recordset"), 1)
-		}
-		results = string(zjson)
-	} else {
-		results = renderRecordsetTable(zonename, updatedRecord)
-	}
-
-	// Write output to file or console
-	if len(outputPath) > 1 {
-		//fmt.Printf("Writing Output to %s ", outputPath)
-		rsHandle, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to create output file. Error: %s", err.Error())), 1)
-		}
-		defer rsHandle.Close()
-		_, err = rsHandle.WriteString(string(results))
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to write zone output to file"), 1)
-		}
-		rsHandle.Sync()
-		fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
-		return nil
-	} else {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, results)
+		return apiError(err, "Failed to read recordset content. Error: %s", err)
 	}
 
-	return nil
+	return writeOutput(c, recordsetOutput(zonename, updatedRecord))
 }
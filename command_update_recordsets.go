@@ -36,83 +36,85 @@ func cmdUpdateRecordsets(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	var (
-		zonename   string
-		outputPath string
-		inputPath  string
+		zonename  string
+		inputPath string
 	)
 
 	// Validate zonename argument
 	if c.NArg() == 0 {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 
 	zonename = c.Args().First()
 
 	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
 	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and does not have recordsets", zonename)), 1)
+		return newCommandError(exitValidation, "Zone %s is an ALIAS zone and does not have recordsets", zonename)
 	}
 
-	if c.IsSet("output") {
-		outputPath = c.String("output")
-		outputPath = filepath.FromSlash(outputPath)
-	}
 	if c.IsSet("file") {
 		inputPath = c.String("file")
 		inputPath = filepath.FromSlash(inputPath)
 	} else {
-		return cli.NewExitError(color.RedString("Input file is required"), 1)
+		return newCommandError(exitValidation, "Input file is required")
 	}
 
 	// Parse input JSON file
 	data, err := os.ReadFile(filepath.FromSlash(inputPath))
 	if err != nil {
-		return cli.NewExitError(color.RedString("Failed to read input file"), 1)
+		return newCommandError(exitValidation, "Failed to read input file")
 	}
 	recordsets := &dns.RecordSets{}
 	err = json.Unmarshal(data, recordsets)
 	if err != nil {
-		return cli.NewExitError(color.RedString("Failed to parse json file content"), 1)
+		return newCommandError(exitValidation, "Failed to parse json file content")
+	}
+
+	// Existing recordsets are needed to merge and to lint the change
+	var existingRecordSets []dns.RecordSet
+	overwrite := c.IsSet("overwrite") && c.Bool("overwrite")
+	if !overwrite || !c.Bool("no-lint") {
+		fmt.Fprintln(os.Stderr, "Retrieving Existing Recordsets")
+		resp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+			Zone: zonename,
+			QueryArgs: &dns.RecordSetQueryArgs{
+				ShowAll: true,
+			},
+		})
+		if err != nil {
+			return apiError(err, "Recordset List retrieval failed. Error: %s", err)
+		}
+		existingRecordSets = resp.RecordSets
 	}
 
 	// Determine update mode (overwrite or update existing recordset)
 	var recordsetWorkList []dns.RecordSet
 
-	if c.IsSet("overwrite") && c.Bool("overwrite") {
+	if overwrite {
 		recordsets := &dns.RecordSets{}
 		err = json.Unmarshal(data, recordsets)
 		if err != nil {
-			return cli.NewExitError(color.RedString("Failed to parse json file content"), 1)
+			return newCommandError(exitValidation, "Failed to parse json file content")
 		}
 		recordsetWorkList = recordsets.RecordSets
 	} else {
-		fmt.Println("Retrieving Existing Recordsets ", "")
-		resp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
-			Zone: zonename,
-			QueryArgs: &dns.RecordSetQueryArgs{
-				ShowAll: true,
-			},
-		})
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset List retrieval failed. Error: %s", err.Error())), 1)
+		fmt.Fprintln(os.Stderr, "Processing Updated Recordsets")
+		recordsetWorkList = make([]dns.RecordSet, len(existingRecordSets))
+		for i, rs := range existingRecordSets {
+			recordsetWorkList[i] = copyRecordSet(rs)
 		}
 
-		fmt.Println("Processing Updated Recordsets ", "")
-		recordsetWorkList = resp.RecordSets
-
 		// Merge changes from input file
 		soaInSet := false
 		soaIndex := 0
@@ -139,8 +141,17 @@ func cmdUpdateRecordsets(c *cli.Context) error {
 		}
 	}
 
+	if err := preflightLint(c, zonename, existingRecordSets, recordsetWorkList); err != nil {
+		return err
+	}
+
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+		return err
+	}
+	defer zoneCache.invalidate(zonename)
+
 	// Submit recordset updates
-	fmt.Println("Updating Recordsets ", "")
+	fmt.Fprintln(os.Stderr, "Updating Recordsets")
 	recordsets.RecordSets = recordsetWorkList
 	err = dnsClient.UpdateRecordSets(ctx, dns.UpdateRecordSetsRequest{
 		Zone:       zonename,
@@ -148,10 +159,10 @@ func cmdUpdateRecordsets(c *cli.Context) error {
 		RecLock:    []bool{true},
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset update failed. Error: %s", err.Error())), 1)
+		return apiError(err, "Recordset update failed. Error: %s", err)
 	}
 
-	if c.IsSet("suppress") && c.Bool("suppress") {
+	if c.Bool("suppress") {
 		return nil
 	}
 
@@ -161,41 +172,14 @@ func cmdUpdateRecordsets(c *cli.Context) error {
 		Zone: zonename,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset List retrieval failed. Error: %s", err.Error())), 1)
+		return apiError(err, "Recordset List retrieval failed. Error: %s", err)
 	}
 
-	results := ""
-
-	// Format output as JSON or table format
-	if c.IsSet("json") && c.Bool("json") {
-		rjson, err := json.MarshalIndent(resp, "", "  ")
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to display recordsets list"), 1)
-		}
-		results = string(rjson)
-	} else {
-		results = renderRecordsetListTable(zonename, resp.RecordSets)
-	}
-
-	// Write output to file or console
-	if len(outputPath) > 1 {
-		//fmt.Printf("Writing Output to %s ", outputPath)
-		rlfHandle, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to create output file. Error: %s", err.Error())), 1)
-		}
-		defer rlfHandle.Close()
-		_, err = rlfHandle.WriteString(string(results))
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to write zone list output to file"), 1)
-		}
-		rlfHandle.Sync()
-		fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
-		return nil
-	} else {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, results)
+	zone, err := zoneDetails(ctx, c, dnsClient, zoneResp)
+	if err != nil {
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
-
-	return nil
+	out := recordsetListOutput(zonename, zone, resp.RecordSets)
+	out.Value = resp
+	return writeOutput(c, out)
 }
@@ -21,7 +21,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
@@ -37,7 +36,7 @@ func cmdUpdateZone(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return cli.NewExitError(fmt.Sprintf("Session initialization failed: %v", err), 1)
+		return newCommandError(exitAuth, "Session initialization failed: %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
@@ -45,154 +44,95 @@ func cmdUpdateZone(c *cli.Context) error {
 	// Validate zonename argument
 	if c.NArg() == 0 {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 	zonename := c.Args().First()
 
 	// Check if the zone is an ALIAS zone
-	zoneResp, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{
-		Zone: zonename,
-	})
+	zoneResp, err := getZoneInfo(ctx, dnsClient, zonename)
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve zone information for %s. Error: %s", zonename, err)), 1)
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
 	if strings.EqualFold(zoneResp.Type, "ALIAS") {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone %s is an ALIAS zone and does not have recordsets", zonename)), 1)
+		return newCommandError(exitValidation, "Zone %s is an ALIAS zone and does not have recordsets", zonename)
 	}
 
-	var (
-		inputPath  string
-		outputPath string
-	)
-
-	var fileData []byte
-	if c.IsSet("file") {
-		inputPath = filepath.FromSlash(c.String("file"))
-		fileData, err = os.ReadFile(inputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to read input file: %v", err)), 1)
-		}
-	} else {
-		stat, _ := os.Stdin.Stat()
-		if (stat.Mode() & os.ModeCharDevice) != 0 {
-			return cli.NewExitError(color.RedString("No input file or piped data provided"), 1)
-		}
-		fileData, err = io.ReadAll(os.Stdin)
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to read from STDIN: %v", err)), 1)
-		}
+	if c.Bool("merge") && !c.Bool("dns") {
+		return newCommandError(exitValidation, "--merge is only valid with --dns")
 	}
 
-	if c.IsSet("output") {
-		outputPath = filepath.FromSlash(c.String("output"))
+	inputRecordSets, err := loadZoneInput(c, zonename)
+	if err != nil {
+		return wrapError(err)
 	}
 
-	// Handle master zone file upload if --dns flag is set
+	// A master zone file replaces the whole zone unless it is merged through the recordsets path.
+	// The uploaded file is rendered from the parsed recordsets, so $INCLUDE and $ORIGIN are resolved.
+	overwrite := c.Bool("overwrite")
 	if c.Bool("dns") {
-		masterZoneFileData := string(fileData)
-
-		const httpMaxBody = 10 * 1024 * 1024
-		if len(masterZoneFileData) > httpMaxBody {
-			return cli.NewExitError(color.RedString("Master Zone File size too large to process"), 1)
-		}
-
-		fmt.Println("Uploading Master Zone File ...")
-		err = dnsClient.PostMasterZoneFile(ctx, dns.PostMasterZoneFileRequest{
-			Zone:     zonename,
-			FileData: masterZoneFileData,
-		})
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Master Zone File upload failed: %v", err)), 1)
+		if !c.Bool("merge") && !c.Bool("plan") {
+			if !c.Bool("no-lint") {
+				existing, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+					Zone:      zonename,
+					QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+				})
+				if err != nil {
+					return apiError(err, "Recordset list retrieval failed: %v", err)
+				}
+				if err := preflightLint(c, zonename, existing.RecordSets, inputRecordSets); err != nil {
+					return err
+				}
+			}
+			if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+				return err
+			}
+			masterFile, err := renderMasterFile(zonename, inputRecordSets)
+			if err != nil {
+				return newCommandError(exitValidation, "Failed to render Master Zone File: %v", err)
+			}
+			defer zoneCache.invalidate(zonename)
+			fmt.Fprintln(os.Stderr, "Uploading Master Zone File ...")
+			err = dnsClient.PostMasterZoneFile(ctx, dns.PostMasterZoneFileRequest{
+				Zone:     zonename,
+				FileData: masterFile,
+			})
+			if err != nil {
+				return apiError(err, "Master Zone File upload failed: %v", err)
+			}
+			fmt.Fprintln(os.Stderr, "Master Zone File uploaded successfully.")
+			return nil
 		}
-		fmt.Println("Master Zone File uploaded successfully.")
-		return nil
+		overwrite = !c.Bool("merge")
 	}
 
-	// Parse input JSON for recordsets
-	inputRecordSets := &dns.RecordSets{}
-	err = json.Unmarshal(fileData, inputRecordSets)
+	// Prepare recordset update list. Merging also bumps the SOA serial
+	fmt.Fprintln(os.Stderr, "Retrieving Existing Recordsets ...")
+	existingRecordSets, recordsetWorkList, changes, err := planZoneUpdate(ctx, dnsClient, zonename, inputRecordSets, overwrite)
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to parse JSON input file: %v", err)), 1)
+		return apiError(err, "Recordset list retrieval failed: %v", err)
 	}
 
-	// Prepare recordset update list and handle SOA record
-	var recordsetWorkList []dns.RecordSet
-	soaInSet := false
-	soaIndex := -1
-
-	if c.Bool("overwrite") {
-		recordsetWorkList = inputRecordSets.RecordSets
-		for i, rs := range recordsetWorkList {
-			if rs.Type == "SOA" {
-				soaInSet = true
-				soaIndex = i
-				break
-			}
-		}
-	} else {
-		fmt.Println("Retrieving Existing Recordsets ...")
-		existingResp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
-			Zone: zonename,
-			QueryArgs: &dns.RecordSetQueryArgs{
-				ShowAll: true,
-			},
-		})
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset list retrieval failed: %v", err)), 1)
-		}
-
-		recordsetWorkList = existingResp.RecordSets
-
-		for i, rs := range recordsetWorkList {
-			if rs.Type == "SOA" {
-				soaIndex = i
-				break
-			}
-		}
+	if c.Bool("plan") {
+		return writeOutput(c, zonePlanOutput(zonename, changes))
+	}
 
-		for _, updatedRS := range inputRecordSets.RecordSets {
-			found := false
-			for i, existingRS := range recordsetWorkList {
-				if updatedRS.Name == existingRS.Name && updatedRS.Type == existingRS.Type {
-					recordsetWorkList[i] = updatedRS
-					found = true
-					break
-				}
-			}
-			if !found {
-				recordsetWorkList = append(recordsetWorkList, updatedRS)
-			}
-			if updatedRS.Type == "SOA" {
-				soaInSet = true
-			}
-		}
+	if err := preflightLint(c, zonename, existingRecordSets, recordsetWorkList); err != nil {
+		return err
+	}
 
-		if !soaInSet && soaIndex >= 0 {
-			soaRec := &recordsetWorkList[soaIndex]
-			if len(soaRec.Rdata) > 0 {
-				soavals := strings.Fields(soaRec.Rdata[0])
-				if len(soavals) >= 3 {
-					serial, err := strconv.Atoi(soavals[2])
-					if err == nil {
-						serial++
-						soavals[2] = strconv.Itoa(serial)
-						soaRec.Rdata[0] = strings.Join(soavals, " ")
-					} else {
-						fmt.Fprintf(os.Stderr, "Warning: failed to parse SOA serial: %v\n", err)
-					}
-				}
-			}
-		}
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+		return err
 	}
+	defer zoneCache.invalidate(zonename)
 
-	fmt.Println("Updating Recordsets")
+	fmt.Fprintln(os.Stderr, "Updating Recordsets")
 	err = dnsClient.UpdateRecordSets(ctx, dns.UpdateRecordSetsRequest{
 		Zone:       zonename,
 		RecordSets: &dns.RecordSets{RecordSets: recordsetWorkList},
 		RecLock:    nil,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Recordset update failed: %v", err)), 1)
+		return apiError(err, "Recordset update failed: %v", err)
 	}
 
 	if c.Bool("suppress") {
@@ -205,37 +145,82 @@ func cmdUpdateZone(c *cli.Context) error {
 		Zone: zonename,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to retrieve recordsets after update: %v", err)), 1)
+		return apiError(err, "Failed to retrieve recordsets after update: %v", err)
+	}
+
+	zone, err := zoneDetails(ctx, c, dnsClient, zoneResp)
+	if err != nil {
+		return apiError(err, "Failed to retrieve zone information for %s. Error: %s", zonename, err)
 	}
+	out := recordsetListOutput(zonename, zone, resp.RecordSets)
+	out.Value = resp
+	return writeOutput(c, out)
+}
 
-	var results string
-	if c.Bool("json") {
-		rjson, err := json.MarshalIndent(resp, "", "  ")
+// Read update input from the --file flag or piped STDIN. A positive limit rejects
+// larger input; files are checked before they are read.
+func readZoneInputData(c *cli.Context, limit int64) ([]byte, error) {
+	if c.IsSet("file") {
+		inputPath := filepath.FromSlash(c.String("file"))
+		if limit > 0 {
+			info, err := os.Stat(inputPath)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to read input file: %w", err)
+			}
+			if info.Size() > limit {
+				return nil, fmt.Errorf("Input file size too large to process")
+			}
+		}
+		fileData, err := os.ReadFile(inputPath)
 		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to display recordsets list"), 1)
+			return nil, fmt.Errorf("Failed to read input file: %w", err)
 		}
-		results = string(rjson)
-	} else {
-		results = renderRecordsetListTable(zonename, resp.RecordSets)
+		return fileData, nil
+	}
+	stat, _ := os.Stdin.Stat()
+	if (stat.Mode() & os.ModeCharDevice) != 0 {
+		return nil, fmt.Errorf("No input file or piped data provided")
+	}
+	var reader io.Reader = os.Stdin
+	if limit > 0 {
+		reader = io.LimitReader(os.Stdin, limit+1)
+	}
+	fileData, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read from STDIN: %w", err)
+	}
+	if limit > 0 && int64(len(fileData)) > limit {
+		return nil, fmt.Errorf("Input size too large to process")
 	}
+	return fileData, nil
+}
 
-	// Output results to file or console
-	if outputPath != "" {
-		f, err := os.Create(outputPath)
+// Read update input and convert it to recordsets. With --dns the input is parsed
+// locally as a master zone file, otherwise it is a recordsets JSON document.
+func loadZoneInput(c *cli.Context, zonename string) ([]dns.RecordSet, error) {
+	if !c.Bool("dns") {
+		fileData, err := readZoneInputData(c, 0)
 		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to create output file: %v", err)), 1)
+			return nil, err
 		}
-		defer f.Close()
-		_, err = f.WriteString(results)
-		if err != nil {
-			return cli.NewExitError(color.RedString("Failed to write zone output to file"), 1)
+		inputRecordSets := &dns.RecordSets{}
+		if err := json.Unmarshal(fileData, inputRecordSets); err != nil {
+			return nil, fmt.Errorf("Failed to parse JSON input file: %w", err)
 		}
-		f.Sync()
-		fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
-	} else {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, results)
+		return inputRecordSets.RecordSets, nil
 	}
 
-	return nil
+	fileData, err := readZoneInputData(c, masterFileMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	inputPath := ""
+	if c.IsSet("file") {
+		inputPath = filepath.FromSlash(c.String("file"))
+	}
+	recordsets, err := parseMasterFile(fileData, zonename, inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid Master Zone File: %w", err)
+	}
+	return recordsets, nil
 }
@@ -24,7 +24,6 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
 	"github.com/akamai/cli-dns/edgegrid"
-	"github.com/fatih/color"
 	"github.com/urfave/cli"
 )
 
@@ -35,14 +34,13 @@ func cmdUpdateZoneconfig(c *cli.Context) error {
 
 	sess, err := edgegrid.InitializeSession(c)
 	if err != nil {
-		return fmt.Errorf("session failed %v", err)
+		return newCommandError(exitAuth, "session failed %v", err)
 	}
 	ctx = edgegrid.WithSession(ctx, sess)
 	dnsClient := dns.Client(edgegrid.GetSession(ctx))
 
 	var (
 		zonename           string
-		outputPath         string
 		inputPath          string
 		masterZoneFileData string
 	)
@@ -50,10 +48,10 @@ func cmdUpdateZoneconfig(c *cli.Context) error {
 	// Validate zonename argument
 	if c.NArg() == 0 {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("zonename is required"), 1)
+		return newCommandError(exitValidation, "zonename is required")
 	}
 
-	fmt.Println("Preparing zone for update ", "")
+	fmt.Fprintln(os.Stderr, "Preparing zone for update")
 
 	zonename = c.Args().First()
 
@@ -66,67 +64,58 @@ func cmdUpdateZoneconfig(c *cli.Context) error {
 		inputPath = c.String("file")
 		inputPath = filepath.FromSlash(inputPath)
 		if c.IsSet("type") {
-			fmt.Println("Warning: Zone Field and File args are defined. Field values will be ignored!")
+			fmt.Fprintln(os.Stderr, "Warning: Zone Field and File args are defined. Field values will be ignored!")
 		}
 	} else if !c.IsSet("type") && !masterfile {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString("Either zone command line field values or input file are required"), 1)
-	}
-
-	if c.IsSet("output") {
-		outputPath = c.String("output")
-		outputPath = filepath.FromSlash(outputPath)
+		return newCommandError(exitValidation, "Either zone command line field values or input file are required")
 	}
 
 	if c.IsSet("file") {
-		data, err := os.ReadFile(inputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString("Failed to read input file"), 1)
-		}
-		// Update master zone file if dns flag set
+		// Update master zone file if dns flag set. The file is parsed locally and the
+		// upload is rendered from the parsed recordsets
 		if masterfile {
-			masterZoneFileData = string(data)
-			if len(masterZoneFileData) > httpMaxBody {
-				return cli.NewExitError(color.RedString("Master Zone File size too large to process"), 1)
+			data, err := readMasterFile(inputPath)
+			if err != nil {
+				return newCommandError(exitValidation, "Failed to read input file: %s", err)
+			}
+			recordsets, err := parseMasterFile(data, zonename, inputPath)
+			if err != nil {
+				return newCommandError(exitValidation, "Invalid Master Zone File: %s", err)
+			}
+			masterZoneFileData, err = renderMasterFile(zonename, recordsets)
+			if err != nil {
+				return newCommandError(exitValidation, "Failed to render Master Zone File: %s", err)
 			}
 		} else {
+			data, err := os.ReadFile(inputPath)
+			if err != nil {
+				return newCommandError(exitValidation, "Failed to read input file")
+			}
 			err = json.Unmarshal(data, &newZone)
 			if err != nil {
-				return cli.NewExitError(color.RedString("Failed to parse json file content into zone object %s", err), 1)
+				return newCommandError(exitValidation, "Failed to parse json file content into zone object %s", err)
 			}
 			// Validate required fields from JSON
-			if newZone.Zone != "" {
-				zonename = strings.TrimSpace(strings.ToLower(newZone.Zone))
-			} else {
-				return cli.NewExitError(color.RedString("zone is missing in JSON file"), 1)
-			}
-			if newZone.Type != "" {
-				newZone.Type = strings.ToUpper(newZone.Type)
-			}
-			if newZone.SignAndServeAlgorithm != "" {
-				newZone.SignAndServeAlgorithm = strings.ToUpper(newZone.SignAndServeAlgorithm) // Uppercase signAndServeAlgorithm
+			normalizeZoneCreate(newZone)
+			if newZone.Zone == "" {
+				return newCommandError(exitValidation, "zone is missing in JSON file")
 			}
+			zonename = newZone.Zone
 		}
 	}
 
 	if zonename == "" {
-		return cli.NewExitError(color.RedString("zone name is required"), 1)
+		return newCommandError(exitValidation, "zone name is required")
 	}
 
 	// Fetch zone
 	zone, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zonename})
 	if err != nil {
-		return cli.NewExitError(color.RedString("failure while checking zone existance %s", err), 1)
+		return apiError(err, "failure while checking zone existance %s", err)
 	}
 	if zone == nil {
-		return cli.NewExitError(color.RedString("zone retrieval returned nil!"), 1)
-	}
-
-	zoneJson, err := json.MarshalIndent(zone, "", "  ")
-	if err != nil {
-		fmt.Printf("Failed to marshal zone for debug: %v\n", err)
-	} else {
-		fmt.Printf("Retrieved Zone:\n%s\n", string(zoneJson))
+		return newCommandError(exitError, "zone retrieval returned nil!")
 	}
 
 	/*payload, _ := json.MarshalIndent(newZone, "", "  ")
@@ -195,27 +184,32 @@ func cmdUpdateZoneconfig(c *cli.Context) error {
 		}
 	}
 
+	if err := snapshotBeforeChange(ctx, dnsClient, c, zonename); err != nil {
+		return err
+	}
+	defer zoneCache.invalidate(zonename)
+
 	// Updating master zone file
 	if masterfile {
-		fmt.Println("Updating Master Zone File ", "")
+		fmt.Fprintln(os.Stderr, "Updating Master Zone File")
 		err = dnsClient.PostMasterZoneFile(ctx, dns.PostMasterZoneFileRequest{
 			Zone:     zonename,
 			FileData: masterZoneFileData,
 		})
 		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Master Zone File update failed. Error: %s", err.Error())), 1)
+			return apiError(err, "Master Zone File update failed. Error: %s", err)
 		}
 		return nil
 	}
 
 	//fmt.Printf("DEBUG: updating zone: '%s'\n", newZone.Zone)
 
-	fmt.Println("Updating Zone  ", "")
+	fmt.Fprintln(os.Stderr, "Updating Zone")
 	err = dns.ValidateZone(newZone)
 
 	if err != nil {
 		cli.ShowCommandHelp(c, c.Command.Name)
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Invalid value provided for zone. Error: %s", err.Error())), 1)
+		return newCommandError(exitValidation, "Invalid value provided for zone. Error: %s", err)
 	}
 
 	// Updating zone
@@ -223,51 +217,17 @@ func cmdUpdateZoneconfig(c *cli.Context) error {
 		CreateZone: newZone,
 	})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Zone update failed. Error: %s", err.Error())), 1)
+		return apiError(err, "Zone update failed. Error: %s", err)
 	}
 
-	fmt.Println("Reading Zone Content  ", "")
+	fmt.Fprintln(os.Stderr, "Reading Zone Content")
 	zone, err = dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zonename})
 	if err != nil {
-		return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to read zone content. Error: %s", err.Error())), 1)
-	}
-
-	if c.IsSet("suppress") && c.Bool("suppress") {
-		return nil
-	}
-	results := ""
-	fmt.Fprintln(os.Stderr, color.BlueString("Assembling updated zone content...\n"))
-
-	// Format output either as JSON or table format
-	if c.IsSet("json") && c.Bool("json") {
-		zjson, err := json.MarshalIndent(zone, "", "  ")
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to display zone"), 1)
-		}
-		results = string(zjson)
-	} else {
-		results = renderZoneconfigTable(zone, c)
+		return apiError(err, "Failed to read zone content. Error: %s", err)
 	}
 
-	// Write output to file or console
-	if len(outputPath) > 1 {
-		//fmt.Printf("Writing Output to %s ", outputPath)
-		zfHandle, err := os.Create(outputPath)
-		if err != nil {
-			return cli.NewExitError(color.RedString(fmt.Sprintf("Failed to create output file. Error: %s", err.Error())), 1)
-		}
-		defer zfHandle.Close()
-		_, err = zfHandle.WriteString(string(results))
-		if err != nil {
-			return cli.NewExitError(color.RedString("Unable to write zone output to file"), 1)
-		}
-		zfHandle.Sync()
-		fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
-		return nil
-	} else {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, results)
-	}
-
-	return nil
+	return writeOutput(c, &CommandOutput{
+		Value: zone,
+		Table: func() string { return renderZoneconfigTable(zone, c) },
+	})
 }
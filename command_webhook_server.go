@@ -0,0 +1,90 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/urfave/cli"
+)
+
+func cmdWebhookServer(c *cli.Context) error {
+	if c.String("txt-owner-id") == "" {
+		return newCommandError(exitValidation, "--txt-owner-id must not be empty")
+	}
+	logger, err := commandLogger(c.String("log-format"))
+	if err != nil {
+		return wrapError(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	sess, err := edgegrid.InitializeSession(c)
+	if err != nil {
+		return newCommandError(exitAuth, "session failed %v", err)
+	}
+	ctx = edgegrid.WithSession(ctx, sess)
+
+	filter := ExternalDNSDomainFilter{}
+	for _, d := range c.StringSlice("domain-filter") {
+		filter.Include = append(filter.Include, normalizeDNSName(d))
+	}
+	for _, d := range c.StringSlice("exclude-domains") {
+		filter.Exclude = append(filter.Exclude, normalizeDNSName(d))
+	}
+	provider := &externalDNSProvider{
+		dnsClient:  dns.Client(edgegrid.GetSession(ctx)),
+		sess:       sess,
+		filter:     filter,
+		ownerID:    c.String("txt-owner-id"),
+		txtPrefix:  c.String("txt-prefix"),
+		txtSuffix:  c.String("txt-suffix"),
+		defaultTTL: c.Int("default-ttl"),
+		changeList: c.Bool("changelist"),
+		log:        logger,
+	}
+
+	listener, err := net.Listen("tcp", c.String("listen"))
+	if err != nil {
+		return newCommandError(exitError, "Failed to listen on %s: %v", c.String("listen"), err)
+	}
+	server := &http.Server{
+		Handler:           provider,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("ExternalDNS webhook provider listening", "address", "http://"+listener.Addr().String(),
+		"domainFilter", filter.Include, "excludeDomains", filter.Exclude, "ownerId", provider.ownerID)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return newCommandError(exitError, "Webhook server failed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DNS record types not defined by dnsmessage
+const (
+	dnsTypeDS     dnsmessage.Type = 43
+	dnsTypeRRSIG  dnsmessage.Type = 46
+	dnsTypeDNSKEY dnsmessage.Type = 48
+)
+
+// DNSSEC algorithm mnemonics, RFC 8624
+var dnssecAlgorithms = map[int]string{
+	5:  "RSASHA1",
+	7:  "RSASHA1-NSEC3-SHA1",
+	8:  "RSASHA256",
+	10: "RSASHA512",
+	13: "ECDSAP256SHA256",
+	14: "ECDSAP384SHA384",
+	15: "ED25519",
+	16: "ED448",
+}
+
+func dnssecAlgorithmName(alg int) string {
+	if name, ok := dnssecAlgorithms[alg]; ok {
+		return name
+	}
+	return strconv.Itoa(alg)
+}
+
+// DNSSecKey is a zone signing key as published in the DNSKEY recordset
+type DNSSecKey struct {
+	KeyTag     int        `json:"keyTag"`
+	Flags      int        `json:"flags"`
+	Role       string     `json:"role"`
+	Algorithm  string     `json:"algorithm"`
+	Pending    bool       `json:"pending,omitempty"`
+	Expiration *time.Time `json:"signatureExpiration,omitempty"`
+}
+
+// Parse DNSKEY records in presentation format, one per line
+func parseDNSKeyRecords(records string) ([]DNSSecKey, error) {
+	keys := []DNSSecKey{}
+	for _, line := range strings.Split(records, "\n") {
+		fields := strings.Fields(line)
+		idx := indexOfField(fields, "DNSKEY")
+		if idx < 0 {
+			continue
+		}
+		rdata := fields[idx+1:]
+		if len(rdata) < 4 {
+			return nil, fmt.Errorf("invalid DNSKEY record %q", line)
+		}
+		flags, err1 := strconv.Atoi(rdata[0])
+		protocol, err2 := strconv.Atoi(rdata[1])
+		alg, err3 := strconv.Atoi(rdata[2])
+		key, err4 := base64.StdEncoding.DecodeString(strings.Join(rdata[3:], ""))
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			return nil, fmt.Errorf("invalid DNSKEY record %q", line)
+		}
+
+		role := "ZSK"
+		if flags&1 == 1 {
+			role = "KSK"
+		}
+		keys = append(keys, DNSSecKey{
+			KeyTag:    dnskeyTag(flags, protocol, alg, key),
+			Flags:     flags,
+			Role:      role,
+			Algorithm: dnssecAlgorithmName(alg),
+		})
+	}
+	return keys, nil
+}
+
+// Key tag calculation from RFC 4034 appendix B
+func dnskeyTag(flags, protocol, alg int, key []byte) int {
+	rdata := make([]byte, 4, 4+len(key))
+	binary.BigEndian.PutUint16(rdata, uint16(flags))
+	rdata[2] = byte(protocol)
+	rdata[3] = byte(alg)
+	rdata = append(rdata, key...)
+
+	var ac uint32
+	for i, b := range rdata {
+		if i&1 == 1 {
+			ac += uint32(b)
+		} else {
+			ac += uint32(b) << 8
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+	return int(ac & 0xFFFF)
+}
+
+// Split DS records in presentation format into one rdata string per record
+func parseDSRecords(records string) []string {
+	ds := []string{}
+	for _, line := range strings.Split(records, "\n") {
+		fields := strings.Fields(line)
+		idx := indexOfField(fields, "DS")
+		if idx < 0 || idx+1 >= len(fields) {
+			continue
+		}
+		ds = append(ds, strings.Join(fields[idx+1:], " "))
+	}
+	return ds
+}
+
+func indexOfField(fields []string, rtype string) int {
+	for i, f := range fields {
+		if strings.EqualFold(f, rtype) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Query a name server for the RRSIG records covering a zone's DNSKEY and SOA
+// recordsets and return the earliest signature expiration by key tag
+func querySignatureExpirations(server, zone string, timeout time.Duration) (map[int]time.Time, error) {
+	expirations := map[int]time.Time{}
+	for _, qtype := range []dnsmessage.Type{dnsTypeDNSKEY, dnsmessage.TypeSOA} {
+		msg, err := dnsQuery(server, zone, qtype, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if err := collectRRSIGExpirations(msg, qtype, expirations); err != nil {
+			return nil, err
+		}
+	}
+	return expirations, nil
+}
+
+// Send a DNSSEC OK query over UDP, retrying over TCP when the answer is truncated
+func dnsQuery(server, name string, qtype dnsmessage.Type, timeout time.Duration) ([]byte, error) {
+	qname, err := dnsmessage.NewName(strings.TrimSuffix(name, ".") + ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var idBytes [2]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, err
+	}
+	id := binary.BigEndian.Uint16(idBytes[:])
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	if err := b.StartAdditionals(); err != nil {
+		return nil, err
+	}
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(4096, dnsmessage.RCodeSuccess, true); err != nil {
+		return nil, err
+	}
+	if err := b.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, err
+	}
+	query, err := b.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	addr := strings.TrimSuffix(server, ".")
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "53")
+	}
+	resp, err := dnsExchange("udp", addr, query, timeout)
+	if err != nil {
+		return nil, err
+	}
+	var h dnsmessage.Header
+	var p dnsmessage.Parser
+	if h, err = p.Start(resp); err != nil {
+		return nil, err
+	}
+	if h.Truncated {
+		if resp, err = dnsExchange("tcp", addr, query, timeout); err != nil {
+			return nil, err
+		}
+		if h, err = p.Start(resp); err != nil {
+			return nil, err
+		}
+	}
+	if h.ID != id {
+		return nil, fmt.Errorf("%s answered with mismatched message id", server)
+	}
+	if h.RCode != dnsmessage.RCodeSuccess {
+		return nil, fmt.Errorf("%s answered %s", server, h.RCode)
+	}
+	return resp, nil
+}
+
+func dnsExchange(network, addr string, query []byte, timeout time.Duration) ([]byte, error) {
+	conn, err := net.DialTimeout(network, addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if network == "udp" {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, 65535)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+
+	// TCP messages carry a two byte length prefix
+	framed := make([]byte, 2+len(query))
+	binary.BigEndian.PutUint16(framed, uint16(len(query)))
+	copy(framed[2:], query)
+	if _, err := conn.Write(framed); err != nil {
+		return nil, err
+	}
+	var size [2]byte
+	if _, err := io.ReadFull(conn, size[:]); err != nil {
+		return nil, err
+	}
+	resp := make([]byte, binary.BigEndian.Uint16(size[:]))
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Record the earliest expiration per key tag of the RRSIGs covering qtype
+func collectRRSIGExpirations(msg []byte, qtype dnsmessage.Type, expirations map[int]time.Time) error {
+	var p dnsmessage.Parser
+	if _, err := p.Start(msg); err != nil {
+		return err
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return err
+	}
+	for {
+		h, err := p.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if h.Type != dnsTypeRRSIG {
+			if err := p.SkipAnswer(); err != nil {
+				return err
+			}
+			continue
+		}
+		r, err := p.UnknownResource()
+		if err != nil {
+			return err
+		}
+
+		// type covered(2) algorithm(1) labels(1) original ttl(4) expiration(4) inception(4) key tag(2)
+		if len(r.Data) < 18 || dnsmessage.Type(binary.BigEndian.Uint16(r.Data)) != qtype {
+			continue
+		}
+		expires := time.Unix(int64(binary.BigEndian.Uint32(r.Data[8:])), 0).UTC()
+		tag := int(binary.BigEndian.Uint16(r.Data[16:]))
+		if prev, ok := expirations[tag]; !ok || expires.Before(prev) {
+			expirations[tag] = expires
+		}
+	}
+}
@@ -0,0 +1,388 @@
+package edgegrid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/urfave/cli"
+)
+
+// Cassettes are directories of recorded API interactions, one JSON file per
+// request, written with --record and served back with --replay
+
+const redacted = "REDACTED"
+
+// Host used for replayed requests when no endpoint is given. The requests never
+// leave the process.
+const replayEndpoint = "https://cassette.invalid"
+
+// Headers and query parameters that are never written to a cassette
+var (
+	redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+	redactedParams  = []string{"accountSwitchKey"}
+)
+
+type cassetteRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type interaction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+// Get the directory to record API interactions to, empty when not recording
+func GetRecordDir(c *cli.Context) string {
+	return c.GlobalString("record")
+}
+
+// Get the directory to replay API interactions from, empty when not replaying
+func GetReplayDir(c *cli.Context) string {
+	return c.GlobalString("replay")
+}
+
+// Check the cassette flags before any command runs. A recording holds a single
+// command run, so it must start in an empty directory.
+func ValidateCassetteFlags(c *cli.Context) error {
+	record, replay := GetRecordDir(c), GetReplayDir(c)
+	if record != "" && replay != "" {
+		return fmt.Errorf("--record and --replay cannot be used together")
+	}
+	if replay != "" {
+		if info, err := os.Stat(replay); err != nil || !info.IsDir() {
+			return fmt.Errorf("replay directory %s does not exist", replay)
+		}
+	}
+	if record != "" {
+		files, err := cassetteFiles(record)
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			return fmt.Errorf("record directory %s already holds a recording; use a new directory for each command", record)
+		}
+	}
+	return nil
+}
+
+// Transports shared by every session of the command run, by directory
+var (
+	cassetteMu         sync.Mutex
+	cassetteTransports = map[string]http.RoundTripper{}
+)
+
+// HTTP client that records to or replays from a cassette, nil when neither is set
+func cassetteClient(c *cli.Context) (*http.Client, error) {
+	replay, record := GetReplayDir(c), GetRecordDir(c)
+	if replay == "" && record == "" {
+		return nil, nil
+	}
+
+	cassetteMu.Lock()
+	defer cassetteMu.Unlock()
+	key := replay + "\x00" + record
+	t, ok := cassetteTransports[key]
+	if !ok {
+		var err error
+		if replay != "" {
+			t, err = newReplayTransport(replay)
+		} else {
+			t, err = newRecordTransport(record, http.DefaultTransport)
+		}
+		if err != nil {
+			return nil, err
+		}
+		cassetteTransports[key] = t
+	}
+	return &http.Client{Transport: t}, nil
+}
+
+// recordTransport sends requests on and saves each request and response
+type recordTransport struct {
+	mu   sync.Mutex
+	dir  string
+	next int
+	base http.RoundTripper
+}
+
+var cassetteFileSeq = regexp.MustCompile(`^(\d+)-`)
+
+func newRecordTransport(dir string, base http.RoundTripper) (http.RoundTripper, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	return &recordTransport{dir: dir, next: 1, base: base}, nil
+}
+
+func (t *recordTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	in := interaction{
+		Request: cassetteRequest{
+			Method: r.Method,
+			URL:    redactURL(r.URL),
+			Header: redactHeader(r.Header),
+			Body:   redactBody(reqBody),
+		},
+		Response: cassetteResponse{
+			Status: resp.StatusCode,
+			Header: responseHeader(resp.Header),
+			Body:   redactBody(respBody),
+		},
+	}
+	data, err := json.MarshalIndent(in, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	name := fmt.Sprintf("%04d-%s-%s.json", t.next, r.Method, cassetteSlug(r.URL.Path))
+	if err := os.WriteFile(filepath.Join(t.dir, name), append(data, '\n'), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write cassette: %w", err)
+	}
+	t.next++
+	return resp, nil
+}
+
+// replayTransport answers requests from a cassette without using the network
+type replayTransport struct {
+	mu           sync.Mutex
+	interactions []interaction
+	used         []bool
+	warn         io.Writer
+}
+
+func newReplayTransport(dir string) (http.RoundTripper, error) {
+	files, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no recorded interactions in %s", dir)
+	}
+	t := &replayTransport{warn: os.Stderr}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		var in interaction
+		if err := json.Unmarshal(data, &in); err != nil {
+			return nil, fmt.Errorf("invalid cassette file %s: %w", f, err)
+		}
+		t.interactions = append(t.interactions, in)
+	}
+	t.used = make([]bool, len(t.interactions))
+	return t, nil
+}
+
+// Requests are matched on method, URL and body in recorded order. Each recording
+// is served once; when all matches are used the last one is served again, so
+// polling loops end on the final recorded status. A request served from a
+// recording with a different body is reported, as the command changed what it
+// sends.
+func (t *replayTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	method, u, body := r.Method, redactURL(r.URL), redactBody(reqBody)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	match, fallback, last := -1, -1, -1
+	for i, in := range t.interactions {
+		if in.Request.Method != method || in.Request.URL != u {
+			continue
+		}
+		last = i
+		if t.used[i] {
+			continue
+		}
+		if in.Request.Body == body {
+			match = i
+			break
+		}
+		if fallback < 0 {
+			fallback = i
+		}
+	}
+	if match < 0 {
+		match = fallback
+	}
+	if match < 0 {
+		match = last
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded interaction for %s %s", method, u)
+	}
+	t.used[match] = true
+	if t.interactions[match].Request.Body != body {
+		fmt.Fprintf(t.warn, "Warning: replayed %s %s with a request body that differs from the recording\n", method, u)
+	}
+
+	rec := t.interactions[match].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        responseHeader(rec.Header),
+		Body:          io.NopCloser(strings.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       r,
+	}, nil
+}
+
+// Cassette files in recorded order, by sequence number
+func cassetteFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		if a, b := cassetteSeq(files[i]), cassetteSeq(files[j]); a != b {
+			return a < b
+		}
+		return files[i] < files[j]
+	})
+	return files, nil
+}
+
+// Sequence number at the start of a cassette file name
+func cassetteSeq(file string) int {
+	if m := cassetteFileSeq.FindStringSubmatch(filepath.Base(file)); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return n
+	}
+	return 0
+}
+
+// Read a request body and restore it so the request can still be sent
+func readRequestBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// Path and query of a request, without the host, with sensitive parameters masked
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	for _, p := range redactedParams {
+		if q.Has(p) {
+			q.Set(p, redacted)
+		}
+	}
+	if len(q) == 0 {
+		return u.EscapedPath()
+	}
+	return u.EscapedPath() + "?" + q.Encode()
+}
+
+func redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range redactedHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
+
+// Response headers without Content-Length, which no longer holds once a body is
+// redacted
+func responseHeader(h http.Header) http.Header {
+	out := redactHeader(h)
+	out.Del("Content-Length")
+	return out
+}
+
+// Mask TSIG secrets in a JSON body. JSON is re-encoded with sorted keys so
+// recorded and replayed bodies compare equal; other bodies are kept as they are.
+func redactBody(body []byte) string {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if len(body) == 0 || dec.Decode(&v) != nil || dec.More() {
+		return string(body)
+	}
+	redactJSON(v)
+	out, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(out)
+}
+
+func redactJSON(v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if _, ok := val.(string); ok && strings.EqualFold(k, "secret") {
+				t[k] = redacted
+				continue
+			}
+			redactJSON(val)
+		}
+	case []interface{}:
+		for _, val := range t {
+			redactJSON(val)
+		}
+	}
+}
+
+var slugUnsafe = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// Readable file name part for a request path
+func cassetteSlug(path string) string {
+	slug := strings.Trim(slugUnsafe.ReplaceAllString(strings.TrimPrefix(path, "/config-dns/v2/"), "_"), "_")
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+	if slug == "" {
+		slug = "root"
+	}
+	return slug
+}
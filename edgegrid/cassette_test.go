@@ -0,0 +1,141 @@
+package edgegrid
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testAuthorization = "EG1-HMAC-SHA256 client_token=akab-client;access_token=akab-access;signature=c2ln"
+	testSecret        = "c2VjcmV0LXRzaWcta2V5"
+	testAccountKey    = "1-ACCOUNT:1-2345"
+)
+
+// API stand-in that answers every request with a TSIG key and a session cookie
+func newCassetteTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc123")
+		io.WriteString(w, `{"zone":"example.com","tsigKey":{"name":"k1","algorithm":"hmac-sha256","secret":"`+testSecret+`"}}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func sendCassetteRequest(t *testing.T, rt http.RoundTripper, method, url, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", testAuthorization)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func readCassetteDir(t *testing.T, dir string) string {
+	t.Helper()
+	files, err := cassetteFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var all strings.Builder
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all.Write(data)
+	}
+	return all.String()
+}
+
+func TestRecordRedactsCredentials(t *testing.T) {
+	srv := newCassetteTestServer(t)
+	dir := filepath.Join(t.TempDir(), "cassette")
+	rt, err := newRecordTransport(dir, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"zone":"example.com","tsigKey":{"name":"k1","algorithm":"hmac-sha256","secret":"` + testSecret + `"}}`
+	resp := sendCassetteRequest(t, rt, http.MethodPut, srv.URL+"/config-dns/v2/zones/example.com?accountSwitchKey="+testAccountKey, body)
+	got, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(got), testSecret) {
+		t.Error("recording changed the response the command sees")
+	}
+
+	files, _ := cassetteFiles(dir)
+	if len(files) != 1 || filepath.Base(files[0]) != "0001-PUT-zones_example.com.json" {
+		t.Fatalf("cassette files %v, want 0001-PUT-zones_example.com.json", files)
+	}
+	cassette := readCassetteDir(t, dir)
+	for _, leak := range []string{testAuthorization, "akab-", testSecret, "abc123", "1-ACCOUNT", "1-2345"} {
+		if strings.Contains(cassette, leak) {
+			t.Errorf("cassette contains %q:\n%s", leak, cassette)
+		}
+	}
+	if n := strings.Count(cassette, redacted); n < 5 {
+		t.Errorf("cassette has %d redactions, want the header, cookie, parameter and both secrets:\n%s", n, cassette)
+	}
+}
+
+func TestReplayServesRecording(t *testing.T) {
+	srv := newCassetteTestServer(t)
+	dir := t.TempDir()
+	rec, err := newRecordTransport(dir, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sendCassetteRequest(t, rec, http.MethodGet, srv.URL+"/config-dns/v2/zones/example.com", "")
+	sendCassetteRequest(t, rec, http.MethodPost, srv.URL+"/config-dns/v2/zones/example.com/recordsets", `{"b":1,"a":2}`)
+	srv.Close()
+
+	rt, err := newReplayTransport(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var warnings bytes.Buffer
+	rt.(*replayTransport).warn = &warnings
+
+	// Replays need no server, and the last recording is repeated for polling
+	for i := 0; i < 2; i++ {
+		resp := sendCassetteRequest(t, rt, http.MethodGet, replayEndpoint+"/config-dns/v2/zones/example.com", "")
+		got, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(got), `"secret":"REDACTED"`) {
+			t.Errorf("replay %d answered %d %s", i, resp.StatusCode, got)
+		}
+	}
+
+	// JSON bodies compare equal whatever their key order
+	sendCassetteRequest(t, rt, http.MethodPost, replayEndpoint+"/config-dns/v2/zones/example.com/recordsets", `{"a":2,"b":1}`)
+	if warnings.Len() != 0 {
+		t.Errorf("unexpected replay warnings: %s", warnings.String())
+	}
+	sendCassetteRequest(t, rt, http.MethodPost, replayEndpoint+"/config-dns/v2/zones/example.com/recordsets", `{"a":3}`)
+	if !strings.Contains(warnings.String(), "differs from the recording") {
+		t.Errorf("changed request body was not reported: %q", warnings.String())
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, replayEndpoint+"/config-dns/v2/zones/example.com", nil)
+	if _, err := rt.RoundTrip(req); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("unrecorded request returned %v", err)
+	}
+}
+
+func TestReplayNeedsRecording(t *testing.T) {
+	if _, err := newReplayTransport(t.TempDir()); err == nil {
+		t.Error("replay from an empty directory succeeded")
+	}
+}
@@ -40,8 +40,8 @@ func GetEdgegridConfig(c *cli.Context) (*edgegrid.Config, error) {
 		return nil, err
 	}
 
-	if c.IsSet("accountkey") {
-		config.AccountKey = c.GlobalString("accountkey")
+	if accountKey := c.GlobalString("accountkey"); accountKey != "" {
+		config.AccountKey = accountKey
 	}
 
 	return config, nil
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/edgegrid"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/log"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/session"
 	"github.com/urfave/cli"
@@ -17,9 +18,26 @@ var sessionKey ctxKey = "session"
 
 // sets up a new Akamai Edgegrid session
 func InitializeSession(c *cli.Context) (session.Session, error) {
-	edgerc, err := GetEdgegridConfig(c)
-	if err != nil {
-		return nil, fmt.Errorf("could not retrieve zone configuration: %s", err)
+	endpoint := GetEndpoint(c)
+	if endpoint == "" && GetReplayDir(c) != "" {
+		// Replayed requests never reach the API, so no credentials are needed
+		endpoint = replayEndpoint
+	}
+
+	var signer edgegrid.Signer
+	if endpoint != "" {
+		// No credentials are needed for an endpoint override
+		s, err := newEndpointSigner(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		signer = s
+	} else {
+		edgerc, err := GetEdgegridConfig(c)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve zone configuration: %s", err)
+		}
+		signer = edgerc
 	}
 
 	retryConfig, err := getRetryConfig()
@@ -28,12 +46,19 @@ func InitializeSession(c *cli.Context) (session.Session, error) {
 	}
 
 	options := []session.Option{
-		session.WithSigner(edgerc),
+		session.WithSigner(signer),
 		session.WithHTTPTracing(os.Getenv("AKAMAI_HTTP_TRACE_ENABLED") == "true"),
 		session.WithLog(log.Default()),
 	}
 
-	if retryConfig != nil {
+	client, err := cassetteClient(c)
+	if err != nil {
+		return nil, err
+	}
+	if client != nil {
+		// Retries are left out so a cassette holds exactly one response per request
+		options = append(options, session.WithClient(client))
+	} else if retryConfig != nil {
 		options = append(options, session.WithRetries(*retryConfig))
 	}
 
@@ -0,0 +1,212 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// Exit codes. These are part of the CLI interface and must not change.
+const (
+	exitOK         = 0
+	exitError      = 1 // any failure not covered below
+	exitValidation = 2 // invalid arguments, flags or input, or a request the API rejected as invalid
+	exitAuth       = 3 // credentials could not be loaded or the API refused them
+	exitNotFound   = 4 // the zone, recordset, key or other object does not exist
+	exitConflict   = 5 // the object already exists or changed since it was read
+	exitPartial    = 6 // some zones or items succeeded and others failed
+)
+
+// Error kinds written with --error-format json, by exit code
+var exitKinds = map[int]string{
+	exitOK:         "ok",
+	exitError:      "error",
+	exitValidation: "validation",
+	exitAuth:       "auth",
+	exitNotFound:   "not_found",
+	exitConflict:   "conflict",
+	exitPartial:    "partial",
+}
+
+const (
+	errorFormatText = "text"
+	errorFormatJSON = "json"
+)
+
+// Error format selected with the global --error-format flag
+var errorFormat = errorFormatText
+
+// CommandError is a command failure with its exit code and, when the failure
+// came from the API, the problem details of the response
+type CommandError struct {
+	Code     int    `json:"exitCode"`
+	Kind     string `json:"kind"`
+	Message  string `json:"message"`
+	Status   int    `json:"status,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	err error
+}
+
+func (e *CommandError) Error() string {
+	return e.Message
+}
+
+// ExitCode implements cli.ExitCoder
+func (e *CommandError) ExitCode() int {
+	return e.Code
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.err
+}
+
+// Error with an explicit exit code. An error among the args is kept as the cause.
+func newCommandError(code int, format string, args ...interface{}) *CommandError {
+	e := &CommandError{Code: code, Message: fmt.Sprintf(format, args...)}
+	for _, a := range args {
+		if err, ok := a.(error); ok {
+			e.err = err
+		}
+	}
+	e.Kind = exitKind(code)
+	e.setAPIDetails()
+	return e
+}
+
+// Error for a failed operation, with the exit code taken from the API response
+// status when err carries one
+func apiError(err error, format string, args ...interface{}) *CommandError {
+	e := newCommandError(exitError, format, args...)
+	e.err = err
+	e.setAPIDetails()
+	if e.Status != 0 {
+		e.Code = statusExitCode(e.Status)
+		e.Kind = exitKind(e.Code)
+	}
+	return e
+}
+
+// Command error for any error returned by a helper. Command errors and errors
+// built with cli.NewExitError keep their exit code.
+func wrapError(err error) *CommandError {
+	var ce *CommandError
+	if errors.As(err, &ce) {
+		return ce
+	}
+	e := apiError(err, "%s", strings.TrimSpace(stripColor(err.Error())))
+	var coder cli.ExitCoder
+	if e.Status == 0 && errors.As(err, &coder) {
+		e.Code = coder.ExitCode()
+		e.Kind = exitKind(e.Code)
+	}
+	return e
+}
+
+// Copy the problem details of a wrapped API error
+func (e *CommandError) setAPIDetails() {
+	var dnsErr *dns.Error
+	if e.err == nil || !errors.As(e.err, &dnsErr) {
+		return
+	}
+	e.Status = dnsErr.StatusCode
+	e.Type = dnsErr.Type
+	e.Title = dnsErr.Title
+	e.Detail = dnsErr.Detail
+	e.Instance = dnsErr.Instance
+}
+
+func statusExitCode(status int) int {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return exitValidation
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return exitAuth
+	case http.StatusNotFound:
+		return exitNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return exitConflict
+	}
+	return exitError
+}
+
+func exitKind(code int) string {
+	if kind, ok := exitKinds[code]; ok {
+		return kind
+	}
+	return exitKinds[exitError]
+}
+
+// Exit code for a run over several zones or items
+func partialExitCode(failed, total int) int {
+	if failed > 0 && failed < total {
+		return exitPartial
+	}
+	return exitError
+}
+
+// Write a command error to STDERR and exit with its code. Used as the app's
+// ExitErrHandler so every returned error is reported the same way.
+func handleCommandError(c *cli.Context, err error) {
+	if err == nil {
+		return
+	}
+	ce := wrapError(err)
+	writeCommandError(os.Stderr, ce)
+	cli.OsExiter(ce.Code)
+}
+
+// Write the error as red text, or as a JSON object with --error-format json
+func writeCommandError(w io.Writer, ce *CommandError) {
+	if errorFormat == errorFormatJSON {
+		b, _ := json.Marshal(ce)
+		fmt.Fprintln(w, string(b))
+		return
+	}
+	if ce.Message != "" {
+		fmt.Fprintln(w, color.RedString(ce.Message))
+	}
+}
+
+// Text without the colour codes added for console output
+func stripColor(msg string) string {
+	if !strings.Contains(msg, "\x1b[") {
+		return msg
+	}
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		if msg[i] == 0x1b && i+1 < len(msg) && msg[i+1] == '[' {
+			for i < len(msg) && msg[i] != 'm' {
+				i++
+			}
+			continue
+		}
+		b.WriteByte(msg[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,242 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// Default number of zones processed at once
+const defaultZoneParallel = 4
+
+// zoneJob runs a command against one zone and returns its rendered result
+type zoneJob func(ctx context.Context, zonename string) (string, error)
+
+// ZoneResult is the outcome of a zone job
+type ZoneResult struct {
+	Zone   string
+	Output string
+	Err    error
+}
+
+// Collect the target zones from positional arguments, --zones-file and --zone-search.
+// Zones are de-duplicated in the order they were given.
+func zoneTargets(ctx context.Context, dnsClient dns.DNS, c *cli.Context, args []string) ([]string, error) {
+	zones := []string{}
+	seen := map[string]bool{}
+	add := func(z string) {
+		z = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(z), "."))
+		if z != "" && !seen[z] {
+			seen[z] = true
+			zones = append(zones, z)
+		}
+	}
+
+	for _, z := range args {
+		add(z)
+	}
+	if c.IsSet("zones-file") {
+		names, err := readZonesFile(filepath.FromSlash(c.String("zones-file")))
+		if err != nil {
+			return nil, err
+		}
+		for _, z := range names {
+			add(z)
+		}
+	}
+	if c.IsSet("zone-search") {
+		if dnsClient == nil {
+			return nil, fmt.Errorf("--zone-search needs an API session")
+		}
+		resp, err := dnsClient.ListZones(ctx, dns.ListZonesRequest{
+			ShowAll: true,
+			SortBy:  "zone",
+			Search:  c.String("zone-search"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("zone list retrieval failed: %w", err)
+		}
+		for _, z := range resp.Zones {
+			add(z.Zone)
+		}
+	}
+	return zones, nil
+}
+
+// Read zone names one per line. Blank lines and # comments are ignored.
+func readZonesFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read zones file: %w", err)
+	}
+	defer f.Close()
+
+	zones := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			zones = append(zones, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read zones file: %w", err)
+	}
+	return zones, nil
+}
+
+// Record name qualified with zonename. Names already in zonename are kept, and a
+// name that ends in a dot or is in another of the target zones is rejected
+// instead of being qualified a second time.
+func qualifyRecordName(name, zonename string, zones []string) (string, error) {
+	name = strings.TrimSpace(name)
+	fqdn := strings.TrimSuffix(name, ".")
+	lower := strings.ToLower(fqdn)
+	if lower == zonename || strings.HasSuffix(lower, "."+zonename) {
+		return fqdn, nil
+	}
+	inZone := strings.HasSuffix(name, ".")
+	for _, z := range zones {
+		inZone = inZone || lower == z || strings.HasSuffix(lower, "."+z)
+	}
+	if inZone {
+		return "", newCommandError(exitValidation, "record name %s must be within the zone %s", name, zonename)
+	}
+	return fqdn + "." + zonename, nil
+}
+
+// True when the command was asked to run against more than the single positional zone
+func multiZone(c *cli.Context, zones []string) bool {
+	return len(zones) != 1 || c.IsSet("zones-file") || c.IsSet("zone-search")
+}
+
+// Run a job for every zone with at most parallel jobs at once. Results are
+// returned in the order of the zones.
+func runZoneJobs(ctx context.Context, zones []string, parallel int, job zoneJob) []ZoneResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+	results := make([]ZoneResult, len(zones))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallel && w < len(zones); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				out, err := job(ctx, zones[i])
+				results[i] = ZoneResult{Zone: zones[i], Output: out, Err: err}
+			}
+		}()
+	}
+	for i := range zones {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// Run a job against the target zones and print the aggregated results. Any
+// failed zone makes the command exit non-zero.
+func runZoneCommand(ctx context.Context, c *cli.Context, zones []string, asJSON bool, job zoneJob) error {
+	if len(zones) == 0 {
+		return newCommandError(exitValidation, "no zones to process")
+	}
+
+	fmt.Fprintln(os.Stderr, color.BlueString("Processing %d zone(s), %d at a time...", len(zones), c.Int("parallel")))
+	results := runZoneJobs(ctx, zones, c.Int("parallel"), job)
+
+	output, failed := renderZoneResults(results, asJSON)
+	if err := writeOutputText(c, output); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return newCommandError(partialExitCode(failed, len(zones)), "%d of %d zone(s) failed", failed, len(zones))
+	}
+	fmt.Fprintln(os.Stderr, color.GreenString("%d zone(s) processed", len(zones)))
+	return nil
+}
+
+// Render zone results in order. JSON output is an array with each zone's result
+// embedded as JSON, other output is one section per zone. A job may return output
+// along with its error, such as lint findings.
+func renderZoneResults(results []ZoneResult, asJSON bool) (string, int) {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+
+	if asJSON {
+		type zoneResultJSON struct {
+			Zone   string      `json:"zone"`
+			Result interface{} `json:"result,omitempty"`
+			Error  string      `json:"error,omitempty"`
+		}
+		list := make([]zoneResultJSON, 0, len(results))
+		for _, r := range results {
+			item := zoneResultJSON{Zone: r.Zone}
+			if r.Err != nil {
+				item.Error = zoneErrorText(r.Err)
+			}
+			if json.Valid([]byte(r.Output)) {
+				item.Result = json.RawMessage(r.Output)
+			} else if strings.TrimSpace(r.Output) != "" {
+				item.Result = r.Output
+			}
+			list = append(list, item)
+		}
+		b, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return "", failed
+		}
+		return string(b), failed
+	}
+
+	var out strings.Builder
+	for _, r := range results {
+		fmt.Fprintf(&out, "\n=== %s ===\n", r.Zone)
+		if strings.TrimSpace(r.Output) != "" {
+			fmt.Fprintln(&out, strings.TrimRight(r.Output, "\n"))
+		}
+		if r.Err != nil {
+			fmt.Fprintln(&out, color.RedString("Error: %s", zoneErrorText(r.Err)))
+		}
+	}
+	return out.String(), failed
+}
+
+// Error text without the colour codes added for console output
+func zoneErrorText(err error) string {
+	return strings.TrimSpace(stripColor(err.Error()))
+}
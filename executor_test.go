@@ -0,0 +1,45 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "testing"
+
+func TestQualifyRecordName(t *testing.T) {
+	zones := []string{"a.com", "b.com"}
+	tests := []struct {
+		name, zone, want string
+	}{
+		{"www", "a.com", "www.a.com"},
+		{"www", "b.com", "www.b.com"},
+		{"www.a.com", "a.com", "www.a.com"},
+		{"WWW.A.com.", "a.com", "WWW.A.com"},
+		{"a.com", "a.com", "a.com"},
+		{"www.a.com", "b.com", ""},
+		{"www.c.com.", "a.com", ""},
+		{"www.c.com", "a.com", "www.c.com.a.com"},
+	}
+	for _, tt := range tests {
+		got, err := qualifyRecordName(tt.name, tt.zone, zones)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%s in %s: got %s, want an error", tt.name, tt.zone, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s in %s: got %q, %v, want %q", tt.name, tt.zone, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"gopkg.in/yaml.v3"
+)
+
+// Output format names accepted by --format
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatJSONL = "jsonl"
+	formatYAML  = "yaml"
+	formatCSV   = "csv"
+	formatBIND  = "bind"
+)
+
+// ZoneExport is the zone header and recordsets handed to an exporter.
+// Zone is nil when only recordsets are known.
+type ZoneExport struct {
+	Name       string               `json:"-"`
+	Zone       *dns.GetZoneResponse `json:"zone,omitempty"`
+	RecordSets []dns.RecordSet      `json:"recordsets"`
+}
+
+// zoneExporter serializes a zone export into a single output document
+type zoneExporter func(export *ZoneExport) (string, error)
+
+// Registered exporters by format name. Table and JSON come from the shared
+// CommandOutput rendering.
+var zoneExporters = map[string]zoneExporter{
+	formatJSONL: exportJSONL,
+	formatYAML:  exportYAML,
+	formatCSV:   exportCSV,
+	formatBIND:  exportBIND,
+}
+
+// Command output for a zone export with the registered exporters as extra formats
+func zoneExportOutput(export *ZoneExport) *CommandOutput {
+	out := &CommandOutput{
+		Value:   export,
+		Table:   func() string { return renderRecordsetListTable(export.Name, export.RecordSets) },
+		Formats: map[string]func() (string, error){},
+	}
+	for name, exporter := range zoneExporters {
+		exporter := exporter
+		out.Formats[name] = func() (string, error) { return exporter(export) }
+	}
+	return out
+}
+
+// One recordset JSON object per line
+func exportJSONL(export *ZoneExport) (string, error) {
+	var out strings.Builder
+	for _, rs := range export.RecordSets {
+		b, err := json.Marshal(rs)
+		if err != nil {
+			return "", err
+		}
+		out.Write(b)
+		out.WriteString("\n")
+	}
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+func exportYAML(export *ZoneExport) (string, error) {
+	return marshalYAML(export)
+}
+
+// YAML keeps the JSON field names by converting the JSON document
+func marshalYAML(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return "", err
+	}
+	clearYAMLStyle(&node)
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return "", err
+	}
+	enc.Close()
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+// Decoded JSON is flow styled; reset it so YAML is written in block style
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && needsYAMLQuote(node.Value) {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+// Strings that would otherwise read back as another type stay quoted,
+// including the YAML 1.1 booleans older readers still honour
+func needsYAMLQuote(s string) bool {
+	switch strings.ToLower(s) {
+	case "y", "yes", "n", "no", "on", "off":
+		return true
+	}
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return true
+	}
+	_, isString := v.(string)
+	return !isString || v.(string) != s
+}
+
+// One row per rdata value so the output loads directly into a spreadsheet
+func exportCSV(export *ZoneExport) (string, error) {
+	var out strings.Builder
+	w := csv.NewWriter(&out)
+	w.Write([]string{"name", "type", "ttl", "rdata"})
+	for _, rs := range export.RecordSets {
+		for _, rdata := range rs.Rdata {
+			w.Write([]string{rs.Name, rs.Type, strconv.Itoa(rs.TTL), rdata})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+// RFC 1035 master file with the zone header as comments. Owner names are fully
+// qualified so the output can be read back with any origin.
+func exportBIND(export *ZoneExport) (string, error) {
+	var out strings.Builder
+	name := strings.TrimSuffix(export.Name, ".")
+	if export.Zone != nil {
+		z := export.Zone
+		fmt.Fprintf(&out, "; Zone: %s\n", z.Zone)
+		fmt.Fprintf(&out, "; Type: %s\n", z.Type)
+		if z.ContractID != "" {
+			fmt.Fprintf(&out, "; Contract ID: %s\n", z.ContractID)
+		}
+		if z.Comment != "" {
+			fmt.Fprintf(&out, "; Comment: %s\n", strings.ReplaceAll(z.Comment, "\n", " "))
+		}
+		if len(z.Masters) > 0 {
+			fmt.Fprintf(&out, "; Masters: %s\n", strings.Join(z.Masters, ", "))
+		}
+		if z.VersionID != "" {
+			fmt.Fprintf(&out, "; Version ID: %s\n", z.VersionID)
+		}
+		if z.LastModifiedDate != "" {
+			fmt.Fprintf(&out, "; Last Modified: %s by %s\n", z.LastModifiedDate, z.LastModifiedBy)
+		}
+	}
+	if name != "" {
+		fmt.Fprintf(&out, "$ORIGIN %s.\n", name)
+	}
+
+	// SOA first, as master file readers expect
+	recordsets := append([]dns.RecordSet(nil), export.RecordSets...)
+	sort.SliceStable(recordsets, func(i, j int) bool {
+		return recordsets[i].Type == "SOA" && recordsets[j].Type != "SOA"
+	})
+	for _, rs := range recordsets {
+		owner := strings.TrimSuffix(rs.Name, ".") + "."
+		for _, rdata := range rs.Rdata {
+			fmt.Fprintf(&out, "%s\t%d\tIN\t%s\t%s\n", owner, rs.TTL, rs.Type, rdata)
+		}
+	}
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
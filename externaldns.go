@@ -0,0 +1,505 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/session"
+)
+
+// Media type of every ExternalDNS webhook request and response
+const externalDNSMediaType = "application/external.dns.webhook+json;version=1"
+
+// ExternalDNSEndpoint is a DNS name and its targets for one record type, as
+// exchanged with ExternalDNS
+type ExternalDNSEndpoint struct {
+	DNSName          string                        `json:"dnsName"`
+	Targets          []string                      `json:"targets"`
+	RecordType       string                        `json:"recordType"`
+	SetIdentifier    string                        `json:"setIdentifier,omitempty"`
+	RecordTTL        int64                         `json:"recordTTL,omitempty"`
+	Labels           map[string]string             `json:"labels,omitempty"`
+	ProviderSpecific []ExternalDNSProviderProperty `json:"providerSpecific,omitempty"`
+}
+
+// ExternalDNSProviderProperty is a provider specific endpoint setting
+type ExternalDNSProviderProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ExternalDNSChanges is the body of an apply changes request
+type ExternalDNSChanges struct {
+	Create    []*ExternalDNSEndpoint `json:"Create,omitempty"`
+	UpdateOld []*ExternalDNSEndpoint `json:"UpdateOld,omitempty"`
+	UpdateNew []*ExternalDNSEndpoint `json:"UpdateNew,omitempty"`
+	Delete    []*ExternalDNSEndpoint `json:"Delete,omitempty"`
+}
+
+// ExternalDNSDomainFilter is the negotiation response
+type ExternalDNSDomainFilter struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Record types ExternalDNS names in TXT registry records, "<type>-<name>"
+var externalDNSRegistryTypes = []string{"A", "AAAA", "CNAME", "TXT", "MX", "SRV", "NS", "CAA", "PTR", "NAPTR"}
+
+// externalDNSProvider serves the ExternalDNS webhook provider protocol for the
+// primary zones matching its domain filter
+type externalDNSProvider struct {
+	dnsClient  dns.DNS
+	sess       session.Session
+	filter     ExternalDNSDomainFilter
+	ownerID    string
+	txtPrefix  string
+	txtSuffix  string
+	defaultTTL int
+	changeList bool
+	log        *slog.Logger
+
+	// Changes are applied one request at a time
+	mu sync.Mutex
+}
+
+func (p *externalDNSProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/" && r.Method == http.MethodGet:
+		p.writeJSON(w, http.StatusOK, p.filter)
+	case r.URL.Path == "/records" && r.Method == http.MethodGet:
+		p.handleRecords(w, r)
+	case r.URL.Path == "/records" && r.Method == http.MethodPost:
+		p.handleApplyChanges(w, r)
+	case r.URL.Path == "/adjustendpoints" && r.Method == http.MethodPost:
+		p.handleAdjustEndpoints(w, r)
+	case r.URL.Path == "/healthz" && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (p *externalDNSProvider) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", externalDNSMediaType)
+	w.Header().Set("Vary", "Content-Type")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (p *externalDNSProvider) writeError(w http.ResponseWriter, status int, err error) {
+	p.log.Error("request failed", "error", err.Error())
+	http.Error(w, err.Error(), status)
+}
+
+func (p *externalDNSProvider) handleRecords(w http.ResponseWriter, r *http.Request) {
+	zones, err := p.zones(r.Context())
+	if err != nil {
+		p.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	endpoints := []*ExternalDNSEndpoint{}
+	for _, zone := range zones {
+		recordsets, err := p.recordSets(r.Context(), zone)
+		if err != nil {
+			p.writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		for _, rs := range recordsets {
+			if rs.Type == "SOA" || !p.matches(rs.Name) {
+				continue
+			}
+			endpoints = append(endpoints, recordSetEndpoint(rs))
+		}
+	}
+	p.log.Debug("records listed", "zones", len(zones), "endpoints", len(endpoints))
+	p.writeJSON(w, http.StatusOK, endpoints)
+}
+
+func (p *externalDNSProvider) handleAdjustEndpoints(w http.ResponseWriter, r *http.Request) {
+	var endpoints []*ExternalDNSEndpoint
+	if err := json.NewDecoder(r.Body).Decode(&endpoints); err != nil {
+		p.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid endpoints: %w", err))
+		return
+	}
+	for _, ep := range endpoints {
+		ep.DNSName = normalizeDNSName(ep.DNSName)
+		ep.RecordType = strings.ToUpper(ep.RecordType)
+		if ep.RecordTTL <= 0 {
+			ep.RecordTTL = int64(p.defaultTTL)
+		}
+	}
+	p.writeJSON(w, http.StatusOK, endpoints)
+}
+
+func (p *externalDNSProvider) handleApplyChanges(w http.ResponseWriter, r *http.Request) {
+	var changes ExternalDNSChanges
+	if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+		p.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid changes: %w", err))
+		return
+	}
+	if err := p.applyChanges(r.Context(), &changes); err != nil {
+		p.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Primary zones that can hold names matching the domain filter
+func (p *externalDNSProvider) zones(ctx context.Context) ([]string, error) {
+	resp, err := p.dnsClient.ListZones(ctx, dns.ListZonesRequest{ShowAll: true, SortBy: "zone", Types: "PRIMARY"})
+	if err != nil {
+		return nil, fmt.Errorf("zone list retrieval failed: %w", err)
+	}
+	zones := []string{}
+	for _, z := range resp.Zones {
+		zone := normalizeDNSName(z.Zone)
+		if !hasRecordSets(z.Type) || domainFilterMatches(p.filter.Exclude, zone) {
+			continue
+		}
+		include := len(p.filter.Include) == 0 || domainFilterMatches(p.filter.Include, zone)
+		for _, f := range p.filter.Include {
+			if strings.HasSuffix(f, "."+zone) {
+				include = true
+			}
+		}
+		if include {
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+func (p *externalDNSProvider) recordSets(ctx context.Context, zone string) ([]dns.RecordSet, error) {
+	resp, err := p.dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+		Zone:      zone,
+		QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recordset list retrieval failed for %s: %w", zone, err)
+	}
+	return resp.RecordSets, nil
+}
+
+// Whether a name is inside the domain filter
+func (p *externalDNSProvider) matches(name string) bool {
+	name = normalizeDNSName(name)
+	if domainFilterMatches(p.filter.Exclude, name) {
+		return false
+	}
+	return len(p.filter.Include) == 0 || domainFilterMatches(p.filter.Include, name)
+}
+
+func domainFilterMatches(domains []string, name string) bool {
+	for _, d := range domains {
+		if name == d || strings.HasSuffix(name, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
+
+// A change to one recordset, with the endpoint that asked for it
+type externalDNSChange struct {
+	op       string
+	endpoint *ExternalDNSEndpoint
+}
+
+// Apply the changes zone by zone. Each changed recordset is written with the
+// record API, or the zone gets one change list with --changelist. Changes to recordsets this owner does not
+// hold in the TXT registry are skipped.
+func (p *externalDNSProvider) applyChanges(ctx context.Context, changes *ExternalDNSChanges) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+	byZone := map[string][]externalDNSChange{}
+	add := func(op string, endpoints []*ExternalDNSEndpoint) {
+		for _, ep := range endpoints {
+			name := normalizeDNSName(ep.DNSName)
+			zone := enclosingZone(zones, name)
+			if zone == "" || !p.matches(name) {
+				p.log.Warn("skipping change outside the managed zones", "op", op, "name", name, "type", ep.RecordType)
+				continue
+			}
+			byZone[zone] = append(byZone[zone], externalDNSChange{op: op, endpoint: ep})
+		}
+	}
+	// Deletes first so a recordset can be replaced in one request
+	add(changeListOpDelete, changes.Delete)
+	add(changeListOpEdit, changes.UpdateNew)
+	add(changeListOpAdd, changes.Create)
+
+	names := make([]string, 0, len(byZone))
+	for zone := range byZone {
+		names = append(names, zone)
+	}
+	sort.Strings(names)
+	failed := []string{}
+	for _, zone := range names {
+		if err := p.applyZoneChanges(ctx, zone, byZone[zone]); err != nil {
+			p.log.Error("zone update failed", "zone", zone, "error", err.Error())
+			failed = append(failed, zone)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Longest zone enclosing a name
+func enclosingZone(zones []string, name string) string {
+	best := ""
+	for _, z := range zones {
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(best) {
+			best = z
+		}
+	}
+	return best
+}
+
+func (p *externalDNSProvider) applyZoneChanges(ctx context.Context, zone string, changes []externalDNSChange) error {
+	current, err := p.recordSets(ctx, zone)
+	if err != nil {
+		return err
+	}
+	owned := p.ownedRecordSets(current)
+	existing := map[string]dns.RecordSet{}
+	for _, rs := range current {
+		existing[recordSetKey(rs.Name, rs.Type)] = rs
+	}
+
+	desired := map[string]dns.RecordSet{}
+	for key, rs := range existing {
+		desired[key] = copyRecordSet(rs)
+	}
+	for _, ch := range changes {
+		rs := endpointRecordSet(ch.endpoint, p.defaultTTL)
+		key := recordSetKey(rs.Name, rs.Type)
+		log := p.log.With("zone", zone, "op", strings.ToLower(ch.op), "name", rs.Name, "type", rs.Type)
+		if rs.Type == "SOA" {
+			log.Warn("skipping SOA change")
+			continue
+		}
+		_, exists := existing[key]
+		if exists && !p.owns(owned, rs) {
+			log.Warn("skipping recordset not owned by this owner id", "owner", p.ownerID)
+			continue
+		}
+		if !exists && isRegistryRecordSet(rs) && registryOwner(rs) != p.ownerID {
+			log.Warn("skipping registry record of another owner", "owner", registryOwner(rs))
+			continue
+		}
+		if ch.op == changeListOpDelete {
+			delete(desired, key)
+		} else {
+			desired[key] = rs
+		}
+		log.Info("recordset change planned")
+	}
+
+	target := make([]dns.RecordSet, 0, len(desired))
+	for _, rs := range desired {
+		target = append(target, rs)
+	}
+	diff := diffRecordSets(current, target)
+	if len(diff) == 0 {
+		return nil
+	}
+	defer zoneCache.invalidate(zone)
+
+	if p.changeList {
+		return p.submitChangeList(ctx, zone, diff)
+	}
+	// Only the changed recordsets are written, so concurrent edits to other
+	// recordsets are kept; the SOA is managed by Edge DNS
+	nonSOA := make([]RecordsetChange, 0, len(diff))
+	for _, ch := range diff {
+		if ch.Type != "SOA" {
+			nonSOA = append(nonSOA, ch)
+		}
+	}
+	if err := applyRecordsetChanges(ctx, p.dnsClient, p.log, zone, nonSOA); err != nil {
+		return fmt.Errorf("recordset update failed: %w", err)
+	}
+	p.log.Info("zone updated", "zone", zone, "changes", len(diff))
+	return nil
+}
+
+// Stage the changes in a new change list and submit it. The change list is
+// discarded when staging fails.
+func (p *externalDNSProvider) submitChangeList(ctx context.Context, zone string, diff []RecordsetChange) error {
+	if err := p.dnsClient.SaveChangeList(ctx, dns.SaveChangeListRequest{Zone: zone}); err != nil {
+		return fmt.Errorf("change list create failed: %w", err)
+	}
+	for _, ch := range diff {
+		change := ChangeListChange{Name: ch.Name, Type: ch.Type}
+		switch ch.Action {
+		case changeRemoved:
+			change.Op = changeListOpDelete
+		case changeAdded:
+			change.Op, change.TTL, change.Rdata = changeListOpAdd, ch.After.TTL, ch.After.Rdata
+		default:
+			change.Op, change.TTL, change.Rdata = changeListOpEdit, ch.After.TTL, ch.After.Rdata
+		}
+		if err := addChangeListChange(ctx, p.sess, zone, change); err != nil {
+			discardChangeList(ctx, p.sess, zone)
+			return fmt.Errorf("change list change failed: %w", err)
+		}
+	}
+	if err := p.dnsClient.SubmitChangeList(ctx, dns.SubmitChangeListRequest{Zone: zone}); err != nil {
+		discardChangeList(ctx, p.sess, zone)
+		return fmt.Errorf("change list submit failed: %w", err)
+	}
+	p.log.Info("zone updated through change list", "zone", zone, "changes", len(diff))
+	return nil
+}
+
+// Recordsets this owner holds: the registry records with its owner id and the
+// records they name. A registry record named "<type>-<name>" owns that type
+// only; one named after the record owns every type at the name.
+func (p *externalDNSProvider) ownedRecordSets(recordsets []dns.RecordSet) map[string]bool {
+	owned := map[string]bool{}
+	for _, rs := range recordsets {
+		if !isRegistryRecordSet(rs) || registryOwner(rs) != p.ownerID {
+			continue
+		}
+		owned[recordSetKey(rs.Name, rs.Type)] = true
+
+		name := normalizeDNSName(rs.Name)
+		if p.txtPrefix != "" {
+			if !strings.HasPrefix(name, p.txtPrefix) {
+				continue
+			}
+			name = strings.TrimPrefix(name, p.txtPrefix)
+		}
+		label, rest, _ := strings.Cut(name, ".")
+		if p.txtSuffix != "" {
+			if !strings.HasSuffix(label, p.txtSuffix) {
+				continue
+			}
+			label = strings.TrimSuffix(label, p.txtSuffix)
+		}
+		join := func(label string) string {
+			if rest == "" {
+				return label
+			}
+			return label + "." + rest
+		}
+		owned[recordSetKey(join(label), "*")] = true
+		for _, t := range externalDNSRegistryTypes {
+			if strings.HasPrefix(label, strings.ToLower(t)+"-") {
+				owned[recordSetKey(join(strings.TrimPrefix(label, strings.ToLower(t)+"-")), t)] = true
+			}
+		}
+	}
+	return owned
+}
+
+func (p *externalDNSProvider) owns(owned map[string]bool, rs dns.RecordSet) bool {
+	return owned[recordSetKey(rs.Name, rs.Type)] || owned[recordSetKey(rs.Name, "*")]
+}
+
+// TXT records written by the ExternalDNS TXT registry carry its heritage label
+func isRegistryRecordSet(rs dns.RecordSet) bool {
+	if !strings.EqualFold(rs.Type, "TXT") {
+		return false
+	}
+	for _, rdata := range rs.Rdata {
+		if registryLabels(txtValue(rdata))["heritage"] == "external-dns" {
+			return true
+		}
+	}
+	return false
+}
+
+func registryOwner(rs dns.RecordSet) string {
+	for _, rdata := range rs.Rdata {
+		labels := registryLabels(txtValue(rdata))
+		if labels["heritage"] == "external-dns" {
+			return labels["external-dns/owner"]
+		}
+	}
+	return ""
+}
+
+// Labels of a registry value: "heritage=external-dns,external-dns/owner=id,..."
+func registryLabels(value string) map[string]string {
+	labels := map[string]string{}
+	for _, part := range strings.Split(value, ",") {
+		if k, v, ok := strings.Cut(part, "="); ok {
+			labels[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return labels
+}
+
+// Endpoint for a recordset. TXT strings are unquoted and names lose the trailing dot.
+func recordSetEndpoint(rs dns.RecordSet) *ExternalDNSEndpoint {
+	ep := &ExternalDNSEndpoint{
+		DNSName:    normalizeDNSName(rs.Name),
+		RecordType: strings.ToUpper(rs.Type),
+		RecordTTL:  int64(rs.TTL),
+		Targets:    make([]string, 0, len(rs.Rdata)),
+	}
+	for _, rdata := range rs.Rdata {
+		if ep.RecordType == "TXT" {
+			ep.Targets = append(ep.Targets, txtValue(rdata))
+		} else {
+			ep.Targets = append(ep.Targets, strings.TrimSuffix(rdata, "."))
+		}
+	}
+	return ep
+}
+
+// Recordset for an endpoint. TXT targets are quoted and split into 255 octet
+// strings, and the host names of other targets are fully qualified.
+func endpointRecordSet(ep *ExternalDNSEndpoint, defaultTTL int) dns.RecordSet {
+	rs := dns.RecordSet{
+		Name:  normalizeDNSName(ep.DNSName),
+		Type:  strings.ToUpper(ep.RecordType),
+		TTL:   int(ep.RecordTTL),
+		Rdata: make([]string, 0, len(ep.Targets)),
+	}
+	if rs.TTL <= 0 {
+		rs.TTL = defaultTTL
+	}
+	for _, t := range ep.Targets {
+		if rs.Type == "TXT" {
+			t = quoteTXTChunks(t)
+		} else {
+			t = qualifyRdataNames(rs.Type, t)
+		}
+		rs.Rdata = append(rs.Rdata, t)
+	}
+	return rs
+}
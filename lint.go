@@ -0,0 +1,511 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// Lint finding severities
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+)
+
+// Default lowest TTL accepted by the ttl-policy rule
+const lintDefaultMinTTL = 60
+
+// LintFinding is a single rule violation for a recordset
+type LintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Message  string `json:"message"`
+}
+
+// lintRule checks a whole zone and reports its findings
+type lintRule struct {
+	ID          string
+	Severity    string
+	Description string
+	Check       func(z *lintZone, rule *lintRule) []LintFinding
+}
+
+// lintZone is the indexed zone content shared by the rules
+type lintZone struct {
+	Zone       string
+	MinTTL     int
+	RecordSets []dns.RecordSet
+	types      map[string]map[string]dns.RecordSet
+}
+
+// Registered lint rules, in report order
+var lintRules = []lintRule{
+	{ID: "cname-apex", Severity: lintError, Description: "CNAME records are not allowed at the zone apex", Check: lintCNAMEApex},
+	{ID: "cname-coexist", Severity: lintError, Description: "A CNAME must be the only recordset at its name", Check: lintCNAMECoexist},
+	{ID: "cname-single", Severity: lintError, Description: "A CNAME recordset must have exactly one target", Check: lintCNAMESingle},
+	{ID: "target-cname", Severity: lintError, Description: "MX, NS and SRV targets must not be CNAMEs", Check: lintTargetCNAME},
+	{ID: "missing-glue", Severity: lintError, Description: "Delegations to name servers inside the delegated zone need glue", Check: lintMissingGlue},
+	{ID: "out-of-zone-glue", Severity: lintWarning, Description: "Records below a delegation are not served unless they are glue", Check: lintOutOfZoneGlue},
+	{ID: "ttl-policy", Severity: lintWarning, Description: "Recordset TTLs should not be below the policy minimum", Check: lintTTLPolicy},
+	{ID: "srv-rdata", Severity: lintError, Description: "SRV rdata must be priority, weight, port and target", Check: lintSRVRdata},
+	{ID: "caa-rdata", Severity: lintError, Description: "CAA rdata must be flags, tag and quoted value", Check: lintCAARdata},
+	{ID: "txt-rdata", Severity: lintError, Description: "TXT strings must be quoted and at most 255 bytes each", Check: lintTXTRdata},
+}
+
+// Run every rule against a zone. Findings are sorted by name, type and rule.
+func lintRecordSets(zone string, recordsets []dns.RecordSet, minTTL int) []LintFinding {
+	z := &lintZone{
+		Zone:       lintName(zone),
+		MinTTL:     minTTL,
+		RecordSets: recordsets,
+		types:      map[string]map[string]dns.RecordSet{},
+	}
+	for _, rs := range recordsets {
+		name := lintName(rs.Name)
+		if z.types[name] == nil {
+			z.types[name] = map[string]dns.RecordSet{}
+		}
+		z.types[name][strings.ToUpper(rs.Type)] = rs
+	}
+
+	findings := []LintFinding{}
+	for i := range lintRules {
+		findings = append(findings, lintRules[i].Check(z, &lintRules[i])...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Name != findings[j].Name {
+			return findings[i].Name < findings[j].Name
+		}
+		if findings[i].Type != findings[j].Type {
+			return findings[i].Type < findings[j].Type
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+	return findings
+}
+
+// Findings in after that are not already present in before. Findings match on
+// rule, name and type, so a change to a value quoted in a message is not new.
+func newLintFindings(before, after []LintFinding) []LintFinding {
+	seen := map[string]int{}
+	for _, f := range before {
+		seen[f.key()]++
+	}
+	added := []LintFinding{}
+	for _, f := range after {
+		if seen[f.key()] > 0 {
+			seen[f.key()]--
+			continue
+		}
+		added = append(added, f)
+	}
+	return added
+}
+
+// Identity of a finding for comparison between zone versions
+func (f LintFinding) key() string {
+	return f.Rule + " " + recordSetKey(f.Name, f.Type)
+}
+
+func lintHasErrors(findings []LintFinding) bool {
+	for _, f := range findings {
+		if f.Severity == lintError {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint the zone a write command would produce and block on errors the change introduces.
+// Problems already present in the live zone are only reported alongside new ones and do not block.
+func preflightLint(c *cli.Context, zonename string, current, proposed []dns.RecordSet) error {
+	if c.Bool("no-lint") {
+		return nil
+	}
+	minTTL := c.Int("min-ttl")
+	existing := lintRecordSets(zonename, current, minTTL)
+	findings := lintRecordSets(zonename, proposed, minTTL)
+	added := newLintFindings(existing, findings)
+	if len(added) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, renderLintText(zonename, findings))
+	if lintHasErrors(added) {
+		return newCommandError(exitValidation, "Lint found errors in the requested change. Fix them or re-run with --no-lint")
+	}
+	return nil
+}
+
+// Lowercase a name without its trailing dot for comparison
+func lintName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
+
+func (z *lintZone) finding(rule *lintRule, rs dns.RecordSet, format string, args ...interface{}) LintFinding {
+	return LintFinding{
+		Rule:     rule.ID,
+		Severity: rule.Severity,
+		Name:     rs.Name,
+		Type:     strings.ToUpper(rs.Type),
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// Names of the delegation points below the apex
+func (z *lintZone) delegations() []string {
+	cuts := []string{}
+	for name, types := range z.types {
+		if _, ok := types["NS"]; ok && name != z.Zone {
+			cuts = append(cuts, name)
+		}
+	}
+	sort.Strings(cuts)
+	return cuts
+}
+
+func isSubdomain(name, parent string) bool {
+	return name == parent || strings.HasSuffix(name, "."+parent)
+}
+
+func lintCNAMEApex(z *lintZone, rule *lintRule) []LintFinding {
+	if rs, ok := z.types[z.Zone]["CNAME"]; ok {
+		return []LintFinding{z.finding(rule, rs, "CNAME at the zone apex %s conflicts with the SOA and NS records", z.Zone)}
+	}
+	return nil
+}
+
+func lintCNAMECoexist(z *lintZone, rule *lintRule) []LintFinding {
+	findings := []LintFinding{}
+	for name, types := range z.types {
+		rs, ok := types["CNAME"]
+		if !ok || name == z.Zone || len(types) == 1 {
+			continue
+		}
+		others := []string{}
+		for t := range types {
+			if t != "CNAME" {
+				others = append(others, t)
+			}
+		}
+		sort.Strings(others)
+		findings = append(findings, z.finding(rule, rs, "CNAME coexists with %s at the same name", strings.Join(others, ", ")))
+	}
+	return findings
+}
+
+func lintCNAMESingle(z *lintZone, rule *lintRule) []LintFinding {
+	findings := []LintFinding{}
+	for _, rs := range z.RecordSets {
+		if strings.EqualFold(rs.Type, "CNAME") && len(rs.Rdata) != 1 {
+			findings = append(findings, z.finding(rule, rs, "CNAME has %d targets", len(rs.Rdata)))
+		}
+	}
+	return findings
+}
+
+func lintTargetCNAME(z *lintZone, rule *lintRule) []LintFinding {
+	targetField := map[string]int{"MX": 1, "NS": 0, "SRV": 3}
+	findings := []LintFinding{}
+	for _, rs := range z.RecordSets {
+		idx, ok := targetField[strings.ToUpper(rs.Type)]
+		if !ok {
+			continue
+		}
+		for _, rdata := range rs.Rdata {
+			fields := strings.Fields(rdata)
+			if idx >= len(fields) {
+				continue
+			}
+			target := lintName(fields[idx])
+			if _, isCNAME := z.types[target]["CNAME"]; isCNAME {
+				findings = append(findings, z.finding(rule, rs, "target %s is a CNAME", target))
+			}
+		}
+	}
+	return findings
+}
+
+// NS targets inside a delegated zone cannot be resolved without address records here
+func lintMissingGlue(z *lintZone, rule *lintRule) []LintFinding {
+	findings := []LintFinding{}
+	for _, cut := range z.delegations() {
+		rs := z.types[cut]["NS"]
+		for _, rdata := range rs.Rdata {
+			target := lintName(rdata)
+			if !isSubdomain(target, cut) {
+				continue
+			}
+			_, hasA := z.types[target]["A"]
+			_, hasAAAA := z.types[target]["AAAA"]
+			if !hasA && !hasAAAA {
+				findings = append(findings, z.finding(rule, rs, "name server %s is inside the delegation and has no A or AAAA glue", target))
+			}
+		}
+	}
+	return findings
+}
+
+// Records at or below a delegation point belong to the child zone. Only the
+// address records of in-delegation name servers are served from this zone.
+func lintOutOfZoneGlue(z *lintZone, rule *lintRule) []LintFinding {
+	cuts := z.delegations()
+	glue := map[string]bool{}
+	for _, cut := range cuts {
+		for _, rdata := range z.types[cut]["NS"].Rdata {
+			glue[lintName(rdata)] = true
+		}
+	}
+
+	findings := []LintFinding{}
+	for _, rs := range z.RecordSets {
+		name := lintName(rs.Name)
+		rtype := strings.ToUpper(rs.Type)
+		for _, cut := range cuts {
+			if !isSubdomain(name, cut) {
+				continue
+			}
+			if name == cut && (rtype == "NS" || rtype == "DS") {
+				break
+			}
+			if (rtype == "A" || rtype == "AAAA") && glue[name] {
+				break
+			}
+			findings = append(findings, z.finding(rule, rs, "record is below the delegation %s and is not served from this zone", cut))
+			break
+		}
+	}
+	return findings
+}
+
+func lintTTLPolicy(z *lintZone, rule *lintRule) []LintFinding {
+	findings := []LintFinding{}
+	if z.MinTTL <= 0 {
+		return findings
+	}
+	for _, rs := range z.RecordSets {
+		if rs.TTL < z.MinTTL {
+			findings = append(findings, z.finding(rule, rs, "TTL %d is below the policy minimum of %d", rs.TTL, z.MinTTL))
+		}
+	}
+	return findings
+}
+
+func lintSRVRdata(z *lintZone, rule *lintRule) []LintFinding {
+	findings := []LintFinding{}
+	for _, rs := range z.RecordSets {
+		if !strings.EqualFold(rs.Type, "SRV") {
+			continue
+		}
+		for _, rdata := range rs.Rdata {
+			fields := strings.Fields(rdata)
+			if len(fields) != 4 {
+				findings = append(findings, z.finding(rule, rs, "%q must have 4 fields", rdata))
+				continue
+			}
+			for i, label := range []string{"priority", "weight", "port"} {
+				if _, err := strconv.ParseUint(fields[i], 10, 16); err != nil {
+					findings = append(findings, z.finding(rule, rs, "%q has invalid %s %q", rdata, label, fields[i]))
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func lintCAARdata(z *lintZone, rule *lintRule) []LintFinding {
+	knownTags := map[string]bool{"issue": true, "issuewild": true, "iodef": true, "issuemail": true, "issuevmc": true}
+	findings := []LintFinding{}
+	for _, rs := range z.RecordSets {
+		if !strings.EqualFold(rs.Type, "CAA") {
+			continue
+		}
+		for _, rdata := range rs.Rdata {
+			fields := strings.Fields(rdata)
+			if len(fields) < 3 {
+				findings = append(findings, z.finding(rule, rs, "%q must have flags, tag and value", rdata))
+				continue
+			}
+			if _, err := strconv.ParseUint(fields[0], 10, 8); err != nil {
+				findings = append(findings, z.finding(rule, rs, "%q has invalid flags %q", rdata, fields[0]))
+			}
+			if !knownTags[strings.ToLower(fields[1])] {
+				findings = append(findings, z.finding(rule, rs, "%q has unknown tag %q", rdata, fields[1]))
+			}
+			value := strings.Join(fields[2:], " ")
+			if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+				findings = append(findings, z.finding(rule, rs, "%q value must be quoted", rdata))
+			}
+		}
+	}
+	return findings
+}
+
+func lintTXTRdata(z *lintZone, rule *lintRule) []LintFinding {
+	findings := []LintFinding{}
+	for _, rs := range z.RecordSets {
+		if !strings.EqualFold(rs.Type, "TXT") && !strings.EqualFold(rs.Type, "SPF") {
+			continue
+		}
+		for _, rdata := range rs.Rdata {
+			strs, err := splitTXTStrings(rdata)
+			if err != nil {
+				findings = append(findings, z.finding(rule, rs, "%s", err))
+				continue
+			}
+			for _, s := range strs {
+				if len(s) > 255 {
+					findings = append(findings, z.finding(rule, rs, "string of %d bytes exceeds 255; split it into several quoted strings", len(s)))
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// Lint findings text format
+func renderLintText(zone string, findings []LintFinding) string {
+	var out strings.Builder
+	errors, warnings := 0, 0
+	for _, f := range findings {
+		severity := color.YellowString("warning")
+		if f.Severity == lintError {
+			severity = color.RedString("error")
+			errors++
+		} else {
+			warnings++
+		}
+		fmt.Fprintf(&out, "%s: %s %s: %s [%s]\n", severity, f.Name, f.Type, f.Message, f.Rule)
+	}
+	fmt.Fprintf(&out, "Zone %s: %d error(s), %d warning(s)", zone, errors, warnings)
+	return out.String()
+}
+
+// Minimal SARIF 2.1.0 log for code scanning tools
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string         `json:"id"`
+	ShortDescription     sarifMessage   `json:"shortDescription"`
+	DefaultConfiguration sarifRuleLevel `json:"defaultConfiguration"`
+}
+
+type sarifRuleLevel struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// Lint findings SARIF format. source is the linted file, or the zone name for a live zone.
+func renderLintSARIF(source string, findings []LintFinding) (string, error) {
+	driver := sarifDriver{
+		Name:           "akamai-dns-lint",
+		InformationURI: "https://github.com/akamai/cli-dns",
+		Rules:          make([]sarifRule, 0, len(lintRules)),
+	}
+	for _, r := range lintRules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   r.ID,
+			ShortDescription:     sarifMessage{Text: r.Description},
+			DefaultConfiguration: sarifRuleLevel{Level: r.Severity},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		results = append(results, sarifResult{
+			RuleID:  f.Rule,
+			Level:   f.Severity,
+			Message: sarifMessage{Text: fmt.Sprintf("%s %s: %s", f.Name, f.Type, f.Message)},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: source}},
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               f.Type,
+					FullyQualifiedName: f.Name + "/" + f.Type,
+					Kind:               "resource",
+				}},
+			}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	b, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
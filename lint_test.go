@@ -0,0 +1,182 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+)
+
+func lintRS(name, rtype string, ttl int, rdata ...string) dns.RecordSet {
+	return dns.RecordSet{Name: name, Type: rtype, TTL: ttl, Rdata: rdata}
+}
+
+func TestLintRules(t *testing.T) {
+	tests := []struct {
+		rule       string
+		recordsets []dns.RecordSet
+		want       []string // name/type of each finding of the rule
+	}{
+		{"cname-apex", []dns.RecordSet{lintRS("example.com", "CNAME", 300, "other.net.")}, []string{"example.com CNAME"}},
+		{"cname-apex", []dns.RecordSet{lintRS("www.example.com", "CNAME", 300, "other.net.")}, nil},
+
+		{"cname-coexist", []dns.RecordSet{
+			lintRS("www.example.com", "CNAME", 300, "other.net."),
+			lintRS("www.example.com", "TXT", 300, `"x"`),
+		}, []string{"www.example.com CNAME"}},
+		{"cname-coexist", []dns.RecordSet{
+			lintRS("www.example.com", "CNAME", 300, "other.net."),
+			lintRS("ftp.example.com", "A", 300, "192.0.2.1"),
+		}, nil},
+
+		{"cname-single", []dns.RecordSet{lintRS("www.example.com", "CNAME", 300, "a.net.", "b.net.")}, []string{"www.example.com CNAME"}},
+		{"cname-single", []dns.RecordSet{lintRS("www.example.com", "CNAME", 300, "a.net.")}, nil},
+
+		{"target-cname", []dns.RecordSet{
+			lintRS("example.com", "MX", 300, "10 mail.example.com."),
+			lintRS("mail.example.com", "CNAME", 300, "mx.other.net."),
+			lintRS("_sip._tcp.example.com", "SRV", 300, "10 5 5060 Mail.example.com."),
+		}, []string{"_sip._tcp.example.com SRV", "example.com MX"}},
+		{"target-cname", []dns.RecordSet{
+			lintRS("example.com", "MX", 300, "10 mail.example.com."),
+			lintRS("mail.example.com", "A", 300, "192.0.2.1"),
+		}, nil},
+
+		{"missing-glue", []dns.RecordSet{
+			lintRS("sub.example.com", "NS", 300, "ns1.sub.example.com.", "ns.other.net."),
+		}, []string{"sub.example.com NS"}},
+		{"missing-glue", []dns.RecordSet{
+			lintRS("sub.example.com", "NS", 300, "ns1.sub.example.com."),
+			lintRS("ns1.sub.example.com", "AAAA", 300, "2001:db8::1"),
+		}, nil},
+
+		{"out-of-zone-glue", []dns.RecordSet{
+			lintRS("sub.example.com", "NS", 300, "ns1.sub.example.com."),
+			lintRS("ns1.sub.example.com", "A", 300, "192.0.2.1"),
+			lintRS("www.sub.example.com", "A", 300, "192.0.2.2"),
+			lintRS("sub.example.com", "TXT", 300, `"x"`),
+		}, []string{"sub.example.com TXT", "www.sub.example.com A"}},
+		{"out-of-zone-glue", []dns.RecordSet{
+			lintRS("sub.example.com", "NS", 300, "ns.other.net."),
+			lintRS("sub.example.com", "DS", 300, "1 8 2 ABCD"),
+			lintRS("subway.example.com", "A", 300, "192.0.2.1"),
+		}, nil},
+
+		{"ttl-policy", []dns.RecordSet{
+			lintRS("www.example.com", "A", 30, "192.0.2.1"),
+			lintRS("ftp.example.com", "A", 60, "192.0.2.1"),
+		}, []string{"www.example.com A"}},
+
+		{"srv-rdata", []dns.RecordSet{
+			lintRS("_a._tcp.example.com", "SRV", 300, "10 5 5060"),
+			lintRS("_b._tcp.example.com", "SRV", 300, "10 5 70000 host.example.com."),
+			lintRS("_c._tcp.example.com", "SRV", 300, "10  5\t5060 host.example.com."),
+		}, []string{"_a._tcp.example.com SRV", "_b._tcp.example.com SRV"}},
+
+		{"caa-rdata", []dns.RecordSet{
+			lintRS("a.example.com", "CAA", 300, `0 issue letsencrypt.org`),
+			lintRS("b.example.com", "CAA", 300, `0 issuer "ca.example"`),
+			lintRS("c.example.com", "CAA", 300, `256 issue "ca.example"`),
+			lintRS("d.example.com", "CAA", 300, `0 issue`),
+		}, []string{"a.example.com CAA", "b.example.com CAA", "c.example.com CAA", "d.example.com CAA"}},
+		{"caa-rdata", []dns.RecordSet{
+			lintRS("example.com", "CAA", 300, `0  issue   "ca.example; account=1"`, "128\tiodef \"mailto:x@example.com\""),
+		}, nil},
+
+		{"txt-rdata", []dns.RecordSet{
+			lintRS("a.example.com", "TXT", 300, `"open`),
+			lintRS("b.example.com", "TXT", 300, `"one" two`),
+			lintRS("c.example.com", "TXT", 300, `"`+strings.Repeat("x", 256)+`"`),
+		}, []string{"a.example.com TXT", "b.example.com TXT", "c.example.com TXT"}},
+		{"txt-rdata", []dns.RecordSet{
+			lintRS("a.example.com", "TXT", 300, `"`+strings.Repeat("x", 255)+`" "more"`),
+			lintRS("b.example.com", "TXT", 300, `"`+strings.Repeat(`\255`, 255)+`"`),
+			lintRS("c.example.com", "SPF", 300, `v=spf1`),
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, f := range lintRecordSets("example.com", tt.recordsets, lintDefaultMinTTL) {
+			if f.Rule == tt.rule {
+				got = append(got, f.Name+" "+f.Type)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s on %v: got %v, want %v", tt.rule, tt.recordsets, got, tt.want)
+		}
+	}
+}
+
+func TestLintRulesAreTested(t *testing.T) {
+	// Each registered rule reports something in a zone built to break it
+	broken := []dns.RecordSet{
+		lintRS("example.com", "CNAME", 300, "a.net.", "b.net."),
+		lintRS("example.com", "MX", 30, "10 alias.example.com."),
+		lintRS("alias.example.com", "CNAME", 300, "other.net."),
+		lintRS("sub.example.com", "NS", 300, "ns1.sub.example.com."),
+		lintRS("www.sub.example.com", "A", 300, "192.0.2.1"),
+		lintRS("www.example.com", "CNAME", 300, "other.net."),
+		lintRS("www.example.com", "SRV", 300, "1 2"),
+		lintRS("www.example.com", "CAA", 300, "0 issue"),
+		lintRS("www.example.com", "TXT", 300, `"open`),
+	}
+	rules := map[string]bool{}
+	for _, f := range lintRecordSets("example.com", broken, lintDefaultMinTTL) {
+		rules[f.Rule] = true
+	}
+	for _, r := range lintRules {
+		if !rules[r.ID] {
+			t.Errorf("rule %s reported nothing", r.ID)
+		}
+	}
+}
+
+func TestNewLintFindings(t *testing.T) {
+	before := lintRecordSets("example.com", []dns.RecordSet{
+		lintRS("www.example.com", "A", 30, "192.0.2.1"),
+	}, lintDefaultMinTTL)
+
+	// Only the TTL in the message changes, so the finding is not new
+	after := lintRecordSets("example.com", []dns.RecordSet{
+		lintRS("www.example.com", "A", 20, "192.0.2.1"),
+	}, lintDefaultMinTTL)
+	if added := newLintFindings(before, after); len(added) != 0 {
+		t.Errorf("changed message reported as new: %+v", added)
+	}
+
+	after = lintRecordSets("example.com", []dns.RecordSet{
+		lintRS("www.example.com", "A", 20, "192.0.2.1"),
+		lintRS("www.example.com", "CAA", 300, "0 issue"),
+	}, lintDefaultMinTTL)
+	added := newLintFindings(before, after)
+	if len(added) != 1 || added[0].Rule != "caa-rdata" {
+		t.Errorf("new findings %+v, want the caa-rdata finding", added)
+	}
+
+	// A second finding of the same rule on the same recordset is new
+	after = lintRecordSets("example.com", []dns.RecordSet{
+		lintRS("_sip._tcp.example.com", "SRV", 300, "1 2", "3 4"),
+	}, lintDefaultMinTTL)
+	before = lintRecordSets("example.com", []dns.RecordSet{
+		lintRS("_sip._tcp.example.com", "SRV", 300, "1 2"),
+	}, lintDefaultMinTTL)
+	if added := newLintFindings(before, after); len(added) != 1 {
+		t.Errorf("got %d new findings, want 1: %+v", len(added), added)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+)
+
+// Zone level plan actions
+const (
+	applyCreate = "create"
+	applyUpdate = "update"
+	applyNoop   = "noop"
+)
+
+// ZoneManifest is the desired state of a single zone, kept in source control
+type ZoneManifest struct {
+	Zone       dns.ZoneCreate  `json:"zone"`
+	GroupID    string          `json:"groupId,omitempty"`
+	RecordSets []dns.RecordSet `json:"recordsets,omitempty"`
+}
+
+// ConfigChange is a single zone configuration field difference
+type ConfigChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ZoneApplyPlan is the planned convergence of one zone
+type ZoneApplyPlan struct {
+	Zone          string            `json:"zone"`
+	Action        string            `json:"action"`
+	VersionID     string            `json:"versionId,omitempty"`
+	GroupID       string            `json:"groupId,omitempty"`
+	Config        *dns.ZoneCreate   `json:"config"`
+	ConfigChanges []ConfigChange    `json:"configChanges,omitempty"`
+	RecordSets    []dns.RecordSet   `json:"recordsets,omitempty"`
+	Changes       []RecordsetChange `json:"changes,omitempty"`
+}
+
+// ApplyPlan is the saved result of planning a set of manifests
+type ApplyPlan struct {
+	CreatedAt string          `json:"createdAt"`
+	Zones     []ZoneApplyPlan `json:"zones"`
+}
+
+// Load zone manifests from a JSON file or from every JSON file below a directory
+func loadZoneManifests(path string) ([]ZoneManifest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ".json") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+	}
+
+	manifests := make([]ZoneManifest, 0, len(files))
+	seen := map[string]string{}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		m := ZoneManifest{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		normalizeZoneCreate(&m.Zone)
+		if m.Zone.Zone == "" {
+			return nil, fmt.Errorf("%s: zone is missing", f)
+		}
+		if err := dns.ValidateZone(&m.Zone); err != nil {
+			return nil, fmt.Errorf("%s: invalid zone value: %w", f, err)
+		}
+		if prev, ok := seen[m.Zone.Zone]; ok {
+			return nil, fmt.Errorf("zone %s is defined in both %s and %s", m.Zone.Zone, prev, f)
+		}
+		seen[m.Zone.Zone] = f
+		manifests = append(manifests, m)
+	}
+
+	return manifests, nil
+}
+
+// Normalize zone config values the same way the zoneconfig commands do
+func normalizeZoneCreate(zone *dns.ZoneCreate) {
+	zone.Zone = strings.TrimSpace(strings.ToLower(zone.Zone))
+	zone.Type = strings.ToUpper(zone.Type)
+	zone.SignAndServeAlgorithm = strings.ToUpper(zone.SignAndServeAlgorithm)
+}
+
+// Build an apply plan for every manifest against the live zones
+func planManifests(ctx context.Context, dnsClient dns.DNS, manifests []ZoneManifest) (*ApplyPlan, error) {
+	plan := &ApplyPlan{
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Zones:     make([]ZoneApplyPlan, 0, len(manifests)),
+	}
+
+	for _, m := range manifests {
+		desired := m.Zone
+		zp := ZoneApplyPlan{
+			Zone:       desired.Zone,
+			GroupID:    m.GroupID,
+			Config:     &desired,
+			RecordSets: m.RecordSets,
+		}
+
+		existing, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: desired.Zone})
+		if err != nil {
+			var dnsErr *dns.Error
+			if !errors.As(err, &dnsErr) || dnsErr.StatusCode != http.StatusNotFound {
+				return nil, fmt.Errorf("failed to retrieve zone %s: %w", desired.Zone, err)
+			}
+			// New zones fall back to the active profile's contract and group
+			if desired.ContractID == "" && activeProfile != nil {
+				desired.ContractID = activeProfile.ContractID
+			}
+			if zp.GroupID == "" && activeProfile != nil {
+				zp.GroupID = activeProfile.GroupID
+			}
+			if desired.ContractID == "" {
+				return nil, newCommandError(exitValidation, "zone %s does not exist and its manifest has no contractId", desired.Zone)
+			}
+			zp.Action = applyCreate
+			zp.Changes = diffRecordSets(nil, m.RecordSets)
+			plan.Zones = append(plan.Zones, zp)
+			continue
+		}
+
+		if desired.ContractID == "" {
+			desired.ContractID = existing.ContractID
+		}
+		zp.VersionID = existing.VersionID
+		zp.ConfigChanges = diffZoneConfig(existing, &desired)
+
+		if hasRecordSets(desired.Type) {
+			current, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+				Zone:      desired.Zone,
+				QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve recordsets for %s: %w", desired.Zone, err)
+			}
+			zp.RecordSets = desiredRecordSets(current.RecordSets, m.RecordSets)
+			zp.Changes = diffRecordSets(current.RecordSets, zp.RecordSets)
+		}
+
+		zp.Action = applyNoop
+		if len(zp.ConfigChanges) > 0 || len(zp.Changes) > 0 {
+			zp.Action = applyUpdate
+		}
+		plan.Zones = append(plan.Zones, zp)
+	}
+
+	return plan, nil
+}
+
+// Command output for an apply plan. CSV has one row per recordset change.
+func applyPlanOutput(plan *ApplyPlan) *CommandOutput {
+	return &CommandOutput{
+		Value: plan,
+		Table: func() string { return renderApplyPlanTable(plan) },
+		CSV: func() [][]string {
+			rows := [][]string{{"zone", "action", "change", "name", "type"}}
+			for _, zp := range plan.Zones {
+				if len(zp.Changes) == 0 {
+					rows = append(rows, []string{zp.Zone, zp.Action, "", "", ""})
+				}
+				for _, ch := range zp.Changes {
+					rows = append(rows, []string{zp.Zone, zp.Action, ch.Action, ch.Name, ch.Type})
+				}
+			}
+			return rows
+		},
+	}
+}
+
+// Only primary zones carry recordsets that the CLI can manage
+func hasRecordSets(zoneType string) bool {
+	return strings.EqualFold(zoneType, "PRIMARY")
+}
+
+// The manifest recordsets are the complete desired state, except that the Akamai managed
+// SOA and apex NS recordsets are kept when the manifest does not declare them. A kept
+// SOA gets its serial incremented when anything else changes.
+func desiredRecordSets(current, manifest []dns.RecordSet) []dns.RecordSet {
+	desired := make([]dns.RecordSet, 0, len(manifest)+2)
+	for _, rs := range manifest {
+		desired = append(desired, copyRecordSet(rs))
+	}
+
+	apex := soaOwner(current)
+	declaredSOA, declaredApexNS := false, false
+	for _, rs := range manifest {
+		switch {
+		case strings.EqualFold(rs.Type, "SOA"):
+			declaredSOA = true
+		case strings.EqualFold(rs.Type, "NS") && recordSetKey(rs.Name, rs.Type) == recordSetKey(apex, "NS"):
+			declaredApexNS = true
+		}
+	}
+
+	soaIndex := -1
+	for _, rs := range current {
+		switch {
+		case rs.Type == "SOA" && !declaredSOA:
+			desired = append(desired, copyRecordSet(rs))
+			soaIndex = len(desired) - 1
+		case rs.Type == "NS" && rs.Name == apex && !declaredApexNS:
+			desired = append(desired, copyRecordSet(rs))
+		}
+	}
+
+	if soaIndex >= 0 && len(diffRecordSets(current, desired)) > 0 {
+		incrementSOASerial(&desired[soaIndex])
+	}
+	return desired
+}
+
+func soaOwner(recordsets []dns.RecordSet) string {
+	for _, rs := range recordsets {
+		if rs.Type == "SOA" {
+			return rs.Name
+		}
+	}
+	return ""
+}
+
+// Join a sorted copy of the list, so lists in a different order compare equal
+func joinSorted(list []string) string {
+	sorted := append([]string(nil), list...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// Compare the updatable zone configuration fields
+func diffZoneConfig(existing *dns.GetZoneResponse, desired *dns.ZoneCreate) []ConfigChange {
+	changes := []ConfigChange{}
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, ConfigChange{Field: field, Before: before, After: after})
+		}
+	}
+
+	add("type", strings.ToUpper(existing.Type), desired.Type)
+	add("comment", existing.Comment, desired.Comment)
+	add("masters", joinSorted(existing.Masters), joinSorted(desired.Masters))
+	add("signAndServe", fmt.Sprintf("%t", existing.SignAndServe), fmt.Sprintf("%t", desired.SignAndServe))
+	add("signAndServeAlgorithm", strings.ToUpper(existing.SignAndServeAlgorithm), desired.SignAndServeAlgorithm)
+	add("target", existing.Target, desired.Target)
+	add("endCustomerId", existing.EndCustomerID, desired.EndCustomerID)
+
+	var beforeKey, afterKey dns.TSIGKey
+	if existing.TSIGKey != nil {
+		beforeKey = *existing.TSIGKey
+	}
+	if desired.TSIGKey != nil {
+		afterKey = *desired.TSIGKey
+	}
+	add("tsigKey:name", beforeKey.Name, afterKey.Name)
+	add("tsigKey:algorithm", beforeKey.Algorithm, afterKey.Algorithm)
+	if beforeKey.Secret != afterKey.Secret {
+		changes = append(changes, ConfigChange{Field: "tsigKey:secret", Before: "(hidden)", After: "(hidden)"})
+	}
+
+	return changes
+}
@@ -0,0 +1,1121 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+)
+
+// Fake of the Edge DNS API endpoints the CLI calls, served by mock-server. State
+// is kept in memory and, when a data file is given, saved after every change.
+
+const (
+	mockAPIPrefix   = "/config-dns/v2/"
+	mockModifiedBy  = "mock-server"
+	mockDefaultTTL  = 86400
+	mockMaxBodySize = 10 << 20
+)
+
+// Default name servers for new primary zones
+var mockNameServers = []string{"a1-1.akam.net.", "a2-2.akam.net.", "a3-3.akam.net."}
+
+type mockZone struct {
+	Config     dns.ZoneResponse `json:"config"`
+	RecordSets []dns.RecordSet  `json:"recordsets"`
+	ChangeList *mockChangeList  `json:"changeList,omitempty"`
+}
+
+type mockChangeList struct {
+	dns.GetChangeListResponse
+	RecordSets []dns.RecordSet `json:"recordsets"`
+}
+
+type mockBulkRequest struct {
+	Status    dns.BulkStatusResponse `json:"status"`
+	Succeeded []string               `json:"succeeded"`
+	Failed    []dns.BulkFailedZone   `json:"failed"`
+}
+
+// Everything saved to the data file
+type mockState struct {
+	Sequence       int                         `json:"sequence"`
+	Zones          map[string]*mockZone        `json:"zones"`
+	CreateRequests map[string]*mockBulkRequest `json:"createRequests"`
+	DeleteRequests map[string]*mockBulkRequest `json:"deleteRequests"`
+}
+
+type mockAPI struct {
+	mu    sync.Mutex
+	path  string
+	state *mockState
+}
+
+// Response of a mock endpoint. body is written as JSON, except for a string,
+// which is written as a master file.
+type mockResponse struct {
+	status int
+	body   interface{}
+}
+
+// Create the fake API, loading its state from path when the file exists
+func newMockAPI(path string) (*mockAPI, error) {
+	m := &mockAPI{
+		path: path,
+		state: &mockState{
+			Zones:          map[string]*mockZone{},
+			CreateRequests: map[string]*mockBulkRequest{},
+			DeleteRequests: map[string]*mockBulkRequest{},
+		},
+	}
+	if path == "" {
+		return m, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock data file: %w", err)
+	}
+	if err := json.Unmarshal(data, m.state); err != nil {
+		return nil, fmt.Errorf("invalid mock data file %s: %w", path, err)
+	}
+	if m.state.Zones == nil {
+		m.state.Zones = map[string]*mockZone{}
+	}
+	if m.state.CreateRequests == nil {
+		m.state.CreateRequests = map[string]*mockBulkRequest{}
+	}
+	if m.state.DeleteRequests == nil {
+		m.state.DeleteRequests = map[string]*mockBulkRequest{}
+	}
+	return m, nil
+}
+
+func (m *mockAPI) save() error {
+	if m.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(m.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(m.path, append(data, '\n'), 0600)
+}
+
+func (m *mockAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var resp mockResponse
+	if !strings.HasPrefix(r.URL.Path, mockAPIPrefix) {
+		resp = mockProblem(http.StatusNotFound, "Not Found", "no such endpoint: %s", r.URL.Path)
+	} else {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, mockAPIPrefix), "/"), "/")
+		resp = m.route(r, parts)
+	}
+
+	if r.Method != http.MethodGet && resp.status < http.StatusMultipleChoices {
+		if err := m.save(); err != nil {
+			resp = mockProblem(http.StatusInternalServerError, "Internal Server Error", "failed to save mock data: %v", err)
+		}
+	}
+	writeMockResponse(w, r, resp)
+}
+
+func writeMockResponse(w http.ResponseWriter, r *http.Request, resp mockResponse) {
+	switch body := resp.body.(type) {
+	case nil:
+		w.WriteHeader(resp.status)
+	case string:
+		w.Header().Set("Content-Type", "text/dns")
+		w.WriteHeader(resp.status)
+		io.WriteString(w, body)
+	case *dns.Error:
+		body.Instance = r.URL.Path
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(resp.status)
+		json.NewEncoder(w).Encode(body)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.status)
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+func mockProblem(status int, title, format string, args ...interface{}) mockResponse {
+	return mockResponse{status: status, body: &dns.Error{
+		Type:   fmt.Sprintf("https://problems.luna.akamaiapis.net/authoritative-dns/%s", strings.ToLower(strings.ReplaceAll(title, " ", "-"))),
+		Title:  title,
+		Detail: fmt.Sprintf(format, args...),
+	}}
+}
+
+func mockOK(status int, body interface{}) mockResponse {
+	return mockResponse{status: status, body: body}
+}
+
+func mockMethodNotAllowed() mockResponse {
+	return mockProblem(http.StatusMethodNotAllowed, "Method Not Allowed", "method not allowed")
+}
+
+// Dispatch on the path segments after /config-dns/v2/
+func (m *mockAPI) route(r *http.Request, parts []string) mockResponse {
+	method := r.Method
+	switch {
+	case len(parts) == 1 && parts[0] == "zones":
+		switch method {
+		case http.MethodGet:
+			return m.listZones(r)
+		case http.MethodPost:
+			return m.createZone(r)
+		}
+		return mockMethodNotAllowed()
+
+	case len(parts) == 2 && parts[0] == "zones" && parts[1] == "dns-sec-status":
+		if method != http.MethodPost {
+			return mockMethodNotAllowed()
+		}
+		return m.dnssecStatus(r)
+
+	case len(parts) >= 2 && parts[0] == "zones" && (parts[1] == "create-requests" || parts[1] == "delete-requests"):
+		return m.routeBulk(r, parts[1], parts[2:])
+
+	case len(parts) >= 2 && parts[0] == "zones":
+		return m.routeZone(r, mockZoneName(parts[1]), parts[2:])
+
+	case len(parts) >= 1 && parts[0] == "changelists":
+		return m.routeChangeList(r, parts[1:])
+
+	case len(parts) >= 1 && parts[0] == "keys":
+		return m.routeKeys(r, parts[1:])
+	}
+	return mockProblem(http.StatusNotFound, "Not Found", "no such endpoint: %s", r.URL.Path)
+}
+
+func (m *mockAPI) routeZone(r *http.Request, name string, parts []string) mockResponse {
+	z, ok := m.state.Zones[name]
+	if !ok {
+		return mockZoneNotFound(name)
+	}
+	method := r.Method
+	switch {
+	case len(parts) == 0:
+		switch method {
+		case http.MethodGet:
+			return mockOK(http.StatusOK, z.Config)
+		case http.MethodPut:
+			return m.updateZone(r, z)
+		}
+	case parts[0] == "recordsets" && len(parts) == 1:
+		switch method {
+		case http.MethodGet:
+			return mockRecordSetList(r, z.RecordSets)
+		case http.MethodPost:
+			return m.createRecordSets(r, z)
+		case http.MethodPut:
+			return m.replaceRecordSets(r, z)
+		}
+	case parts[0] == "zone-file" && len(parts) == 1:
+		switch method {
+		case http.MethodGet:
+			out, _ := exportBIND(&ZoneExport{Name: z.Config.Zone, RecordSets: z.RecordSets})
+			return mockOK(http.StatusOK, out+"\n")
+		case http.MethodPost:
+			return m.postZoneFile(r, z)
+		}
+	case parts[0] == "names" && len(parts) == 1:
+		if method == http.MethodGet {
+			names := []string{}
+			seen := map[string]bool{}
+			for _, rs := range z.RecordSets {
+				if !seen[rs.Name] {
+					seen[rs.Name] = true
+					names = append(names, rs.Name)
+				}
+			}
+			return mockOK(http.StatusOK, dns.GetZoneNamesResponse{Names: names})
+		}
+	case parts[0] == "names" && len(parts) == 3 && parts[2] == "types":
+		if method == http.MethodGet {
+			types := []string{}
+			for _, rs := range z.RecordSets {
+				if strings.EqualFold(rs.Name, parts[1]) {
+					types = append(types, rs.Type)
+				}
+			}
+			if len(types) == 0 {
+				return mockProblem(http.StatusNotFound, "Not Found", "name %s does not exist in zone %s", parts[1], name)
+			}
+			return mockOK(http.StatusOK, dns.GetZoneNameTypesResponse{Types: types})
+		}
+	case parts[0] == "names" && len(parts) == 4 && parts[2] == "types":
+		return m.routeRecord(r, z, parts[1], strings.ToUpper(parts[3]))
+	case parts[0] == "key" && len(parts) == 1:
+		return m.routeZoneKey(r, z)
+	default:
+		return mockProblem(http.StatusNotFound, "Not Found", "no such endpoint: %s", r.URL.Path)
+	}
+	return mockMethodNotAllowed()
+}
+
+// Zone names are case-insensitive and may be given with a trailing dot
+func mockZoneName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func mockZoneNotFound(name string) mockResponse {
+	return mockProblem(http.StatusNotFound, "Not Found", "zone %s does not exist", name)
+}
+
+func decodeMockBody(r *http.Request, v interface{}) *mockResponse {
+	data, err := io.ReadAll(io.LimitReader(r.Body, mockMaxBodySize))
+	if err == nil {
+		err = json.Unmarshal(data, v)
+	}
+	if err != nil {
+		resp := mockProblem(http.StatusBadRequest, "Bad Request", "invalid request body: %v", err)
+		return &resp
+	}
+	return nil
+}
+
+func (m *mockAPI) nextID() string {
+	m.state.Sequence++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012x", m.state.Sequence)
+}
+
+// Record a change to a zone's configuration or recordsets
+func (m *mockAPI) touch(z *mockZone) {
+	z.Config.VersionID = m.nextID()
+	z.Config.LastModifiedDate = time.Now().UTC().Format(time.RFC3339)
+	z.Config.LastModifiedBy = mockModifiedBy
+}
+
+// Zones
+
+func (m *mockAPI) listZones(r *http.Request) mockResponse {
+	q := r.URL.Query()
+	search := strings.ToLower(q.Get("search"))
+	types := mockCSVSet(q.Get("types"), strings.ToUpper)
+	contracts := mockCSVSet(q.Get("contractIds"), nil)
+
+	zones := []dns.ZoneResponse{}
+	for _, z := range m.state.Zones {
+		if search != "" && !strings.Contains(z.Config.Zone, search) {
+			continue
+		}
+		if len(types) > 0 && !types[strings.ToUpper(z.Config.Type)] {
+			continue
+		}
+		if len(contracts) > 0 && !contracts[z.Config.ContractID] {
+			continue
+		}
+		zones = append(zones, z.Config)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Zone < zones[j].Zone })
+
+	page, pageSize, showAll := mockPaging(q)
+	start, end, _ := mockPageBounds(len(zones), page, pageSize, showAll)
+	contractIDs := []string{}
+	for id := range contracts {
+		contractIDs = append(contractIDs, id)
+	}
+	sort.Strings(contractIDs)
+	return mockOK(http.StatusOK, dns.ZoneListResponse{
+		Metadata: &dns.ListMetadata{
+			ContractIDs:   contractIDs,
+			Page:          page,
+			PageSize:      pageSize,
+			ShowAll:       showAll,
+			TotalElements: len(zones),
+		},
+		Zones: zones[start:end],
+	})
+}
+
+func (m *mockAPI) createZone(r *http.Request) mockResponse {
+	var zc dns.ZoneCreate
+	if resp := decodeMockBody(r, &zc); resp != nil {
+		return *resp
+	}
+	contract := r.URL.Query().Get("contractId")
+	if contract == "" {
+		return mockProblem(http.StatusBadRequest, "Bad Request", "contractId is required")
+	}
+	z, resp := m.addZone(&zc, contract)
+	if z == nil {
+		return resp
+	}
+	return mockOK(http.StatusCreated, z.Config)
+}
+
+// Add a zone, returning nil and the error response when it is rejected
+func (m *mockAPI) addZone(zc *dns.ZoneCreate, contract string) (*mockZone, mockResponse) {
+	name := mockZoneName(zc.Zone)
+	if name == "" {
+		return nil, mockProblem(http.StatusBadRequest, "Bad Request", "zone is required")
+	}
+	if _, ok := m.state.Zones[name]; ok {
+		return nil, mockProblem(http.StatusConflict, "Conflict", "zone %s already exists", name)
+	}
+	if resp := validateMockZone(zc); resp != nil {
+		return nil, *resp
+	}
+
+	z := &mockZone{Config: dns.ZoneResponse{
+		Zone:            name,
+		ContractID:      contract,
+		ActivationState: "NEW",
+	}}
+	applyMockZoneConfig(&z.Config, zc)
+	m.touch(z)
+	m.state.Zones[name] = z
+	return z, mockResponse{}
+}
+
+func validateMockZone(zc *dns.ZoneCreate) *mockResponse {
+	var resp mockResponse
+	switch strings.ToUpper(zc.Type) {
+	case "PRIMARY":
+		return nil
+	case "SECONDARY":
+		if len(zc.Masters) > 0 {
+			return nil
+		}
+		resp = mockProblem(http.StatusBadRequest, "Bad Request", "masters are required for a SECONDARY zone")
+	case "ALIAS":
+		if zc.Target != "" {
+			return nil
+		}
+		resp = mockProblem(http.StatusBadRequest, "Bad Request", "target is required for an ALIAS zone")
+	default:
+		resp = mockProblem(http.StatusBadRequest, "Bad Request", "invalid zone type %q", zc.Type)
+	}
+	return &resp
+}
+
+func applyMockZoneConfig(z *dns.ZoneResponse, zc *dns.ZoneCreate) {
+	z.Type = strings.ToUpper(zc.Type)
+	z.Masters = zc.Masters
+	z.Comment = zc.Comment
+	z.SignAndServe = zc.SignAndServe
+	z.SignAndServeAlgorithm = zc.SignAndServeAlgorithm
+	z.TSIGKey = zc.TSIGKey
+	z.Target = zc.Target
+	z.EndCustomerID = zc.EndCustomerID
+	z.OutboundZoneTransfer = zc.OutboundZoneTransfer
+}
+
+func (m *mockAPI) updateZone(r *http.Request, z *mockZone) mockResponse {
+	var zc dns.ZoneCreate
+	if resp := decodeMockBody(r, &zc); resp != nil {
+		return *resp
+	}
+	if !strings.EqualFold(zc.Type, z.Config.Type) {
+		return mockProblem(http.StatusBadRequest, "Bad Request", "zone type cannot be changed from %s", z.Config.Type)
+	}
+	if resp := validateMockZone(&zc); resp != nil {
+		return *resp
+	}
+	applyMockZoneConfig(&z.Config, &zc)
+	m.touch(z)
+	return mockOK(http.StatusOK, z.Config)
+}
+
+// Recordsets
+
+func mockRecordSetList(r *http.Request, recordsets []dns.RecordSet) mockResponse {
+	q := r.URL.Query()
+	search := strings.ToLower(q.Get("search"))
+	types := mockCSVSet(q.Get("types"), strings.ToUpper)
+
+	matched := []dns.RecordSet{}
+	for _, rs := range recordsets {
+		if search != "" && !strings.Contains(strings.ToLower(rs.Name), search) {
+			continue
+		}
+		if len(types) > 0 && !types[rs.Type] {
+			continue
+		}
+		matched = append(matched, rs)
+	}
+	sortRecordSets(matched)
+
+	page, pageSize, showAll := mockPaging(q)
+	start, end, lastPage := mockPageBounds(len(matched), page, pageSize, showAll)
+	return mockOK(http.StatusOK, dns.GetRecordSetsResponse{
+		Metadata: dns.Metadata{
+			LastPage:      lastPage,
+			Page:          page,
+			PageSize:      pageSize,
+			ShowAll:       showAll,
+			TotalElements: len(matched),
+		},
+		RecordSets: matched[start:end],
+	})
+}
+
+func sortRecordSets(recordsets []dns.RecordSet) {
+	sort.SliceStable(recordsets, func(i, j int) bool {
+		if recordsets[i].Name != recordsets[j].Name {
+			return recordsets[i].Name < recordsets[j].Name
+		}
+		return recordsets[i].Type < recordsets[j].Type
+	})
+}
+
+// Check a recordset belongs to the zone and is complete, normalizing its name and type
+func validateMockRecordSet(zone string, rs *dns.RecordSet) *mockResponse {
+	rs.Name = strings.ToLower(strings.TrimSuffix(rs.Name, "."))
+	rs.Type = strings.ToUpper(rs.Type)
+	var resp mockResponse
+	switch {
+	case rs.Name == "" || rs.Type == "":
+		resp = mockProblem(http.StatusBadRequest, "Bad Request", "recordset name and type are required")
+	case rs.Name != zone && !strings.HasSuffix(rs.Name, "."+zone):
+		resp = mockProblem(http.StatusBadRequest, "Bad Request", "recordset %s is not in zone %s", rs.Name, zone)
+	case rs.TTL <= 0:
+		resp = mockProblem(http.StatusBadRequest, "Bad Request", "recordset %s %s has no TTL", rs.Name, rs.Type)
+	case len(rs.Rdata) == 0:
+		resp = mockProblem(http.StatusBadRequest, "Bad Request", "recordset %s %s has no rdata", rs.Name, rs.Type)
+	default:
+		return nil
+	}
+	return &resp
+}
+
+func mockRecordSetIndex(recordsets []dns.RecordSet, name, rtype string) int {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	for i, rs := range recordsets {
+		if rs.Name == name && rs.Type == rtype {
+			return i
+		}
+	}
+	return -1
+}
+
+func requireMockPrimary(z *mockZone) *mockResponse {
+	if z.Config.Type == "PRIMARY" {
+		return nil
+	}
+	resp := mockProblem(http.StatusBadRequest, "Bad Request", "recordsets cannot be changed in %s zone %s", z.Config.Type, z.Config.Zone)
+	return &resp
+}
+
+func (m *mockAPI) decodeRecordSets(r *http.Request, z *mockZone) ([]dns.RecordSet, *mockResponse) {
+	if resp := requireMockPrimary(z); resp != nil {
+		return nil, resp
+	}
+	var body dns.RecordSets
+	if resp := decodeMockBody(r, &body); resp != nil {
+		return nil, resp
+	}
+	seen := map[string]bool{}
+	for i := range body.RecordSets {
+		if resp := validateMockRecordSet(z.Config.Zone, &body.RecordSets[i]); resp != nil {
+			return nil, resp
+		}
+		key := body.RecordSets[i].Name + " " + body.RecordSets[i].Type
+		if seen[key] {
+			resp := mockProblem(http.StatusBadRequest, "Bad Request", "duplicate recordset %s", key)
+			return nil, &resp
+		}
+		seen[key] = true
+	}
+	return body.RecordSets, nil
+}
+
+func (m *mockAPI) createRecordSets(r *http.Request, z *mockZone) mockResponse {
+	recordsets, resp := m.decodeRecordSets(r, z)
+	if resp != nil {
+		return *resp
+	}
+	for _, rs := range recordsets {
+		if mockRecordSetIndex(z.RecordSets, rs.Name, rs.Type) >= 0 {
+			return mockProblem(http.StatusConflict, "Conflict", "recordset %s %s already exists", rs.Name, rs.Type)
+		}
+	}
+	z.RecordSets = append(z.RecordSets, recordsets...)
+	m.recordSetsChanged(z)
+	return mockOK(http.StatusNoContent, nil)
+}
+
+func (m *mockAPI) replaceRecordSets(r *http.Request, z *mockZone) mockResponse {
+	recordsets, resp := m.decodeRecordSets(r, z)
+	if resp != nil {
+		return *resp
+	}
+	z.RecordSets = recordsets
+	m.recordSetsChanged(z)
+	return mockOK(http.StatusNoContent, nil)
+}
+
+func (m *mockAPI) recordSetsChanged(z *mockZone) {
+	sortRecordSets(z.RecordSets)
+	z.Config.ActivationState = "ACTIVE"
+	m.touch(z)
+}
+
+func (m *mockAPI) postZoneFile(r *http.Request, z *mockZone) mockResponse {
+	if resp := requireMockPrimary(z); resp != nil {
+		return *resp
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, mockMaxBodySize))
+	if err != nil {
+		return mockProblem(http.StatusBadRequest, "Bad Request", "invalid request body: %v", err)
+	}
+	// The parser would read $INCLUDE files from the server's disk
+	if strings.Contains(strings.ToUpper(string(data)), "$INCLUDE") {
+		return mockProblem(http.StatusBadRequest, "Bad Request", "$INCLUDE is not supported")
+	}
+	recordsets, err := parseMasterFile(data, z.Config.Zone, "")
+	if err != nil {
+		return mockProblem(http.StatusBadRequest, "Bad Request", "invalid master file: %v", err)
+	}
+	for i := range recordsets {
+		if resp := validateMockRecordSet(z.Config.Zone, &recordsets[i]); resp != nil {
+			return *resp
+		}
+	}
+	z.RecordSets = recordsets
+	m.recordSetsChanged(z)
+	return mockOK(http.StatusNoContent, nil)
+}
+
+// Single recordset by name and type
+func (m *mockAPI) routeRecord(r *http.Request, z *mockZone, name, rtype string) mockResponse {
+	idx := mockRecordSetIndex(z.RecordSets, name, rtype)
+	if r.Method == http.MethodGet {
+		if idx < 0 {
+			return mockProblem(http.StatusNotFound, "Not Found", "recordset %s %s does not exist", name, rtype)
+		}
+		rs := z.RecordSets[idx]
+		return mockOK(http.StatusOK, dns.GetRecordResponse{Name: rs.Name, RecordType: rs.Type, TTL: rs.TTL, Active: true, Target: rs.Rdata})
+	}
+
+	if resp := requireMockPrimary(z); resp != nil {
+		return *resp
+	}
+	switch r.Method {
+	case http.MethodDelete:
+		if idx < 0 {
+			return mockProblem(http.StatusNotFound, "Not Found", "recordset %s %s does not exist", name, rtype)
+		}
+		z.RecordSets = append(z.RecordSets[:idx], z.RecordSets[idx+1:]...)
+		m.recordSetsChanged(z)
+		return mockOK(http.StatusNoContent, nil)
+
+	case http.MethodPost, http.MethodPut:
+		var body dns.RecordBody
+		if resp := decodeMockBody(r, &body); resp != nil {
+			return *resp
+		}
+		rs := dns.RecordSet{Name: name, Type: rtype, TTL: body.TTL, Rdata: body.Target}
+		if resp := validateMockRecordSet(z.Config.Zone, &rs); resp != nil {
+			return *resp
+		}
+		if r.Method == http.MethodPost {
+			if idx >= 0 {
+				return mockProblem(http.StatusConflict, "Conflict", "recordset %s %s already exists", rs.Name, rs.Type)
+			}
+			z.RecordSets = append(z.RecordSets, rs)
+			m.recordSetsChanged(z)
+			return mockOK(http.StatusCreated, body)
+		}
+		if idx < 0 {
+			return mockProblem(http.StatusNotFound, "Not Found", "recordset %s %s does not exist", rs.Name, rs.Type)
+		}
+		z.RecordSets[idx] = rs
+		m.recordSetsChanged(z)
+		return mockOK(http.StatusOK, body)
+	}
+	return mockMethodNotAllowed()
+}
+
+// Change lists
+
+func (m *mockAPI) routeChangeList(r *http.Request, parts []string) mockResponse {
+	if len(parts) == 0 {
+		if r.Method != http.MethodPost {
+			return mockMethodNotAllowed()
+		}
+		return m.createChangeList(mockZoneName(r.URL.Query().Get("zone")))
+	}
+
+	name := mockZoneName(parts[0])
+	z, ok := m.state.Zones[name]
+	if !ok {
+		return mockZoneNotFound(name)
+	}
+	cl := z.ChangeList
+	if cl == nil {
+		return mockProblem(http.StatusNotFound, "Not Found", "no change list exists for zone %s", name)
+	}
+	cl.Stale = cl.ZoneVersionID != z.Config.VersionID
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		return mockOK(http.StatusOK, cl.GetChangeListResponse)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		z.ChangeList = nil
+		return mockOK(http.StatusNoContent, nil)
+	case len(parts) == 2 && parts[1] == "recordsets" && r.Method == http.MethodGet:
+		return mockRecordSetList(r, cl.RecordSets)
+	case len(parts) == 3 && parts[1] == "recordsets" && parts[2] == "add-change" && r.Method == http.MethodPost:
+		return m.addChange(r, z)
+	case len(parts) == 2 && parts[1] == "submit" && r.Method == http.MethodPost:
+		if cl.Stale {
+			return mockProblem(http.StatusConflict, "Conflict", "change list for zone %s is stale", name)
+		}
+		z.RecordSets = cl.RecordSets
+		z.ChangeList = nil
+		m.recordSetsChanged(z)
+		return mockOK(http.StatusNoContent, nil)
+	}
+	return mockProblem(http.StatusNotFound, "Not Found", "no such endpoint: %s", r.URL.Path)
+}
+
+// Start a change list from the zone's recordsets, or from default SOA and NS
+// records for a zone that has none
+func (m *mockAPI) createChangeList(name string) mockResponse {
+	z, ok := m.state.Zones[name]
+	if !ok {
+		return mockZoneNotFound(name)
+	}
+	if resp := requireMockPrimary(z); resp != nil {
+		return *resp
+	}
+	if z.ChangeList != nil {
+		return mockProblem(http.StatusConflict, "Conflict", "a change list already exists for zone %s", name)
+	}
+
+	recordsets := append([]dns.RecordSet(nil), z.RecordSets...)
+	if len(recordsets) == 0 {
+		recordsets = []dns.RecordSet{
+			{Name: name, Type: "NS", TTL: mockDefaultTTL, Rdata: append([]string(nil), mockNameServers...)},
+			{Name: name, Type: "SOA", TTL: mockDefaultTTL, Rdata: []string{
+				fmt.Sprintf("%s hostmaster.%s. 1 3600 600 604800 300", mockNameServers[0], name),
+			}},
+		}
+	}
+	z.ChangeList = &mockChangeList{
+		GetChangeListResponse: dns.GetChangeListResponse{
+			Zone:             name,
+			ChangeTag:        m.nextID(),
+			ZoneVersionID:    z.Config.VersionID,
+			LastModifiedDate: time.Now().UTC().Format(time.RFC3339),
+		},
+		RecordSets: recordsets,
+	}
+	return mockOK(http.StatusCreated, z.ChangeList.GetChangeListResponse)
+}
+
+func (m *mockAPI) addChange(r *http.Request, z *mockZone) mockResponse {
+	var change ChangeListChange
+	if resp := decodeMockBody(r, &change); resp != nil {
+		return *resp
+	}
+	cl := z.ChangeList
+	rs := dns.RecordSet{Name: change.Name, Type: change.Type, TTL: change.TTL, Rdata: change.Rdata}
+	op := strings.ToUpper(change.Op)
+	if op == changeListOpDelete {
+		rs.TTL, rs.Rdata = 1, []string{"-"}
+	}
+	if resp := validateMockRecordSet(z.Config.Zone, &rs); resp != nil {
+		return *resp
+	}
+
+	idx := mockRecordSetIndex(cl.RecordSets, rs.Name, rs.Type)
+	switch op {
+	case changeListOpAdd:
+		if idx >= 0 {
+			return mockProblem(http.StatusConflict, "Conflict", "recordset %s %s already exists", rs.Name, rs.Type)
+		}
+		cl.RecordSets = append(cl.RecordSets, rs)
+		sortRecordSets(cl.RecordSets)
+	case changeListOpEdit, changeListOpDelete:
+		if idx < 0 {
+			return mockProblem(http.StatusNotFound, "Not Found", "recordset %s %s does not exist", rs.Name, rs.Type)
+		}
+		if op == changeListOpEdit {
+			cl.RecordSets[idx] = rs
+		} else {
+			cl.RecordSets = append(cl.RecordSets[:idx], cl.RecordSets[idx+1:]...)
+		}
+	default:
+		return mockProblem(http.StatusBadRequest, "Bad Request", "invalid change op %q", change.Op)
+	}
+	cl.LastModifiedDate = time.Now().UTC().Format(time.RFC3339)
+	return mockOK(http.StatusNoContent, nil)
+}
+
+// Bulk zone requests complete as soon as they are submitted
+
+func (m *mockAPI) routeBulk(r *http.Request, kind string, parts []string) mockResponse {
+	requests := m.state.CreateRequests
+	if kind == "delete-requests" {
+		requests = m.state.DeleteRequests
+	}
+	if len(parts) == 0 {
+		if r.Method != http.MethodPost {
+			return mockMethodNotAllowed()
+		}
+		if kind == "create-requests" {
+			return m.bulkCreate(r)
+		}
+		return m.bulkDelete(r)
+	}
+
+	req, ok := requests[parts[0]]
+	if !ok {
+		return mockProblem(http.StatusNotFound, "Not Found", "request %s does not exist", parts[0])
+	}
+	if r.Method != http.MethodGet {
+		return mockMethodNotAllowed()
+	}
+	switch {
+	case len(parts) == 1:
+		return mockOK(http.StatusOK, req.Status)
+	case len(parts) == 2 && parts[1] == "result" && kind == "create-requests":
+		return mockOK(http.StatusOK, dns.BulkCreateResultResponse{
+			RequestID:                req.Status.RequestID,
+			SuccessfullyCreatedZones: req.Succeeded,
+			FailedZones:              req.Failed,
+		})
+	case len(parts) == 2 && parts[1] == "result":
+		return mockOK(http.StatusOK, dns.BulkDeleteResultResponse{
+			RequestID:                req.Status.RequestID,
+			SuccessfullyDeletedZones: req.Succeeded,
+			FailedZones:              req.Failed,
+		})
+	}
+	return mockProblem(http.StatusNotFound, "Not Found", "no such endpoint: %s", r.URL.Path)
+}
+
+func (m *mockAPI) newBulkRequest(requests map[string]*mockBulkRequest, submitted int) *mockBulkRequest {
+	req := &mockBulkRequest{
+		Status: dns.BulkStatusResponse{
+			RequestID:      m.nextID(),
+			ZonesSubmitted: submitted,
+			IsComplete:     true,
+			ExpirationDate: time.Now().UTC().Add(24 * time.Hour).Format(time.RFC3339),
+		},
+		Succeeded: []string{},
+		Failed:    []dns.BulkFailedZone{},
+	}
+	requests[req.Status.RequestID] = req
+	return req
+}
+
+func (req *mockBulkRequest) result(zone string, resp mockResponse) {
+	if e, ok := resp.body.(*dns.Error); ok {
+		req.Failed = append(req.Failed, dns.BulkFailedZone{Zone: zone, FailureReason: e.Detail})
+		req.Status.FailureCount++
+		return
+	}
+	req.Succeeded = append(req.Succeeded, zone)
+	req.Status.SuccessCount++
+}
+
+func (req *mockBulkRequest) response() mockResponse {
+	return mockOK(http.StatusCreated, dns.BulkZonesResponse{
+		RequestID:      req.Status.RequestID,
+		ExpirationDate: req.Status.ExpirationDate,
+	})
+}
+
+func (m *mockAPI) bulkCreate(r *http.Request) mockResponse {
+	var body dns.BulkZonesCreate
+	if resp := decodeMockBody(r, &body); resp != nil {
+		return *resp
+	}
+	contract := r.URL.Query().Get("contractId")
+	if contract == "" {
+		return mockProblem(http.StatusBadRequest, "Bad Request", "contractId is required")
+	}
+	req := m.newBulkRequest(m.state.CreateRequests, len(body.Zones))
+	for i := range body.Zones {
+		_, resp := m.addZone(&body.Zones[i], contract)
+		req.result(mockZoneName(body.Zones[i].Zone), resp)
+	}
+	return req.response()
+}
+
+func (m *mockAPI) bulkDelete(r *http.Request) mockResponse {
+	var body dns.ZoneNameListResponse
+	if resp := decodeMockBody(r, &body); resp != nil {
+		return *resp
+	}
+	req := m.newBulkRequest(m.state.DeleteRequests, len(body.Zones))
+	for _, zone := range body.Zones {
+		name := mockZoneName(zone)
+		if _, ok := m.state.Zones[name]; !ok {
+			req.result(name, mockZoneNotFound(name))
+			continue
+		}
+		delete(m.state.Zones, name)
+		req.result(name, mockResponse{})
+	}
+	return req.response()
+}
+
+// TSIG keys
+
+func (m *mockAPI) routeZoneKey(r *http.Request, z *mockZone) mockResponse {
+	switch r.Method {
+	case http.MethodGet:
+		if z.Config.TSIGKey == nil {
+			return mockProblem(http.StatusNotFound, "Not Found", "zone %s has no TSIG key", z.Config.Zone)
+		}
+		return mockOK(http.StatusOK, dns.TSIGKeyResponse{TSIGKey: *z.Config.TSIGKey, ZoneCount: int64(len(m.keyZones(*z.Config.TSIGKey)))})
+	case http.MethodPut:
+		var key dns.TSIGKey
+		if resp := decodeMockBody(r, &key); resp != nil {
+			return *resp
+		}
+		if resp := validateMockKey(&key); resp != nil {
+			return *resp
+		}
+		z.Config.TSIGKey = &key
+		m.touch(z)
+		return mockOK(http.StatusNoContent, nil)
+	case http.MethodDelete:
+		if z.Config.TSIGKey == nil {
+			return mockProblem(http.StatusNotFound, "Not Found", "zone %s has no TSIG key", z.Config.Zone)
+		}
+		z.Config.TSIGKey = nil
+		m.touch(z)
+		return mockOK(http.StatusNoContent, nil)
+	}
+	return mockMethodNotAllowed()
+}
+
+func (m *mockAPI) routeKeys(r *http.Request, parts []string) mockResponse {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		return m.listKeys(r)
+	case len(parts) == 1 && parts[0] == "used-by" && r.Method == http.MethodPost:
+		var key dns.TSIGKey
+		if resp := decodeMockBody(r, &key); resp != nil {
+			return *resp
+		}
+		return mockOK(http.StatusOK, dns.GetTSIGKeyZonesResponse{Zones: m.keyZones(key), Aliases: []string{}})
+	case len(parts) == 1 && parts[0] == "bulk-update" && r.Method == http.MethodPost:
+		var body dns.TSIGKeyBulkPost
+		if resp := decodeMockBody(r, &body); resp != nil {
+			return *resp
+		}
+		if body.Key == nil {
+			return mockProblem(http.StatusBadRequest, "Bad Request", "key is required")
+		}
+		if resp := validateMockKey(body.Key); resp != nil {
+			return *resp
+		}
+		zones := make([]*mockZone, 0, len(body.Zones))
+		for _, zone := range body.Zones {
+			z, ok := m.state.Zones[mockZoneName(zone)]
+			if !ok {
+				return mockZoneNotFound(mockZoneName(zone))
+			}
+			zones = append(zones, z)
+		}
+		for _, z := range zones {
+			key := *body.Key
+			z.Config.TSIGKey = &key
+			m.touch(z)
+		}
+		return mockOK(http.StatusNoContent, nil)
+	}
+	return mockProblem(http.StatusNotFound, "Not Found", "no such endpoint: %s", r.URL.Path)
+}
+
+func validateMockKey(key *dns.TSIGKey) *mockResponse {
+	if key.Name != "" && key.Algorithm != "" && key.Secret != "" {
+		return nil
+	}
+	resp := mockProblem(http.StatusBadRequest, "Bad Request", "TSIG key name, algorithm and secret are required")
+	return &resp
+}
+
+// Zones using a key, sorted
+func (m *mockAPI) keyZones(key dns.TSIGKey) []string {
+	zones := []string{}
+	for name, z := range m.state.Zones {
+		if k := z.Config.TSIGKey; k != nil && k.Name == key.Name && strings.EqualFold(k.Algorithm, key.Algorithm) && k.Secret == key.Secret {
+			zones = append(zones, name)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+func (m *mockAPI) listKeys(r *http.Request) mockResponse {
+	q := r.URL.Query()
+	search := strings.ToLower(q.Get("search"))
+	contracts := mockCSVSet(q.Get("contractIds"), nil)
+
+	counts := map[dns.TSIGKey]int64{}
+	for _, z := range m.state.Zones {
+		k := z.Config.TSIGKey
+		if k == nil || (search != "" && !strings.Contains(strings.ToLower(k.Name), search)) {
+			continue
+		}
+		if len(contracts) > 0 && !contracts[z.Config.ContractID] {
+			continue
+		}
+		counts[*k]++
+	}
+	keys := make([]dns.TSIGKeyResponse, 0, len(counts))
+	for k, n := range counts {
+		keys = append(keys, dns.TSIGKeyResponse{TSIGKey: k, ZoneCount: n})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Name != keys[j].Name {
+			return keys[i].Name < keys[j].Name
+		}
+		return keys[i].Algorithm < keys[j].Algorithm
+	})
+	return mockOK(http.StatusOK, dns.TSIGReportResponse{
+		Metadata: &dns.TSIGReportMeta{TotalElements: int64(len(keys)), Search: q.Get("search")},
+		Keys:     keys,
+	})
+}
+
+// DNSSEC
+
+// Status of signed zones with a KSK and ZSK derived from the zone name, so the
+// same zone always reports the same keys
+func (m *mockAPI) dnssecStatus(r *http.Request) mockResponse {
+	var body dns.GetZonesDNSSecStatusRequest
+	if resp := decodeMockBody(r, &body); resp != nil {
+		return *resp
+	}
+	statuses := []dns.SecStatus{}
+	for _, zone := range body.Zones {
+		name := mockZoneName(zone)
+		z, ok := m.state.Zones[name]
+		if !ok {
+			return mockZoneNotFound(name)
+		}
+		if !z.Config.SignAndServe {
+			continue
+		}
+		modified, _ := time.Parse(time.RFC3339, z.Config.LastModifiedDate)
+		var dnskeys, ds []string
+		for _, flags := range []int{257, 256} {
+			sum := sha256.Sum256([]byte(name + "/" + strconv.Itoa(flags)))
+			key := append(sum[:], sum[:]...)
+			dnskeys = append(dnskeys, fmt.Sprintf("%s. %d IN DNSKEY %d 3 13 %s", name, mockDefaultTTL, flags, base64.StdEncoding.EncodeToString(key)))
+			if flags == 257 {
+				ds = append(ds, fmt.Sprintf("%s. %d IN DS %d 13 2 %s", name, mockDefaultTTL, dnskeyTag(flags, 3, 13, key), mockDSDigest(name, flags, key)))
+			}
+		}
+		statuses = append(statuses, dns.SecStatus{
+			Zone:   name,
+			Alerts: []string{},
+			CurrentRecords: dns.SecRecords{
+				DNSKeyRecord:     strings.Join(dnskeys, "\n"),
+				DSRecord:         strings.Join(ds, "\n"),
+				ExpectedTTL:      mockDefaultTTL,
+				LastModifiedDate: modified,
+			},
+		})
+	}
+	return mockOK(http.StatusOK, dns.GetZonesDNSSecStatusResponse{DNSSecStatuses: statuses})
+}
+
+// SHA-256 DS digest from RFC 4509: owner name in wire format followed by the DNSKEY rdata
+func mockDSDigest(zone string, flags int, key []byte) string {
+	var data []byte
+	for _, label := range strings.Split(zone, ".") {
+		data = append(data, byte(len(label)))
+		data = append(data, label...)
+	}
+	data = append(data, 0)
+	data = binary.BigEndian.AppendUint16(data, uint16(flags))
+	data = append(data, 3, 13)
+	data = append(data, key...)
+	sum := sha256.Sum256(data)
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// Query helpers
+
+// Comma separated query values as a set, optionally normalized
+func mockCSVSet(value string, normalize func(string) string) map[string]bool {
+	set := map[string]bool{}
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if normalize != nil {
+			v = normalize(v)
+		}
+		set[v] = true
+	}
+	return set
+}
+
+func mockPaging(q url.Values) (page, pageSize int, showAll bool) {
+	page, _ = strconv.Atoi(q.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ = strconv.Atoi(q.Get("pageSize"))
+	if pageSize < 1 {
+		pageSize = 25
+	}
+	showAll, _ = strconv.ParseBool(q.Get("showAll"))
+	return page, pageSize, showAll
+}
+
+// Slice bounds of a page and the number of the last page
+func mockPageBounds(total, page, pageSize int, showAll bool) (start, end, lastPage int) {
+	if showAll {
+		return 0, total, 1
+	}
+	lastPage = (total + pageSize - 1) / pageSize
+	if lastPage == 0 {
+		lastPage = 1
+	}
+	start = (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end = start + pageSize
+	if end > total {
+		end = total
+	}
+	return start, end, lastPage
+}
@@ -0,0 +1,280 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// Output rendered through the --template go template
+const formatTemplate = "template"
+
+// CommandOutput is a command result and the ways it can be rendered
+type CommandOutput struct {
+	// Value is written as JSON or YAML and is the data passed to --template
+	Value interface{}
+	// Table renders the human readable output. JSON is written when it is nil.
+	Table func() string
+	// CSV returns a header row followed by the data rows. CSV is refused when nil.
+	CSV func() [][]string
+	// Formats renders command specific formats, or replaces a standard one
+	Formats map[string]func() (string, error)
+}
+
+// Flags shared by every command that writes a result. Extra formats are the
+// command specific ones it supports. A new slice is returned on each call.
+func outputFlags(formats ...string) []cli.Flag {
+	names := append([]string{formatTable, formatJSON, formatYAML, formatCSV}, formats...)
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:   "json",
+			Usage:  "Output as JSON",
+			EnvVar: "AKAMAI_CLI_DNS_" + "JSON",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: fmt.Sprintf("Output `FORMAT`: %s", strings.Join(names, ", ")),
+		},
+		cli.StringFlag{
+			Name:  "template",
+			Usage: "Render the JSON result with the go `TEMPLATE`",
+		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "Write command results to `FILE` instead of the console",
+		},
+		cli.BoolFlag{
+			Name:   "suppress",
+			Usage:  "Suppress command result output",
+			EnvVar: "AKAMAI_CLI_DNS_" + "SUPPRESS",
+		},
+	}
+}
+
+// Resolve the format from --template, --format and --json, defaulting to table
+func outputFormat(c *cli.Context, out *CommandOutput) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(c.String("format")))
+	if c.String("template") != "" {
+		if format != "" && format != formatTemplate {
+			return "", newCommandError(exitValidation, "--template cannot be combined with --format %s", format)
+		}
+		return formatTemplate, nil
+	}
+	switch {
+	case format == "" && c.Bool("json"):
+		return formatJSON, nil
+	case format == "":
+		return defaultOutputFormat(out), nil
+	}
+
+	valid := outputFormatNames(out)
+	for _, name := range valid {
+		if name == format {
+			return format, nil
+		}
+	}
+	return "", newCommandError(exitValidation, "Invalid format %q. Valid formats: %s", format, strings.Join(valid, ", "))
+}
+
+// Output format of the active profile when the command supports it, else table
+func defaultOutputFormat(out *CommandOutput) string {
+	format := profileFormat()
+	if format == "" || format == formatTemplate {
+		return formatTable
+	}
+	for _, name := range outputFormatNames(out) {
+		if name == format {
+			return format
+		}
+	}
+	return formatTable
+}
+
+// Formats a command output supports, for error text
+func outputFormatNames(out *CommandOutput) []string {
+	names := []string{formatTable, formatJSON, formatYAML}
+	if out.CSV != nil {
+		names = append(names, formatCSV)
+	}
+	extra := []string{}
+	for name := range out.Formats {
+		if name != formatTable && name != formatJSON && name != formatYAML && name != formatCSV {
+			extra = append(extra, name)
+		}
+	}
+	if _, ok := out.Formats[formatCSV]; ok && out.CSV == nil {
+		extra = append(extra, formatCSV)
+	}
+	sort.Strings(extra)
+	return append(append(names, extra...), formatTemplate)
+}
+
+// Render a command output in the format selected by the output flags
+func renderOutput(c *cli.Context, out *CommandOutput) (string, error) {
+	format, err := outputFormat(c, out)
+	if err != nil {
+		return "", err
+	}
+	if render, ok := out.Formats[format]; ok {
+		return render()
+	}
+
+	switch format {
+	case formatTable:
+		if out.Table != nil {
+			return out.Table(), nil
+		}
+	case formatYAML:
+		return marshalYAML(out.Value)
+	case formatCSV:
+		return renderCSVRows(out.CSV())
+	case formatTemplate:
+		return renderTemplate(c.String("template"), out.Value)
+	}
+	b, err := json.MarshalIndent(out.Value, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("Unable to marshal JSON output: %w", err)
+	}
+	return string(b), nil
+}
+
+func renderCSVRows(rows [][]string) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if err := w.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// Execute a go template against the JSON form of the value, so templates use
+// the same field names as JSON output
+func renderTemplate(text string, value interface{}) (string, error) {
+	tmpl, err := template.New("output").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		"join":  strings.Join,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}).Parse(text)
+	if err != nil {
+		return "", newCommandError(exitValidation, "invalid --template: %v", err)
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("Unable to marshal template data: %w", err)
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return "", fmt.Errorf("Unable to marshal template data: %w", err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("template failed: %w", err)
+	}
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+// Render and write a command result. See writeOutputText.
+func writeOutput(c *cli.Context, out *CommandOutput) error {
+	if c.Bool("suppress") {
+		return nil
+	}
+	text, err := renderOutput(c, out)
+	if err != nil {
+		return wrapError(err)
+	}
+	return writeOutputText(c, text)
+}
+
+// Write rendered output to the --output file or to the console, never both.
+// Nothing is written with --suppress. Status messages go to STDERR.
+func writeOutputText(c *cli.Context, text string) error {
+	if c.Bool("suppress") {
+		return nil
+	}
+	if c.String("output") != "" {
+		outputPath := filepath.FromSlash(c.String("output"))
+		if err := writeFileAtomic(outputPath, []byte(strings.TrimRight(text, "\n")+"\n"), 0644); err != nil {
+			return apiError(err, "Failed to write output file: %v", err)
+		}
+		fmt.Fprintln(os.Stderr, color.GreenString("Output written to %s", outputPath))
+		return nil
+	}
+	fmt.Fprintln(c.App.Writer, text)
+	return nil
+}
+
+// Write a file through a temporary file in the same directory, so readers never
+// see a partly written file and a failed write leaves the old file in place
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Command output for a single recordset, with the zone export formats available
+func recordsetOutput(zonename string, record *dns.GetRecordResponse) *CommandOutput {
+	rs := dns.RecordSet{
+		Name:  record.Name,
+		Type:  record.RecordType,
+		TTL:   record.TTL,
+		Rdata: record.Target,
+	}
+	out := zoneExportOutput(&ZoneExport{Name: zonename, RecordSets: []dns.RecordSet{rs}})
+	out.Value = rs
+	out.Table = func() string { return renderRecordsetTable(zonename, record) }
+	return out
+}
+
+// Command output for a zone's recordsets. JSON keeps the recordset list shape.
+func recordsetListOutput(zonename string, zone *dns.GetZoneResponse, recordsets []dns.RecordSet) *CommandOutput {
+	out := zoneExportOutput(&ZoneExport{Name: zonename, Zone: zone, RecordSets: recordsets})
+	out.Value = RecordsetList{Recordsets: recordsets}
+	return out
+}
@@ -4,12 +4,21 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
 )
 
+// Mask a TSIG secret unless --show-secrets is set
+func tsigSecret(secret string, c *cli.Context) string {
+	if secret == "" || c.GlobalBool("show-secrets") {
+		return secret
+	}
+	return "********"
+}
+
 // Recordset Table format
 func renderRecordsetTable(zone string, record *dns.GetRecordResponse) string {
 	return fmt.Sprintf(`
@@ -108,7 +117,7 @@ func renderZoneconfigTable(zone *dns.GetZoneResponse, c *cli.Context) string {
 					table.Append([]string{" ", "TsigKey:Algorithm", zone.TSIGKey.Algorithm})
 				}
 				if len(zone.TSIGKey.Secret) > 0 {
-					table.Append([]string{" ", "TsigKey:Secret", zone.TSIGKey.Secret})
+					table.Append([]string{" ", "TsigKey:Secret", tsigSecret(zone.TSIGKey.Secret, c)})
 				}
 			}
 		}
@@ -138,7 +147,7 @@ func renderZoneconfigTable(zone *dns.GetZoneResponse, c *cli.Context) string {
 }
 
 // Zone list table format
-func renderZoneListTable(zones []dns.ZoneResponse) string {
+func renderZoneListTable(zones []dns.ZoneResponse, c *cli.Context) string {
 	outString := ""
 	outString += fmt.Sprintln(" ")
 	outString += fmt.Sprintln("Zone List")
@@ -174,7 +183,7 @@ func renderZoneListTable(zones []dns.ZoneResponse) string {
 				if zone.TSIGKey != nil {
 					table.Append([]string{" ", "TsigKey:Name", zone.TSIGKey.Name})
 					table.Append([]string{" ", "TsigKey:Algorithm", zone.TSIGKey.Algorithm})
-					table.Append([]string{" ", "TsigKey:Secret", zone.TSIGKey.Secret})
+					table.Append([]string{" ", "TsigKey:Secret", tsigSecret(zone.TSIGKey.Secret, c)})
 				}
 			}
 			if strings.ToUpper(ztype) == "PRIMARY" || strings.ToUpper(ztype) == "SECONDARY" {
@@ -225,7 +234,8 @@ func renderZoneSummaryListTable(zones []dns.ZoneResponse) string {
 }
 
 // Zone table format
-func renderZoneTable(zone *dns.GetZoneResponse, records []dns.RecordSet, c *cli.Context) {
+func renderZoneTable(zone *dns.GetZoneResponse, records []dns.RecordSet, c *cli.Context) string {
+	var out strings.Builder
 	tableString := &strings.Builder{}
 	table := tablewriter.NewWriter(tableString)
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
@@ -249,16 +259,16 @@ func renderZoneTable(zone *dns.GetZoneResponse, records []dns.RecordSet, c *cli.
 	if zone.TSIGKey != nil {
 		table.Append([]string{"TSIG Name", zone.TSIGKey.Name})
 		table.Append([]string{"TSIG Algorithm", zone.TSIGKey.Algorithm})
-		table.Append([]string{"TSIG Secret", zone.TSIGKey.Secret})
+		table.Append([]string{"TSIG Secret", tsigSecret(zone.TSIGKey.Secret, c)})
 	}
 
 	table.Render()
-	fmt.Fprintln(c.App.Writer, tableString.String())
+	fmt.Fprintln(&out, tableString.String())
 
 	if len(records) > 0 {
-		fmt.Fprintln(c.App.Writer, "")
-		fmt.Fprintln(c.App.Writer, "DNS Records: ")
-		fmt.Fprintln(c.App.Writer, "")
+		fmt.Fprintln(&out, "")
+		fmt.Fprintln(&out, "DNS Records: ")
+		fmt.Fprintln(&out, "")
 
 		recordsTableString := &strings.Builder{}
 		recordsTable := tablewriter.NewWriter(recordsTableString)
@@ -274,8 +284,9 @@ func renderZoneTable(zone *dns.GetZoneResponse, records []dns.RecordSet, c *cli.
 			}
 		}
 		recordsTable.Render()
-		fmt.Fprintln(c.App.Writer, recordsTableString.String())
+		fmt.Fprintln(&out, recordsTableString.String())
 	}
+	return out.String()
 }
 
 // Bulk zone request status format
@@ -403,7 +414,7 @@ func renderBulkZonesResultTable(resultRespList interface{}, c *cli.Context) stri
 		failzones = delreq.FailedZones
 		op = "Deleted"
 		table.Append([]string{"Request Id", requestid, "", ""})
-		table.Append([]string{fmt.Sprintf("", "Successfully %s Zones", op), "", ""})
+		table.Append([]string{"", fmt.Sprintf("Successfully %s Zones", op), "", ""})
 		if len(succzones) == 0 {
 			table.Append([]string{"", "", "None", ""})
 		} else {
@@ -411,7 +422,7 @@ func renderBulkZonesResultTable(resultRespList interface{}, c *cli.Context) stri
 				table.Append([]string{"", "", zn, ""})
 			}
 		}
-		table.Append([]string{fmt.Sprintf("", "Failed %s Zones", op), "", ""})
+		table.Append([]string{"", fmt.Sprintf("Failed %s Zones", op), "", ""})
 		if len(succzones) == 0 {
 			table.Append([]string{"", "", "None", ""})
 		} else {
@@ -426,3 +437,351 @@ func renderBulkZonesResultTable(resultRespList interface{}, c *cli.Context) stri
 
 	return outString
 }
+
+// Zone plan table format
+func renderZonePlanTable(zone string, changes []RecordsetChange) string {
+	var out strings.Builder
+	out.WriteString("\nZone Plan\n\n")
+	table := tablewriter.NewWriter(&out)
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_CENTER, tablewriter.ALIGN_LEFT})
+	table.SetHeader([]string{"ACTION", "NAME", "TYPE", "TTL", "RDATA"})
+	table.SetReflowDuringAutoWrap(false)
+	table.SetAutoWrapText(false)
+	table.SetRowLine(true)
+	table.SetCenterSeparator(" ")
+	table.SetColumnSeparator(" ")
+	table.SetRowSeparator(" ")
+	table.SetBorder(false)
+
+	added, removed, changed := summarizeChanges(changes)
+	table.SetCaption(true, fmt.Sprintf("Zone: %s (%d added, %d removed, %d changed)", zone, added, removed, changed))
+
+	if len(changes) == 0 {
+		table.Append([]string{"No changes", " ", " ", " ", " "})
+	}
+	for _, ch := range changes {
+		switch ch.Action {
+		case changeAdded:
+			appendPlanRows(table, "+ added", ch.After)
+		case changeRemoved:
+			appendPlanRows(table, "- removed", ch.Before)
+		case changeChanged:
+			appendPlanRows(table, "~ before", ch.Before)
+			appendPlanRows(table, "~ after", ch.After)
+		}
+	}
+	table.Render()
+	return out.String()
+}
+
+func appendPlanRows(table *tablewriter.Table, action string, rs *dns.RecordSet) {
+	if len(rs.Rdata) == 0 {
+		table.Append([]string{action, rs.Name, rs.Type, strconv.Itoa(rs.TTL), " "})
+		return
+	}
+	for i, rdata := range rs.Rdata {
+		if i == 0 {
+			table.Append([]string{action, rs.Name, rs.Type, strconv.Itoa(rs.TTL), rdata})
+		} else {
+			table.Append([]string{" ", " ", " ", " ", rdata})
+		}
+	}
+}
+
+// Zone plan unified text format
+func renderZonePlanText(zone string, changes []RecordsetChange) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s (current)\n", zone)
+	fmt.Fprintf(&out, "+++ %s (planned)\n", zone)
+	for _, ch := range changes {
+		fmt.Fprintf(&out, "@@ %s %s (%s) @@\n", ch.Name, ch.Type, ch.Action)
+		if ch.Before != nil {
+			for _, rdata := range ch.Before.Rdata {
+				fmt.Fprintf(&out, "-%s %d IN %s %s\n", ch.Before.Name, ch.Before.TTL, ch.Before.Type, rdata)
+			}
+		}
+		if ch.After != nil {
+			for _, rdata := range ch.After.Rdata {
+				fmt.Fprintf(&out, "+%s %d IN %s %s\n", ch.After.Name, ch.After.TTL, ch.After.Type, rdata)
+			}
+		}
+	}
+	added, removed, changed := summarizeChanges(changes)
+	fmt.Fprintf(&out, "\n%d added, %d removed, %d changed\n", added, removed, changed)
+	return out.String()
+}
+
+// Apply plan table format
+func renderApplyPlanTable(plan *ApplyPlan) string {
+	var out strings.Builder
+	out.WriteString("\nApply Plan\n")
+	if len(plan.Zones) == 0 {
+		out.WriteString("\nNo zones found in manifest\n")
+	}
+	for _, zp := range plan.Zones {
+		fmt.Fprintf(&out, "\nZone: %s  Action: %s\n", zp.Zone, strings.ToUpper(zp.Action))
+		if zp.Action == applyNoop {
+			continue
+		}
+		if len(zp.ConfigChanges) > 0 {
+			out.WriteString("\n")
+			table := tablewriter.NewWriter(&out)
+			table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT})
+			table.SetHeader([]string{"ATTRIBUTE", "CURRENT", "DESIRED"})
+			table.SetAutoWrapText(false)
+			table.SetRowLine(true)
+			table.SetCenterSeparator(" ")
+			table.SetColumnSeparator(" ")
+			table.SetRowSeparator(" ")
+			table.SetBorder(false)
+			for _, ch := range zp.ConfigChanges {
+				table.Append([]string{ch.Field, ch.Before, ch.After})
+			}
+			table.Render()
+		}
+		if len(zp.Changes) > 0 {
+			out.WriteString(renderZonePlanTable(zp.Zone, zp.Changes))
+		}
+	}
+	return out.String()
+}
+
+// Change list table format
+func renderChangeListTable(view *ChangeListView) string {
+	var out strings.Builder
+	out.WriteString("\nChange List\n\n")
+	table := tablewriter.NewWriter(&out)
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT})
+	table.SetHeader([]string{"ATTRIBUTE", "VALUE"})
+	table.SetAutoWrapText(false)
+	table.SetRowLine(true)
+	table.SetCenterSeparator(" ")
+	table.SetColumnSeparator(" ")
+	table.SetRowSeparator(" ")
+	table.SetBorder(false)
+	table.Append([]string{"Zone", view.Zone})
+	table.Append([]string{"Change Tag", view.ChangeTag})
+	table.Append([]string{"Zone Version ID", view.ZoneVersionID})
+	table.Append([]string{"Last Modified Date", view.LastModifiedDate})
+	table.Append([]string{"Stale", strconv.FormatBool(view.Stale)})
+	table.Render()
+
+	if len(view.RecordSets) > 0 {
+		out.WriteString(renderRecordsetListTable(view.Zone, view.RecordSets))
+	}
+	return out.String()
+}
+
+// TSIG key list table format
+func renderTSIGKeyListTable(keys []dns.TSIGKeyResponse, c *cli.Context) string {
+	var out strings.Builder
+	out.WriteString("\nTSIG Keys\n\n")
+	table := tablewriter.NewWriter(&out)
+	table.SetHeader([]string{"NAME", "ALGORITHM", "SECRET", "ZONES"})
+	table.SetAutoWrapText(false)
+	table.SetRowLine(true)
+	table.SetCenterSeparator(" ")
+	table.SetColumnSeparator(" ")
+	table.SetRowSeparator(" ")
+	table.SetBorder(false)
+
+	if len(keys) == 0 {
+		table.Append([]string{"No TSIG keys found", " ", " ", " "})
+	}
+	for _, k := range keys {
+		table.Append([]string{k.Name, k.Algorithm, tsigSecret(k.Secret, c), strconv.FormatInt(k.ZoneCount, 10)})
+	}
+	table.Render()
+	return out.String()
+}
+
+// TSIG key zones table format
+func renderTSIGKeyZonesTable(key *dns.TSIGKey, zones []string, c *cli.Context) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "\nTSIG Key: %s (%s)\nSecret: %s\n\n", key.Name, key.Algorithm, tsigSecret(key.Secret, c))
+	table := tablewriter.NewWriter(&out)
+	table.SetHeader([]string{"ZONE"})
+	table.SetAutoWrapText(false)
+	table.SetBorder(false)
+	if len(zones) == 0 {
+		table.Append([]string{"No zones use this key"})
+	}
+	for _, z := range zones {
+		table.Append([]string{z})
+	}
+	table.Render()
+	return out.String()
+}
+
+// DNSSEC status table format
+func renderDNSSecStatusTable(statuses []*DNSSecZoneStatus) string {
+	var out strings.Builder
+	out.WriteString("\nDNSSEC Status\n")
+	if len(statuses) == 0 {
+		out.WriteString("\nNo zones found\n")
+	}
+	for _, st := range statuses {
+		state := "unsigned"
+		if st.SignAndServe {
+			state = "signed"
+			if st.Algorithm != "" {
+				state += " (" + st.Algorithm + ")"
+			}
+		}
+		fmt.Fprintf(&out, "\nZone: %s  %s\n", st.Zone, state)
+		if st.Expiration != nil {
+			fmt.Fprintf(&out, "Earliest signature expiration: %s (%s)\n", st.Expiration.Format(time.RFC3339), st.Nameserver)
+		} else if st.Unknown {
+			out.WriteString("Earliest signature expiration: unknown\n")
+		}
+		if len(st.Keys) > 0 {
+			out.WriteString("\n")
+			table := tablewriter.NewWriter(&out)
+			table.SetHeader([]string{"KEY TAG", "ROLE", "ALGORITHM", "STATE", "SIGNATURES EXPIRE"})
+			table.SetAutoWrapText(false)
+			table.SetCenterSeparator(" ")
+			table.SetColumnSeparator(" ")
+			table.SetRowSeparator(" ")
+			table.SetBorder(false)
+			for _, k := range st.Keys {
+				keyState := "active"
+				if k.Pending {
+					keyState = "pending"
+				}
+				expires := "-"
+				if k.Expiration != nil {
+					expires = k.Expiration.Format(time.RFC3339)
+				}
+				table.Append([]string{strconv.Itoa(k.KeyTag), k.Role, k.Algorithm, keyState, expires})
+			}
+			table.Render()
+		}
+		if len(st.DSRecords) > 0 {
+			out.WriteString("\nDS records to publish at the registrar:\n")
+			for _, ds := range st.DSRecords {
+				fmt.Fprintf(&out, "  %s. IN DS %s\n", st.Zone, ds)
+			}
+		}
+		if len(st.PendingDS) > 0 {
+			out.WriteString("\nDS records for the next key rollover:\n")
+			for _, ds := range st.PendingDS {
+				fmt.Fprintf(&out, "  %s. IN DS %s\n", st.Zone, ds)
+			}
+		}
+		for _, alert := range st.Alerts {
+			fmt.Fprintf(&out, "Alert: %s\n", alert)
+		}
+	}
+	return out.String()
+}
+
+// Zone snapshot list table format
+func renderZoneSnapshotsTable(zone string, snaps []*ZoneSnapshot) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "\nSnapshots of %s\n\n", zone)
+	if len(snaps) == 0 {
+		out.WriteString("No snapshots found\n")
+		return out.String()
+	}
+	table := tablewriter.NewWriter(&out)
+	table.SetHeader([]string{"ID", "CREATED", "COMMAND", "VERSION ID", "RECORDSETS"})
+	table.SetAutoWrapText(false)
+	table.SetRowLine(true)
+	table.SetCenterSeparator(" ")
+	table.SetColumnSeparator(" ")
+	table.SetRowSeparator(" ")
+	table.SetBorder(false)
+	for _, s := range snaps {
+		table.Append([]string{s.ID, s.Created, s.Command, s.Config.VersionID, strconv.Itoa(len(s.RecordSets))})
+	}
+	table.Render()
+	return out.String()
+}
+
+// find-record matches table format
+func renderRecordMatchesTable(matches []RecordMatch) string {
+	var out strings.Builder
+	out.WriteString("\nMatching Recordsets\n\n")
+	table := tablewriter.NewWriter(&out)
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_CENTER, tablewriter.ALIGN_LEFT})
+	table.SetHeader([]string{"ZONE", "NAME", "TYPE", "TTL", "RDATA"})
+	table.SetReflowDuringAutoWrap(false)
+	table.SetAutoWrapText(false)
+	table.SetRowLine(true)
+	table.SetCenterSeparator(" ")
+	table.SetColumnSeparator(" ")
+	table.SetRowSeparator(" ")
+	table.SetBorder(false)
+
+	if len(matches) == 0 {
+		table.Append([]string{"No matching recordsets found", " ", " ", " ", " "})
+	}
+	for _, m := range matches {
+		for i, rdata := range m.Rdata {
+			if i == 0 {
+				table.Append([]string{m.Zone, m.Name, m.Type, strconv.Itoa(m.TTL), rdata})
+			} else {
+				table.Append([]string{" ", " ", " ", " ", rdata})
+			}
+		}
+	}
+	table.Render()
+	return out.String()
+}
+
+// Profile list table format
+func renderProfileListTable(path string, profiles []*Profile) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "\nProfiles in %s\n\n", path)
+	table := tablewriter.NewWriter(&out)
+	table.SetHeader([]string{"", "NAME", "SECTION", "ACCOUNT KEY", "CONTRACT", "GROUP"})
+	table.SetAutoWrapText(false)
+	table.SetBorder(false)
+
+	if len(profiles) == 0 {
+		table.Append([]string{" ", "No profiles found", " ", " ", " ", " "})
+	}
+	for _, p := range profiles {
+		active := " "
+		if p.Active {
+			active = "*"
+		}
+		table.Append([]string{active, p.Name, p.Section, p.AccountKey, p.ContractID, p.GroupID})
+	}
+	table.Render()
+	return out.String()
+}
+
+// Profile table format
+func renderProfileTable(p *Profile) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "\nProfile: %s\n\n", p.Name)
+	table := tablewriter.NewWriter(&out)
+	table.SetHeader([]string{"ATTRIBUTE", "VALUE"})
+	table.SetAutoWrapText(false)
+	table.SetBorder(false)
+
+	table.Append([]string{"Active", strconv.FormatBool(p.Active)})
+	table.Append([]string{"Edgerc", p.Edgerc})
+	table.Append([]string{"Section", p.Section})
+	table.Append([]string{"Account Key", p.AccountKey})
+	table.Append([]string{"Contract ID", p.ContractID})
+	table.Append([]string{"Group ID", p.GroupID})
+	table.Append([]string{"Format", p.Format})
+	if r := p.Retry; r != nil {
+		if r.Disabled != nil {
+			table.Append([]string{"Retry Disabled", strconv.FormatBool(*r.Disabled)})
+		}
+		if r.Max != nil {
+			table.Append([]string{"Retry Max", strconv.Itoa(*r.Max)})
+		}
+		if r.WaitMin != nil {
+			table.Append([]string{"Retry Wait Min", strconv.Itoa(*r.WaitMin) + "s"})
+		}
+		if r.WaitMax != nil {
+			table.Append([]string{"Retry Wait Max", strconv.Itoa(*r.WaitMax) + "s"})
+		}
+	}
+	table.Render()
+	return out.String()
+}
@@ -0,0 +1,282 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultConfigFile = "~/.akamai-dns.yaml"
+
+// Profile is a named set of account settings in the CLI config file
+type Profile struct {
+	Name       string        `json:"name" yaml:"-"`
+	Active     bool          `json:"active" yaml:"-"`
+	Edgerc     string        `json:"edgerc,omitempty" yaml:"edgerc,omitempty"`
+	Section    string        `json:"section,omitempty" yaml:"section,omitempty"`
+	AccountKey string        `json:"accountKey,omitempty" yaml:"accountKey,omitempty"`
+	ContractID string        `json:"contractId,omitempty" yaml:"contractId,omitempty"`
+	GroupID    string        `json:"groupId,omitempty" yaml:"groupId,omitempty"`
+	Format     string        `json:"format,omitempty" yaml:"format,omitempty"`
+	Retry      *ProfileRetry `json:"retry,omitempty" yaml:"retry,omitempty"`
+}
+
+// ProfileRetry holds the retry settings otherwise read from AKAMAI_RETRY_* variables
+type ProfileRetry struct {
+	Disabled *bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	Max      *int  `json:"max,omitempty" yaml:"max,omitempty"`
+	WaitMin  *int  `json:"waitMin,omitempty" yaml:"waitMin,omitempty"`
+	WaitMax  *int  `json:"waitMax,omitempty" yaml:"waitMax,omitempty"`
+}
+
+// CLIConfig is the content of ~/.akamai-dns.yaml
+type CLIConfig struct {
+	Current  string              `yaml:"current,omitempty"`
+	Profiles map[string]*Profile `yaml:"profiles"`
+
+	path string
+}
+
+// Profile selected with --profile or the config file, nil when none is in use
+var activeProfile *Profile
+
+// Path of the CLI config file, from AKAMAI_DNS_CONFIG or the default
+func configFilePath() string {
+	path := os.Getenv("AKAMAI_DNS_CONFIG")
+	if path == "" {
+		path = defaultConfigFile
+	}
+	if strings.HasPrefix(path, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// Load the CLI config file. A missing file is an empty config.
+func loadCLIConfig() (*CLIConfig, error) {
+	cfg := &CLIConfig{path: configFilePath(), Profiles: map[string]*Profile{}}
+	data, err := os.ReadFile(cfg.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", cfg.path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*Profile{}
+	}
+	for name, p := range cfg.Profiles {
+		if p == nil {
+			p = &Profile{}
+			cfg.Profiles[name] = p
+		}
+		p.Name = name
+	}
+	return cfg, nil
+}
+
+// Profile names, sorted
+func (cfg *CLIConfig) profileNames() []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (cfg *CLIConfig) profile(name string) (*Profile, error) {
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		if len(cfg.Profiles) == 0 {
+			return nil, newCommandError(exitNotFound, "profile %s not found: %s has no profiles", name, cfg.path)
+		}
+		return nil, newCommandError(exitNotFound, "profile %s not found. Profiles: %s", name, strings.Join(cfg.profileNames(), ", "))
+	}
+	return p, nil
+}
+
+// Set the current profile in the config file. The file is edited in place so
+// comments and the order of the profiles are kept.
+func (cfg *CLIConfig) setCurrent(name string) error {
+	data, err := os.ReadFile(cfg.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", cfg.path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid config file %s: expected a mapping", cfg.path)
+	}
+	root := doc.Content[0]
+	set := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "current" {
+			root.Content[i+1].SetString(name)
+			set = true
+		}
+	}
+	if !set {
+		key, value := &yaml.Node{}, &yaml.Node{}
+		key.SetString("current")
+		value.SetString(name)
+		root.Content = append([]*yaml.Node{key, value}, root.Content...)
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	enc.Close()
+	if err := writeFileAtomic(cfg.path, out.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	cfg.Current = name
+	return nil
+}
+
+// Select the profile from --profile, AKAMAI_DNS_PROFILE or the config file and
+// use its settings for the global flags and retry variables that are not set.
+// Called before any command runs.
+func applyProfile(c *cli.Context) error {
+	cfg, err := loadCLIConfig()
+	if err != nil {
+		return newCommandError(exitValidation, "%v", err)
+	}
+	name := c.String("profile")
+	if name == "" {
+		name = cfg.Current
+	}
+	if name == "" {
+		return nil
+	}
+	p, err := cfg.profile(name)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(p.Format) {
+	case "", formatTable, formatJSON, formatYAML, formatCSV:
+	default:
+		return newCommandError(exitValidation, "profile %s: format must be one of table, json, yaml or csv", name)
+	}
+
+	flags := map[string]string{
+		"edgerc":     p.Edgerc,
+		"section":    p.Section,
+		"accountkey": p.AccountKey,
+	}
+	for flag, value := range flags {
+		if value != "" && !c.IsSet(flag) {
+			if err := c.Set(flag, value); err != nil {
+				return newCommandError(exitValidation, "profile %s: %v", name, err)
+			}
+		}
+	}
+
+	if r := p.Retry; r != nil {
+		env := map[string]string{}
+		if r.Disabled != nil {
+			env["AKAMAI_RETRY_DISABLED"] = strconv.FormatBool(*r.Disabled)
+		}
+		if r.Max != nil {
+			env["AKAMAI_RETRY_MAX"] = strconv.Itoa(*r.Max)
+		}
+		if r.WaitMin != nil {
+			env["AKAMAI_RETRY_WAIT_MIN"] = strconv.Itoa(*r.WaitMin)
+		}
+		if r.WaitMax != nil {
+			env["AKAMAI_RETRY_WAIT_MAX"] = strconv.Itoa(*r.WaitMax)
+		}
+		for key, value := range env {
+			if _, ok := os.LookupEnv(key); !ok {
+				os.Setenv(key, value)
+			}
+		}
+	}
+
+	activeProfile = p
+	return nil
+}
+
+// Contract ID from --contractid, or the active profile's default
+func contractIDFlag(c *cli.Context) string {
+	if id := c.String("contractid"); id != "" {
+		return id
+	}
+	if activeProfile != nil {
+		return activeProfile.ContractID
+	}
+	return ""
+}
+
+// Group ID from --groupid, or the active profile's default
+func groupIDFlag(c *cli.Context) string {
+	if id := c.String("groupid"); id != "" {
+		return id
+	}
+	if activeProfile != nil {
+		return activeProfile.GroupID
+	}
+	return ""
+}
+
+// Output format of the active profile, used when no output flag is given
+func profileFormat() string {
+	if activeProfile != nil {
+		return strings.ToLower(activeProfile.Format)
+	}
+	return ""
+}
+
+// Print the active profile to STDERR before a command changes anything, so the
+// account a change goes to is always visible. Used as the Before of mutating commands.
+func profileHeader(c *cli.Context) error {
+	if activeProfile == nil {
+		return nil
+	}
+	details := []string{}
+	if key := c.GlobalString("accountkey"); key != "" {
+		details = append(details, "account "+key)
+	}
+	if section := c.GlobalString("section"); section != "" {
+		details = append(details, "section "+section)
+	}
+	header := "Profile: " + activeProfile.Name
+	if len(details) > 0 {
+		header += " (" + strings.Join(details, ", ") + ")"
+	}
+	fmt.Fprintln(os.Stderr, color.YellowString(header))
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rdata fields holding domain names, by record type. These are qualified with the origin.
+var rdataNameFields = map[string][]int{
+	"NS":    {0},
+	"CNAME": {0},
+	"PTR":   {0},
+	"DNAME": {0},
+	"MX":    {1},
+	"AFSDB": {1},
+	"RP":    {0, 1},
+	"SOA":   {0, 1},
+	"SRV":   {3},
+	"NAPTR": {5},
+	"SVCB":  {1},
+	"HTTPS": {1},
+}
+
+// Add the trailing dot to the domain name fields of an rdata value. Provider
+// exports hold fully qualified names, with or without the dot.
+func qualifyRdataNames(rtype, rdata string) string {
+	fields, ok := rdataNameFields[rtype]
+	if !ok {
+		return rdata
+	}
+	parts := strings.Fields(rdata)
+	for _, i := range fields {
+		if i < len(parts) && parts[i] != "." && !strings.HasSuffix(parts[i], ".") {
+			parts[i] += "."
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Split TXT rdata into its character strings, undoing escapes. Unquoted rdata is a single string.
+func splitTXTStrings(rdata string) ([]string, error) {
+	rdata = strings.TrimSpace(rdata)
+	if !strings.HasPrefix(rdata, `"`) {
+		if strings.Contains(rdata, `"`) {
+			return nil, fmt.Errorf("rdata has unbalanced quotes")
+		}
+		return []string{rdata}, nil
+	}
+
+	strs := []string{}
+	var cur strings.Builder
+	inQuote := false
+	for i := 0; i < len(rdata); i++ {
+		ch := rdata[i]
+		switch {
+		case ch == '\\' && inQuote:
+			if i+3 < len(rdata) && isDigits(rdata[i+1:i+4]) {
+				n, _ := strconv.Atoi(rdata[i+1 : i+4])
+				cur.WriteByte(byte(n))
+				i += 3
+			} else if i+1 < len(rdata) {
+				cur.WriteByte(rdata[i+1])
+				i++
+			}
+		case ch == '"':
+			if inQuote {
+				strs = append(strs, cur.String())
+				cur.Reset()
+			}
+			inQuote = !inQuote
+		case inQuote:
+			cur.WriteByte(ch)
+		case ch != ' ' && ch != '\t':
+			return nil, fmt.Errorf("rdata has text outside quotes")
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("rdata has unbalanced quotes")
+	}
+	return strs, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Text of TXT rdata with its character strings joined
+func txtValue(rdata string) string {
+	strs, err := splitTXTStrings(rdata)
+	if err != nil {
+		return rdata
+	}
+	return strings.Join(strs, "")
+}
+
+// Quote a character string, escaping quotes, backslashes and non-printable octets
+func quoteTXTString(s []byte) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, ch := range s {
+		switch {
+		case ch == '"' || ch == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		case ch < ' ' || ch >= 0x7f:
+			fmt.Fprintf(&b, "\\%03d", ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// TXT rdata holding value as it is, split into quoted strings of at most 255 octets
+func quoteTXTChunks(value string) string {
+	if value == "" {
+		return `""`
+	}
+	strs := []string{}
+	for data := []byte(value); len(data) > 0; {
+		n := min(len(data), 255)
+		strs = append(strs, quoteTXTString(data[:n]))
+		data = data[n:]
+	}
+	return strings.Join(strs, " ")
+}
@@ -0,0 +1,615 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNS message values used by the RFC 2136 update gateway
+const (
+	dnsOpcodeUpdate = 5
+
+	dnsRcodeNoError  = 0
+	dnsRcodeFormErr  = 1
+	dnsRcodeServFail = 2
+	dnsRcodeNXDomain = 3
+	dnsRcodeNotImp   = 4
+	dnsRcodeRefused  = 5
+	dnsRcodeYXDomain = 6
+	dnsRcodeYXRRSet  = 7
+	dnsRcodeNXRRSet  = 8
+	dnsRcodeNotAuth  = 9
+	dnsRcodeNotZone  = 10
+
+	dnsClassIN   = 1
+	dnsClassNone = 254
+	dnsClassAny  = 255
+
+	dnsTypeSOA  = 6
+	dnsTypeTSIG = 250
+	dnsTypeAny  = 255
+
+	tsigErrBadSig  = 16
+	tsigErrBadKey  = 17
+	tsigErrBadTime = 18
+
+	dnsHeaderSize = 12
+)
+
+var dnsRcodeNames = map[int]string{
+	dnsRcodeNoError:  "NOERROR",
+	dnsRcodeFormErr:  "FORMERR",
+	dnsRcodeServFail: "SERVFAIL",
+	dnsRcodeNXDomain: "NXDOMAIN",
+	dnsRcodeNotImp:   "NOTIMP",
+	dnsRcodeRefused:  "REFUSED",
+	dnsRcodeYXDomain: "YXDOMAIN",
+	dnsRcodeYXRRSet:  "YXRRSET",
+	dnsRcodeNXRRSet:  "NXRRSET",
+	dnsRcodeNotAuth:  "NOTAUTH",
+	dnsRcodeNotZone:  "NOTZONE",
+}
+
+// Record types by number. The meta types can't be added to a zone.
+var dnsTypeNames = map[uint16]string{
+	1: "A", 2: "NS", 5: "CNAME", 6: "SOA", 12: "PTR", 13: "HINFO", 15: "MX", 16: "TXT",
+	28: "AAAA", 33: "SRV", 35: "NAPTR", 43: "DS", 44: "SSHFP", 52: "TLSA", 64: "SVCB",
+	65: "HTTPS", 99: "SPF", 257: "CAA",
+	251: "IXFR", 252: "AXFR", 253: "MAILB", 254: "MAILA", 255: "ANY",
+}
+
+func dnsTypeName(t uint16) string {
+	if name, ok := dnsTypeNames[t]; ok {
+		return name
+	}
+	return "TYPE" + strconv.Itoa(int(t))
+}
+
+func isMetaType(t uint16) bool {
+	return t >= 128 && t <= 255
+}
+
+func dnsRcodeName(rcode int) string {
+	if name, ok := dnsRcodeNames[rcode]; ok {
+		return name
+	}
+	return "RCODE" + strconv.Itoa(rcode)
+}
+
+// dnsQuestion is an entry of the zone section
+type dnsQuestion struct {
+	Name  string
+	Type  uint16
+	Class uint16
+}
+
+// dnsRR is a resource record of an UPDATE message. Names are lower case without
+// the trailing dot.
+type dnsRR struct {
+	Name  string
+	Type  uint16
+	Class uint16
+	TTL   uint32
+
+	// Position of the rdata in the message, which compressed names point into
+	rdataOff int
+	rdataLen int
+}
+
+// dnsTSIG is the TSIG record signing a message
+type dnsTSIG struct {
+	KeyName    string
+	Algorithm  string
+	TimeSigned uint64
+	Fudge      uint16
+	MAC        []byte
+	OrigID     uint16
+	Error      uint16
+	Other      []byte
+}
+
+// dnsUpdateMessage is a parsed UPDATE request
+type dnsUpdateMessage struct {
+	ID      uint16
+	Opcode  int
+	Zones   []dnsQuestion
+	Prereqs []dnsRR
+	Updates []dnsRR
+	TSIG    *dnsTSIG
+
+	raw []byte
+	// Offset of the TSIG record, where the signed data ends
+	tsigOff int
+}
+
+// An UPDATE request that fails with an RCODE
+type updateError struct {
+	rcode  int
+	reason string
+}
+
+func (e *updateError) Error() string {
+	return dnsRcodeName(e.rcode) + ": " + e.reason
+}
+
+func newUpdateError(rcode int, format string, args ...interface{}) error {
+	return &updateError{rcode: rcode, reason: fmt.Sprintf(format, args...)}
+}
+
+// Parse an UPDATE message. Only the header is read for other opcodes.
+func parseUpdateMessage(msg []byte) (*dnsUpdateMessage, error) {
+	if len(msg) < dnsHeaderSize {
+		return nil, errors.New("message shorter than a header")
+	}
+	m := &dnsUpdateMessage{
+		ID:     binary.BigEndian.Uint16(msg),
+		Opcode: int(binary.BigEndian.Uint16(msg[2:])>>11) & 0xf,
+		raw:    msg,
+	}
+	if m.Opcode != dnsOpcodeUpdate {
+		return m, nil
+	}
+
+	counts := [4]int{}
+	for i := range counts {
+		counts[i] = int(binary.BigEndian.Uint16(msg[4+2*i:]))
+	}
+	off := dnsHeaderSize
+	for i := 0; i < counts[0]; i++ {
+		name, next, err := readDNSName(msg, off)
+		if err != nil {
+			return m, err
+		}
+		if next+4 > len(msg) {
+			return m, errors.New("truncated zone section")
+		}
+		m.Zones = append(m.Zones, dnsQuestion{
+			Name:  name,
+			Type:  binary.BigEndian.Uint16(msg[next:]),
+			Class: binary.BigEndian.Uint16(msg[next+2:]),
+		})
+		off = next + 4
+	}
+	sections := []*[]dnsRR{&m.Prereqs, &m.Updates, nil}
+	for s, section := range sections {
+		for i := 0; i < counts[s+1]; i++ {
+			start := off
+			rr, next, err := readDNSRR(msg, off)
+			if err != nil {
+				return m, err
+			}
+			off = next
+			if rr.Type == dnsTypeTSIG {
+				// TSIG must be the last record of the additional section
+				if section != nil || i != counts[3]-1 {
+					return m, errors.New("TSIG record is not the last record")
+				}
+				if m.TSIG, err = readTSIG(msg, rr); err != nil {
+					return m, err
+				}
+				m.tsigOff = start
+				continue
+			}
+			if section != nil {
+				*section = append(*section, rr)
+			}
+		}
+	}
+	if off != len(msg) {
+		return m, errors.New("trailing data after the last record")
+	}
+	return m, nil
+}
+
+// Read a possibly compressed name. Returns the offset after the name in place.
+func readDNSName(msg []byte, off int) (string, int, error) {
+	labels := []string{}
+	next := -1
+	length := 0
+	for hops := 0; ; {
+		if off >= len(msg) {
+			return "", 0, errors.New("truncated name")
+		}
+		c := int(msg[off])
+		switch {
+		case c == 0:
+			if next < 0 {
+				next = off + 1
+			}
+			return strings.Join(labels, "."), next, nil
+		case c&0xc0 == 0xc0:
+			if off+1 >= len(msg) {
+				return "", 0, errors.New("truncated name")
+			}
+			if hops++; hops > 32 {
+				return "", 0, errors.New("name compression loop")
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+		case c&0xc0 != 0:
+			return "", 0, errors.New("unsupported label type")
+		default:
+			if off+1+c > len(msg) {
+				return "", 0, errors.New("truncated name")
+			}
+			if length += c + 1; length > 255 {
+				return "", 0, errors.New("name longer than 255 octets")
+			}
+			labels = append(labels, escapeDNSLabel(msg[off+1:off+1+c]))
+			off += 1 + c
+		}
+	}
+}
+
+// Lower-case a label, escaping dots and non-printable octets as \DDD
+func escapeDNSLabel(label []byte) string {
+	var b strings.Builder
+	for _, ch := range label {
+		switch {
+		case ch <= ' ' || ch >= 0x7f || ch == '.' || ch == '\\':
+			fmt.Fprintf(&b, "\\%03d", ch)
+		case ch >= 'A' && ch <= 'Z':
+			b.WriteByte(ch + 'a' - 'A')
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+func readDNSRR(msg []byte, off int) (dnsRR, int, error) {
+	name, next, err := readDNSName(msg, off)
+	if err != nil {
+		return dnsRR{}, 0, err
+	}
+	if next+10 > len(msg) {
+		return dnsRR{}, 0, errors.New("truncated record")
+	}
+	rr := dnsRR{
+		Name:     name,
+		Type:     binary.BigEndian.Uint16(msg[next:]),
+		Class:    binary.BigEndian.Uint16(msg[next+2:]),
+		TTL:      binary.BigEndian.Uint32(msg[next+4:]),
+		rdataLen: int(binary.BigEndian.Uint16(msg[next+8:])),
+		rdataOff: next + 10,
+	}
+	if rr.rdataOff+rr.rdataLen > len(msg) {
+		return dnsRR{}, 0, errors.New("truncated record data")
+	}
+	return rr, rr.rdataOff + rr.rdataLen, nil
+}
+
+func readTSIG(msg []byte, rr dnsRR) (*dnsTSIG, error) {
+	if rr.Class != dnsClassAny || rr.TTL != 0 {
+		return nil, errors.New("malformed TSIG record")
+	}
+	end := rr.rdataOff + rr.rdataLen
+	alg, off, err := readDNSName(msg, rr.rdataOff)
+	if err != nil || off+10 > end {
+		return nil, errors.New("malformed TSIG record")
+	}
+	t := &dnsTSIG{
+		KeyName:    rr.Name,
+		Algorithm:  alg,
+		TimeSigned: uint64(binary.BigEndian.Uint16(msg[off:]))<<32 | uint64(binary.BigEndian.Uint32(msg[off+2:])),
+		Fudge:      binary.BigEndian.Uint16(msg[off+6:]),
+	}
+	macLen := int(binary.BigEndian.Uint16(msg[off+8:]))
+	off += 10
+	if off+macLen+6 > end {
+		return nil, errors.New("malformed TSIG record")
+	}
+	t.MAC = msg[off : off+macLen]
+	off += macLen
+	t.OrigID = binary.BigEndian.Uint16(msg[off:])
+	t.Error = binary.BigEndian.Uint16(msg[off+2:])
+	otherLen := int(binary.BigEndian.Uint16(msg[off+4:]))
+	off += 6
+	if off+otherLen != end {
+		return nil, errors.New("malformed TSIG record")
+	}
+	t.Other = msg[off:end]
+	return t, nil
+}
+
+// Uncompressed wire form of a name
+func packDNSName(name string) []byte {
+	b := []byte{}
+	name = strings.TrimSuffix(name, ".")
+	if name != "" {
+		for _, label := range strings.Split(name, ".") {
+			b = append(b, byte(len(label)))
+			b = append(b, label...)
+		}
+	}
+	return append(b, 0)
+}
+
+// Rdata of a record in the Edge DNS presentation format
+func rdataString(msg []byte, rr dnsRR) (string, error) {
+	rdata := msg[rr.rdataOff : rr.rdataOff+rr.rdataLen]
+	name := func(off int) (string, int, error) {
+		n, next, err := readDNSName(msg, rr.rdataOff+off)
+		if err != nil || next > rr.rdataOff+rr.rdataLen {
+			return "", 0, errors.New("malformed name in rdata")
+		}
+		return n + ".", next - rr.rdataOff, nil
+	}
+	malformed := fmt.Errorf("malformed %s rdata", dnsTypeName(rr.Type))
+
+	switch dnsTypeName(rr.Type) {
+	case "A":
+		if len(rdata) != net.IPv4len {
+			return "", malformed
+		}
+		return net.IP(rdata).String(), nil
+	case "AAAA":
+		if len(rdata) != net.IPv6len {
+			return "", malformed
+		}
+		return net.IP(rdata).String(), nil
+	case "CNAME", "NS", "PTR":
+		target, next, err := name(0)
+		if err != nil || next != len(rdata) {
+			return "", malformed
+		}
+		return target, nil
+	case "MX":
+		if len(rdata) < 3 {
+			return "", malformed
+		}
+		target, next, err := name(2)
+		if err != nil || next != len(rdata) {
+			return "", malformed
+		}
+		return fmt.Sprintf("%d %s", binary.BigEndian.Uint16(rdata), target), nil
+	case "SRV":
+		if len(rdata) < 7 {
+			return "", malformed
+		}
+		target, next, err := name(6)
+		if err != nil || next != len(rdata) {
+			return "", malformed
+		}
+		return fmt.Sprintf("%d %d %d %s", binary.BigEndian.Uint16(rdata), binary.BigEndian.Uint16(rdata[2:]),
+			binary.BigEndian.Uint16(rdata[4:]), target), nil
+	case "TXT", "SPF":
+		strs := []string{}
+		for off := 0; off < len(rdata); {
+			n := int(rdata[off])
+			if off+1+n > len(rdata) {
+				return "", malformed
+			}
+			strs = append(strs, quoteTXTString(rdata[off+1:off+1+n]))
+			off += 1 + n
+		}
+		if len(strs) == 0 {
+			return "", malformed
+		}
+		return strings.Join(strs, " "), nil
+	case "CAA":
+		if len(rdata) < 2 || 2+int(rdata[1]) > len(rdata) {
+			return "", malformed
+		}
+		tag := string(rdata[2 : 2+rdata[1]])
+		return fmt.Sprintf("%d %s %s", rdata[0], tag, quoteTXTString(rdata[2+len(tag):])), nil
+	}
+	return "", newUpdateError(dnsRcodeNotImp, "%s records are not supported", dnsTypeName(rr.Type))
+}
+
+// Comparable form of an rdata value: addresses in canonical form, names in
+// lower case without the trailing dot, and TXT strings unquoted
+func rdataKey(rtype, rdata string) string {
+	switch strings.ToUpper(rtype) {
+	case "A", "AAAA":
+		if ip := net.ParseIP(strings.TrimSpace(rdata)); ip != nil {
+			return ip.String()
+		}
+	case "TXT", "SPF":
+		if strs, err := splitTXTStrings(rdata); err == nil {
+			return strings.Join(strs, "\x00")
+		}
+	}
+	fields := strings.Fields(strings.ToLower(rdata))
+	for i, f := range fields {
+		fields[i] = strings.TrimSuffix(f, ".")
+	}
+	return strings.Join(fields, " ")
+}
+
+// tsigKey is a shared secret requests can be signed with
+type tsigKey struct {
+	Name      string
+	Algorithm string
+	Secret    []byte
+}
+
+// TSIG algorithms by their canonical name
+var tsigAlgorithms = map[string]func() hash.Hash{
+	"hmac-md5.sig-alg.reg.int": md5.New,
+	"hmac-sha1":                sha1.New,
+	"hmac-sha224":              sha256.New224,
+	"hmac-sha256":              sha256.New,
+	"hmac-sha384":              sha512.New384,
+	"hmac-sha512":              sha512.New,
+}
+
+// Canonical algorithm name, accepting hmac-md5 for the long form
+func tsigAlgorithmName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "hmac-md5" {
+		name = "hmac-md5.sig-alg.reg.int"
+	}
+	if _, ok := tsigAlgorithms[name]; !ok {
+		return "", fmt.Errorf("unsupported TSIG algorithm %s", name)
+	}
+	return name, nil
+}
+
+func newTSIGKey(name, algorithm, secret string) (*tsigKey, error) {
+	alg, err := tsigAlgorithmName(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	name = normalizeDNSName(name)
+	if name == "" {
+		return nil, errors.New("TSIG key name must not be empty")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(secret))
+	if err != nil || len(decoded) == 0 {
+		return nil, fmt.Errorf("TSIG key %s has an invalid base64 secret", name)
+	}
+	return &tsigKey{Name: name, Algorithm: alg, Secret: decoded}, nil
+}
+
+// Parse a key given as [algorithm:]name:secret, the nsupdate -y format.
+// The algorithm defaults to hmac-sha256.
+func parseTSIGKeyFlag(value string) (*tsigKey, error) {
+	parts := strings.Split(value, ":")
+	switch len(parts) {
+	case 2:
+		return newTSIGKey(parts[0], "hmac-sha256", parts[1])
+	case 3:
+		return newTSIGKey(parts[1], parts[0], parts[2])
+	}
+	return nil, fmt.Errorf("invalid TSIG key %q, expected [algorithm:]name:secret", strings.SplitN(value, ":", 2)[0])
+}
+
+var (
+	bindKeyPattern       = regexp.MustCompile(`(?s)key\s+"?([^"\s{]+)"?\s*\{(.*?)\}\s*;`)
+	bindAlgorithmPattern = regexp.MustCompile(`algorithm\s+"?([^";\s]+)"?\s*;`)
+	bindSecretPattern    = regexp.MustCompile(`secret\s+"([^"]+)"\s*;`)
+)
+
+// Read the key statements of a BIND key file, as written by tsig-keygen
+func readTSIGKeyFile(path string) ([]*tsigKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys := []*tsigKey{}
+	for _, m := range bindKeyPattern.FindAllStringSubmatch(string(data), -1) {
+		alg := bindAlgorithmPattern.FindStringSubmatch(m[2])
+		secret := bindSecretPattern.FindStringSubmatch(m[2])
+		if alg == nil || secret == nil {
+			return nil, fmt.Errorf("%s: key %s needs an algorithm and a secret", path, m[1])
+		}
+		key, err := newTSIGKey(m[1], alg[1], secret[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s has no key statements", path)
+	}
+	return keys, nil
+}
+
+// MAC of a message without its TSIG record. Responses also cover the request MAC.
+func (k *tsigKey) mac(msg []byte, t *dnsTSIG, requestMAC []byte) []byte {
+	h := hmac.New(tsigAlgorithms[k.Algorithm], k.Secret)
+	if requestMAC != nil {
+		binary.Write(h, binary.BigEndian, uint16(len(requestMAC)))
+		h.Write(requestMAC)
+	}
+	h.Write(msg)
+	h.Write(packDNSName(k.Name))
+	binary.Write(h, binary.BigEndian, uint16(dnsClassAny))
+	binary.Write(h, binary.BigEndian, uint32(0))
+	h.Write(packDNSName(k.Algorithm))
+	h.Write(tsigTime(t.TimeSigned))
+	binary.Write(h, binary.BigEndian, t.Fudge)
+	binary.Write(h, binary.BigEndian, t.Error)
+	binary.Write(h, binary.BigEndian, uint16(len(t.Other)))
+	h.Write(t.Other)
+	return h.Sum(nil)
+}
+
+// Check the signature of a request. The signed data is the message up to the
+// TSIG record, with the original ID and one additional record less.
+func (k *tsigKey) verify(m *dnsUpdateMessage) bool {
+	signed := append([]byte(nil), m.raw[:m.tsigOff]...)
+	binary.BigEndian.PutUint16(signed, m.TSIG.OrigID)
+	binary.BigEndian.PutUint16(signed[10:], binary.BigEndian.Uint16(signed[10:])-1)
+	return hmac.Equal(k.mac(signed, m.TSIG, nil), m.TSIG.MAC)
+}
+
+// Append a TSIG record to a response. An empty MAC leaves it unsigned, as
+// BADKEY and BADSIG responses must be.
+func appendTSIG(resp []byte, t *dnsTSIG) []byte {
+	rdata := packDNSName(t.Algorithm)
+	rdata = append(rdata, tsigTime(t.TimeSigned)...)
+	rdata = binary.BigEndian.AppendUint16(rdata, t.Fudge)
+	rdata = binary.BigEndian.AppendUint16(rdata, uint16(len(t.MAC)))
+	rdata = append(rdata, t.MAC...)
+	rdata = binary.BigEndian.AppendUint16(rdata, t.OrigID)
+	rdata = binary.BigEndian.AppendUint16(rdata, t.Error)
+	rdata = binary.BigEndian.AppendUint16(rdata, uint16(len(t.Other)))
+	rdata = append(rdata, t.Other...)
+
+	resp = append(resp, packDNSName(t.KeyName)...)
+	resp = binary.BigEndian.AppendUint16(resp, dnsTypeTSIG)
+	resp = binary.BigEndian.AppendUint16(resp, dnsClassAny)
+	resp = binary.BigEndian.AppendUint32(resp, 0)
+	resp = binary.BigEndian.AppendUint16(resp, uint16(len(rdata)))
+	resp = append(resp, rdata...)
+	binary.BigEndian.PutUint16(resp[10:], binary.BigEndian.Uint16(resp[10:])+1)
+	return resp
+}
+
+// 48-bit seconds since the epoch
+func tsigTime(t uint64) []byte {
+	b := make([]byte, 6)
+	binary.BigEndian.PutUint16(b, uint16(t>>32))
+	binary.BigEndian.PutUint32(b[2:], uint32(t))
+	return b
+}
+
+func tsigNow() uint64 {
+	return uint64(time.Now().Unix())
+}
+
+// Response to a request: the header with the RCODE and the zone section echoed
+func dnsResponse(m *dnsUpdateMessage, rcode int) []byte {
+	resp := make([]byte, dnsHeaderSize)
+	binary.BigEndian.PutUint16(resp, m.ID)
+	binary.BigEndian.PutUint16(resp[2:], 0x8000|uint16(m.Opcode)<<11|uint16(rcode&0xf))
+	binary.BigEndian.PutUint16(resp[4:], uint16(len(m.Zones)))
+	for _, z := range m.Zones {
+		resp = append(resp, packDNSName(z.Name)...)
+		resp = binary.BigEndian.AppendUint16(resp, z.Type)
+		resp = binary.BigEndian.AppendUint16(resp, z.Class)
+	}
+	return resp
+}
@@ -0,0 +1,296 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Record types used to build test messages
+const (
+	testTypeA     uint16 = 1
+	testTypeNS    uint16 = 2
+	testTypeCNAME uint16 = 5
+	testTypeMX    uint16 = 15
+	testTypeTXT   uint16 = 16
+	testTypeAAAA  uint16 = 28
+	testTypeCAA   uint16 = 257
+)
+
+// Wire form of a resource record with an uncompressed owner name
+func testRR(name string, rtype, class uint16, ttl uint32, rdata []byte) []byte {
+	b := packDNSName(name)
+	b = binary.BigEndian.AppendUint16(b, rtype)
+	b = binary.BigEndian.AppendUint16(b, class)
+	b = binary.BigEndian.AppendUint32(b, ttl)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(rdata)))
+	return append(b, rdata...)
+}
+
+// UPDATE message for zone with the given prerequisite and update records
+func testUpdateMessage(id uint16, zone string, prereqs, updates [][]byte) []byte {
+	msg := make([]byte, dnsHeaderSize)
+	binary.BigEndian.PutUint16(msg, id)
+	binary.BigEndian.PutUint16(msg[2:], dnsOpcodeUpdate<<11)
+	binary.BigEndian.PutUint16(msg[4:], 1)
+	binary.BigEndian.PutUint16(msg[6:], uint16(len(prereqs)))
+	binary.BigEndian.PutUint16(msg[8:], uint16(len(updates)))
+	msg = append(msg, packDNSName(zone)...)
+	msg = binary.BigEndian.AppendUint16(msg, dnsTypeSOA)
+	msg = binary.BigEndian.AppendUint16(msg, dnsClassIN)
+	for _, rr := range append(append([][]byte{}, prereqs...), updates...) {
+		msg = append(msg, rr...)
+	}
+	return msg
+}
+
+// Sign a message with key at timeSigned, as a client would
+func testSign(msg []byte, key *tsigKey, timeSigned uint64) []byte {
+	t := &dnsTSIG{
+		KeyName:    key.Name,
+		Algorithm:  key.Algorithm,
+		TimeSigned: timeSigned,
+		Fudge:      tsigFudge,
+		OrigID:     binary.BigEndian.Uint16(msg),
+	}
+	t.MAC = key.mac(msg, t, nil)
+	return appendTSIG(append([]byte(nil), msg...), t)
+}
+
+func mustParseUpdate(t *testing.T, msg []byte) *dnsUpdateMessage {
+	t.Helper()
+	m, err := parseUpdateMessage(msg)
+	if err != nil {
+		t.Fatalf("parseUpdateMessage: %v", err)
+	}
+	return m
+}
+
+func TestReadDNSName(t *testing.T) {
+	// "example.com" at 0, then names pointing into it
+	base := packDNSName("example.com")
+	tests := []struct {
+		name string
+		msg  []byte
+		off  int
+		want string
+		next int
+		err  string
+	}{
+		{name: "plain", msg: base, want: "example.com", next: 13},
+		{name: "root", msg: []byte{0}, want: "", next: 1},
+		{name: "lower cased", msg: packDNSName("WWW.Example.COM"), want: "www.example.com", next: 17},
+		{name: "escaped octets", msg: []byte{3, 'a', '.', ' ', 0}, want: `a\046\032`, next: 5},
+		{name: "compressed", msg: append(append(append([]byte(nil), base...), 3, 'w', 'w', 'w'), 0xc0, 0), off: 13, want: "www.example.com", next: 19},
+		{name: "pointer only", msg: append(append([]byte(nil), base...), 0xc0, 8), off: 13, want: "com", next: 15},
+		{name: "truncated label", msg: []byte{7, 'e', 'x', 'a'}, err: "truncated name"},
+		{name: "missing terminator", msg: []byte{3, 'c', 'o', 'm'}, err: "truncated name"},
+		{name: "truncated pointer", msg: []byte{3, 'w', 'w', 'w', 0xc0}, err: "truncated name"},
+		{name: "pointer past the end", msg: []byte{0xc0, 0x10}, err: "truncated name"},
+		{name: "pointer to itself", msg: []byte{0xc0, 0}, err: "compression loop"},
+		{name: "pointer cycle", msg: []byte{1, 'a', 0xc0, 4, 1, 'b', 0xc0, 0}, err: "compression loop"},
+		{name: "extended label type", msg: []byte{0x41, 0}, err: "unsupported label type"},
+		{name: "too long", msg: append(bytes.Repeat(append([]byte{63}, bytes.Repeat([]byte{'a'}, 63)...), 4), 0), err: "longer than 255"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, next, err := readDNSName(tt.msg, tt.off)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want || next != tt.next {
+				t.Errorf("got %q, %d, want %q, %d", got, next, tt.want, tt.next)
+			}
+		})
+	}
+}
+
+func TestParseUpdateMessage(t *testing.T) {
+	add := testRR("www.example.com", testTypeA, dnsClassIN, 300, []byte{192, 0, 2, 1})
+	valid := testUpdateMessage(7, "example.com", nil, [][]byte{add})
+
+	m := mustParseUpdate(t, valid)
+	if m.ID != 7 || m.Opcode != dnsOpcodeUpdate || len(m.Zones) != 1 || m.Zones[0].Name != "example.com" {
+		t.Errorf("parsed header and zone %+v", m)
+	}
+	if len(m.Updates) != 1 || m.Updates[0].Name != "www.example.com" || m.Updates[0].TTL != 300 {
+		t.Errorf("parsed updates %+v", m.Updates)
+	}
+
+	key, _ := newTSIGKey("key.example", "hmac-sha256", "c2VjcmV0")
+	signed := testSign(valid, key, 1000)
+	if m := mustParseUpdate(t, signed); m.TSIG == nil || m.TSIG.KeyName != "key.example" || m.TSIG.TimeSigned != 1000 || m.tsigOff != len(valid) {
+		t.Errorf("parsed TSIG %+v", m.TSIG)
+	}
+
+	// The query opcode is read from the header only
+	query := append([]byte(nil), valid...)
+	binary.BigEndian.PutUint16(query[2:], 0)
+	if m, err := parseUpdateMessage(query); err != nil || m.Opcode != 0 || m.Zones != nil {
+		t.Errorf("query parsed as %+v, %v", m, err)
+	}
+
+	// TSIG followed by another additional record
+	tsigFirst := append(append([]byte(nil), signed...), testRR("x.example.com", testTypeA, dnsClassIN, 0, []byte{1, 2, 3, 4})...)
+	binary.BigEndian.PutUint16(tsigFirst[10:], 2)
+
+	// TSIG in the update section
+	tsigUpdate := append([]byte(nil), signed...)
+	binary.BigEndian.PutUint16(tsigUpdate[8:], 2)
+	binary.BigEndian.PutUint16(tsigUpdate[10:], 0)
+
+	tests := []struct {
+		name string
+		msg  []byte
+		err  string
+	}{
+		{"short header", valid[:8], "shorter than a header"},
+		{"truncated zone section", valid[:dnsHeaderSize+13+2], "truncated zone section"},
+		{"truncated record", valid[:len(valid)-12], "truncated record"},
+		{"truncated rdata", valid[:len(valid)-1], "truncated record data"},
+		{"trailing data", append(append([]byte(nil), valid...), 0), "trailing data"},
+		{"TSIG not last", tsigFirst, "not the last record"},
+		{"TSIG in update section", tsigUpdate, "not the last record"},
+		{"truncated TSIG", signed[:len(signed)-2], "truncated record data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseUpdateMessage(tt.msg)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRdataString(t *testing.T) {
+	// The MX target is compressed against the zone name of the message
+	mxRdata := append([]byte{0, 10, 4, 'm', 'a', 'i', 'l'}, 0xc0, dnsHeaderSize)
+	tests := []struct {
+		name  string
+		rtype uint16
+		rdata []byte
+		want  string
+		rcode int
+	}{
+		{"A", testTypeA, []byte{192, 0, 2, 1}, "192.0.2.1", 0},
+		{"AAAA", testTypeAAAA, append([]byte{0x20, 0x01, 0x0d, 0xb8}, make([]byte, 12)...), "2001:db8::", 0},
+		{"CNAME", testTypeCNAME, packDNSName("Target.Example.NET"), "target.example.net.", 0},
+		{"compressed MX", testTypeMX, mxRdata, "10 mail.example.com.", 0},
+		{"TXT", testTypeTXT, []byte{5, 'h', 'e', '"', 'l', 'o', 2, 0x01, 'x'}, `"he\"lo" "\001x"`, 0},
+		{"CAA", testTypeCAA, append([]byte{0, 5}, "issueca.example"...), `0 issue "ca.example"`, 0},
+		{"short A", testTypeA, []byte{192, 0, 2}, "", dnsRcodeFormErr},
+		{"CNAME with trailing data", testTypeCNAME, append(packDNSName("a.example"), 1), "", dnsRcodeFormErr},
+		{"MX name past rdata", testTypeMX, []byte{0, 10, 4, 'm'}, "", dnsRcodeFormErr},
+		{"TXT string past rdata", testTypeTXT, []byte{9, 'x'}, "", dnsRcodeFormErr},
+		{"empty TXT", testTypeTXT, nil, "", dnsRcodeFormErr},
+		{"unsupported type", 44, []byte{1, 1, 0}, "", dnsRcodeNotImp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := testUpdateMessage(1, "example.com", nil, [][]byte{testRR("x.example.com", tt.rtype, dnsClassIN, 60, tt.rdata)})
+			m := mustParseUpdate(t, msg)
+			got, err := rdataString(m.raw, m.Updates[0])
+			if tt.rcode != 0 {
+				var uerr *updateError
+				if err == nil || errors.As(asUpdateError(err), &uerr) && uerr.rcode != tt.rcode {
+					t.Fatalf("error %v, want %s", err, dnsRcodeName(tt.rcode))
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTSIGKeyParsing(t *testing.T) {
+	tests := []struct {
+		value string
+		name  string
+		alg   string
+		err   bool
+	}{
+		{"Key.Example.:c2VjcmV0", "key.example", "hmac-sha256", false},
+		{"hmac-md5:key:c2VjcmV0", "key", "hmac-md5.sig-alg.reg.int", false},
+		{"HMAC-SHA512:key:c2VjcmV0", "key", "hmac-sha512", false},
+		{"hmac-foo:key:c2VjcmV0", "", "", true},
+		{"key:not base64!", "", "", true},
+		{":c2VjcmV0", "", "", true},
+		{"key", "", "", true},
+	}
+	for _, tt := range tests {
+		key, err := parseTSIGKeyFlag(tt.value)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: got %+v, want an error", tt.value, key)
+			}
+			continue
+		}
+		if err != nil || key.Name != tt.name || key.Algorithm != tt.alg {
+			t.Errorf("%s: got %+v, %v", tt.value, key, err)
+		}
+	}
+
+	file := writeTestFile(t, "keys.conf", `key "ddns-key" {
+	algorithm hmac-sha256;
+	secret "c2VjcmV0";
+};
+key other { algorithm hmac-sha1; secret "b3RoZXI="; };
+`)
+	keys, err := readTSIGKeyFile(file)
+	if err != nil || len(keys) != 2 || keys[0].Name != "ddns-key" || keys[1].Algorithm != "hmac-sha1" {
+		t.Errorf("readTSIGKeyFile returned %+v, %v", keys, err)
+	}
+}
+
+func TestTSIGVerify(t *testing.T) {
+	key, _ := newTSIGKey("key.example", "hmac-sha256", "c2VjcmV0")
+	other, _ := newTSIGKey("key.example", "hmac-sha256", "b3RoZXI=")
+	msg := testUpdateMessage(42, "example.com", nil, [][]byte{testRR("www.example.com", testTypeA, dnsClassIN, 60, []byte{192, 0, 2, 1})})
+
+	signed := testSign(msg, key, tsigNow())
+	if !key.verify(mustParseUpdate(t, signed)) {
+		t.Error("valid signature does not verify")
+	}
+	if other.verify(mustParseUpdate(t, signed)) {
+		t.Error("signature verifies with another secret")
+	}
+
+	// Any change to the signed data breaks the signature
+	tampered := append([]byte(nil), signed...)
+	tampered[len(msg)-1] ^= 1
+	if key.verify(mustParseUpdate(t, tampered)) {
+		t.Error("tampered message verifies")
+	}
+
+	// Forwarders may change the ID; the original ID is signed
+	forwarded := append([]byte(nil), signed...)
+	binary.BigEndian.PutUint16(forwarded, 99)
+	if !key.verify(mustParseUpdate(t, forwarded)) {
+		t.Error("message with a new ID does not verify")
+	}
+}
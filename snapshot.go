@@ -0,0 +1,258 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// Snapshot ids are UTC timestamps, which also sort chronologically
+const snapshotIDFormat = "20060102T150405Z"
+
+// ZoneSnapshot is a point-in-time copy of a zone configuration and its recordsets
+type ZoneSnapshot struct {
+	ID         string               `json:"id"`
+	Zone       string               `json:"zone"`
+	Created    string               `json:"created"`
+	Command    string               `json:"command,omitempty"`
+	Config     *dns.GetZoneResponse `json:"config"`
+	RecordSets []dns.RecordSet      `json:"recordsets,omitempty"`
+}
+
+// Snapshot directory from --snapshot-dir, defaulting to ~/.akamai-dns/snapshots
+func snapshotDir(c *cli.Context) (string, error) {
+	if c.String("snapshot-dir") != "" {
+		return filepath.FromSlash(c.String("snapshot-dir")), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine snapshot directory: %w", err)
+	}
+	return filepath.Join(home, ".akamai-dns", "snapshots"), nil
+}
+
+// Retrieve the zone configuration and all recordsets and write them to the snapshot directory
+func takeZoneSnapshot(ctx context.Context, dnsClient dns.DNS, dir, zonename, command string) (*ZoneSnapshot, string, error) {
+	zonename = strings.ToLower(strings.TrimSuffix(zonename, "."))
+	zone, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zonename})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to retrieve zone %s: %w", zonename, err)
+	}
+
+	now := time.Now().UTC()
+	snap := &ZoneSnapshot{
+		ID:      now.Format(snapshotIDFormat),
+		Zone:    zonename,
+		Created: now.Format(time.RFC3339),
+		Command: command,
+		Config:  zone,
+	}
+	if hasRecordSets(zone.Type) {
+		resp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+			Zone:      zonename,
+			QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to retrieve recordsets for %s: %w", zonename, err)
+		}
+		snap.RecordSets = resp.RecordSets
+	}
+
+	zoneDir := filepath.Join(dir, zonename)
+	if err := os.MkdirAll(zoneDir, 0700); err != nil {
+		return nil, "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	// Snapshots taken within the same second get a sequence suffix
+	path := filepath.Join(zoneDir, snap.ID+".json")
+	for i := 1; ; i++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			snap.ID = fmt.Sprintf("%s-%d", now.Format(snapshotIDFormat), i)
+			path = filepath.Join(zoneDir, snap.ID+".json")
+			if data, err = json.MarshalIndent(snap, "", "  "); err != nil {
+				return nil, "", fmt.Errorf("failed to marshal snapshot: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to write snapshot: %w", err)
+		}
+		_, err = f.Write(data)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to write snapshot: %w", err)
+		}
+		return snap, path, nil
+	}
+}
+
+// Snapshot each zone before a mutating command when --snapshot is set
+func snapshotBeforeChange(ctx context.Context, dnsClient dns.DNS, c *cli.Context, zones ...string) error {
+	if !c.Bool("snapshot") {
+		return nil
+	}
+	dir, err := snapshotDir(c)
+	if err != nil {
+		return wrapError(err)
+	}
+	for _, z := range zones {
+		snap, path, err := takeZoneSnapshot(ctx, dnsClient, dir, z, c.Command.FullName())
+		if err != nil {
+			return apiError(err, "Snapshot failed: %v", err)
+		}
+		fmt.Fprintln(os.Stderr, color.BlueString("Snapshot %s of %s written to %s", snap.ID, snap.Zone, path))
+	}
+	return nil
+}
+
+// List a zone's snapshots, oldest first
+func listZoneSnapshots(dir, zonename string) ([]*ZoneSnapshot, error) {
+	zoneDir := filepath.Join(dir, strings.ToLower(strings.TrimSuffix(zonename, ".")))
+	entries, err := os.ReadDir(zoneDir)
+	if os.IsNotExist(err) {
+		return []*ZoneSnapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	snaps := []*ZoneSnapshot{}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		snap, err := readZoneSnapshot(filepath.Join(zoneDir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, snap)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Created+snaps[i].ID < snaps[j].Created+snaps[j].ID })
+	return snaps, nil
+}
+
+// Load a snapshot by id, "latest", or a path to a snapshot file
+func loadZoneSnapshot(dir, zonename, id string) (*ZoneSnapshot, error) {
+	var (
+		snap *ZoneSnapshot
+		err  error
+	)
+	switch {
+	case strings.EqualFold(id, "latest"):
+		snaps, err := listZoneSnapshots(dir, zonename)
+		if err != nil {
+			return nil, err
+		}
+		if len(snaps) == 0 {
+			return nil, newCommandError(exitNotFound, "no snapshots of %s in %s", zonename, dir)
+		}
+		snap = snaps[len(snaps)-1]
+	case strings.ContainsRune(id, os.PathSeparator) || strings.HasSuffix(id, ".json"):
+		snap, err = readZoneSnapshot(filepath.FromSlash(id))
+	default:
+		snap, err = readZoneSnapshot(filepath.Join(dir, strings.ToLower(strings.TrimSuffix(zonename, ".")), id+".json"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(snap.Zone, strings.TrimSuffix(zonename, ".")) {
+		return nil, newCommandError(exitValidation, "snapshot %s is of zone %s, not %s", snap.ID, snap.Zone, zonename)
+	}
+	return snap, nil
+}
+
+func readZoneSnapshot(path string) (*ZoneSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	snap := &ZoneSnapshot{}
+	if err := json.Unmarshal(data, snap); err != nil || snap.Config == nil {
+		return nil, newCommandError(exitValidation, "invalid snapshot file %s", path)
+	}
+	return snap, nil
+}
+
+// Plan the changes that return a zone to a snapshot. The live SOA is kept, with
+// its serial incremented, so secondaries still pick up the restored content.
+func planZoneRestore(ctx context.Context, dnsClient dns.DNS, snap *ZoneSnapshot) (*ZoneApplyPlan, error) {
+	existing, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: snap.Zone})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve zone %s: %w", snap.Zone, err)
+	}
+
+	cfg := snap.Config
+	desired := &dns.ZoneCreate{
+		Zone:                  snap.Zone,
+		Type:                  strings.ToUpper(cfg.Type),
+		Masters:               cfg.Masters,
+		Comment:               cfg.Comment,
+		SignAndServe:          cfg.SignAndServe,
+		SignAndServeAlgorithm: strings.ToUpper(cfg.SignAndServeAlgorithm),
+		TSIGKey:               cfg.TSIGKey,
+		Target:                cfg.Target,
+		EndCustomerID:         cfg.EndCustomerID,
+		ContractID:            existing.ContractID,
+		OutboundZoneTransfer:  cfg.OutboundZoneTransfer,
+	}
+	zp := &ZoneApplyPlan{
+		Zone:          snap.Zone,
+		Action:        applyNoop,
+		VersionID:     existing.VersionID,
+		Config:        desired,
+		ConfigChanges: diffZoneConfig(existing, desired),
+	}
+
+	if hasRecordSets(desired.Type) && hasRecordSets(existing.Type) {
+		current, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+			Zone:      snap.Zone,
+			QueryArgs: &dns.RecordSetQueryArgs{ShowAll: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve recordsets for %s: %w", snap.Zone, err)
+		}
+		target := make([]dns.RecordSet, 0, len(snap.RecordSets))
+		for _, rs := range snap.RecordSets {
+			if rs.Type != "SOA" {
+				target = append(target, rs)
+			}
+		}
+		zp.RecordSets = desiredRecordSets(current.RecordSets, target)
+		zp.Changes = diffRecordSets(current.RecordSets, zp.RecordSets)
+	}
+
+	if len(zp.ConfigChanges) > 0 || len(zp.Changes) > 0 {
+		zp.Action = applyUpdate
+	}
+	return zp, nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+)
+
+// Terraform resource types of the Akamai provider
+const (
+	terraformZoneResource   = "akamai_dns_zone"
+	terraformRecordResource = "akamai_dns_record"
+)
+
+// Output format of the generated configuration
+const formatHCL = "hcl"
+
+// TerraformExportOptions selects what export-terraform writes
+type TerraformExportOptions struct {
+	Group   string
+	NoZone  bool
+	Imports bool
+}
+
+// hclAttribute is a name and an already rendered HCL value
+type hclAttribute struct {
+	Name  string
+	Value string
+}
+
+// terraformWriter renders resources, variables and import blocks in the
+// layout terraform fmt produces, with unique resource names
+type terraformWriter struct {
+	b       strings.Builder
+	imports strings.Builder
+	names   map[string]bool
+	opts    TerraformExportOptions
+}
+
+func newTerraformWriter(opts TerraformExportOptions) *terraformWriter {
+	return &terraformWriter{names: map[string]bool{}, opts: opts}
+}
+
+// HCL document with every resource followed by the import blocks
+func (w *terraformWriter) String() string {
+	out := w.b.String()
+	if w.imports.Len() > 0 {
+		out += w.imports.String()
+	}
+	return strings.TrimRight(out, "\n") + "\n"
+}
+
+// Add a zone and its recordsets. Zone is nil when only recordsets are known;
+// the records then name the zone directly.
+func (w *terraformWriter) addZone(zonename string, zone *dns.GetZoneResponse, recordsets []dns.RecordSet) {
+	zonename = normalizeDNSName(zonename)
+	fmt.Fprintf(&w.b, "# Zone %s\n\n", zonename)
+
+	zoneRef := hclString(zonename)
+	if zone != nil && !w.opts.NoZone {
+		name := w.resourceName(terraformZoneResource, zonename)
+		zoneRef = terraformZoneResource + "." + name + ".zone"
+
+		var tsigSecret string
+		attrs := []hclAttribute{
+			{"contract", hclString(zone.ContractID)},
+			{"group", hclString(w.opts.Group)},
+			{"zone", hclString(zonename)},
+			{"type", hclString(strings.ToLower(zone.Type))},
+		}
+		if len(zone.Masters) > 0 {
+			attrs = append(attrs, hclAttribute{"masters", hclStringList(zone.Masters)})
+		}
+		if zone.Target != "" {
+			attrs = append(attrs, hclAttribute{"target", hclString(zone.Target)})
+		}
+		if zone.Comment != "" {
+			attrs = append(attrs, hclAttribute{"comment", hclString(zone.Comment)})
+		}
+		attrs = append(attrs, hclAttribute{"sign_and_serve", strconv.FormatBool(zone.SignAndServe)})
+		if zone.SignAndServeAlgorithm != "" {
+			attrs = append(attrs, hclAttribute{"sign_and_serve_algorithm", hclString(zone.SignAndServeAlgorithm)})
+		}
+		if zone.EndCustomerID != "" {
+			attrs = append(attrs, hclAttribute{"end_customer_id", hclString(zone.EndCustomerID)})
+		}
+
+		// The TSIG secret goes in a sensitive variable rather than the configuration
+		if zone.TSIGKey != nil && zone.TSIGKey.Name != "" {
+			tsigSecret = name + "_tsig_secret"
+			fmt.Fprintf(&w.b, "variable %s {\n", hclString(tsigSecret))
+			writeHCLAttributes(&w.b, "  ", []hclAttribute{
+				{"type", "string"},
+				{"description", hclString("TSIG key secret of zone " + zonename)},
+				{"sensitive", "true"},
+			})
+			w.b.WriteString("}\n\n")
+		}
+
+		fmt.Fprintf(&w.b, "resource %s %s {\n", hclString(terraformZoneResource), hclString(name))
+		writeHCLAttributes(&w.b, "  ", attrs)
+		if tsigSecret != "" {
+			w.b.WriteString("\n  tsig_key {\n")
+			writeHCLAttributes(&w.b, "    ", []hclAttribute{
+				{"name", hclString(zone.TSIGKey.Name)},
+				{"algorithm", hclString(zone.TSIGKey.Algorithm)},
+				{"secret", "var." + tsigSecret},
+			})
+			w.b.WriteString("  }\n")
+		}
+		w.b.WriteString("}\n\n")
+		w.addImport(terraformZoneResource+"."+name, zonename)
+	}
+
+	sorted := append([]dns.RecordSet(nil), recordsets...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := normalizeDNSName(sorted[i].Name), normalizeDNSName(sorted[j].Name)
+		if a != b {
+			return a < b
+		}
+		return strings.ToUpper(sorted[i].Type) < strings.ToUpper(sorted[j].Type)
+	})
+	for _, rs := range sorted {
+		w.addRecordSet(zonename, zoneRef, rs)
+	}
+}
+
+func (w *terraformWriter) addRecordSet(zonename, zoneRef string, rs dns.RecordSet) {
+	rname := normalizeDNSName(rs.Name)
+	rtype := strings.ToUpper(rs.Type)
+	name := w.resourceName(terraformRecordResource, rname+"_"+rtype)
+
+	attrs := []hclAttribute{
+		{"zone", zoneRef},
+		{"name", hclString(rname)},
+		{"recordtype", hclString(rtype)},
+		{"ttl", strconv.Itoa(rs.TTL)},
+	}
+	if rtype == "SOA" && len(rs.Rdata) == 1 && len(strings.Fields(rs.Rdata[0])) == 7 {
+		// The provider takes the SOA fields separately; the serial is managed by Edge DNS
+		f := strings.Fields(rs.Rdata[0])
+		attrs = append(attrs,
+			hclAttribute{"name_server", hclString(f[0])},
+			hclAttribute{"email_address", hclString(f[1])},
+			hclAttribute{"refresh", f[3]},
+			hclAttribute{"retry", f[4]},
+			hclAttribute{"expiry", f[5]},
+			hclAttribute{"nxdomain_ttl", f[6]},
+		)
+	} else {
+		attrs = append(attrs, hclAttribute{"target", hclStringList(rs.Rdata)})
+	}
+
+	fmt.Fprintf(&w.b, "resource %s %s {\n", hclString(terraformRecordResource), hclString(name))
+	writeHCLAttributes(&w.b, "  ", attrs)
+	w.b.WriteString("}\n\n")
+	w.addImport(terraformRecordResource+"."+name, zonename+"#"+rname+"#"+rtype)
+}
+
+func (w *terraformWriter) addImport(to, id string) {
+	if !w.opts.Imports {
+		return
+	}
+	w.imports.WriteString("import {\n")
+	writeHCLAttributes(&w.imports, "  ", []hclAttribute{{"to", to}, {"id", hclString(id)}})
+	w.imports.WriteString("}\n\n")
+}
+
+// Terraform resource name for a DNS name: lower case, with anything other than
+// letters, digits, dashes and underscores replaced by underscores. Names taken
+// by an earlier resource of the type get a numeric suffix.
+func (w *terraformWriter) resourceName(resourceType, name string) string {
+	var b strings.Builder
+	for _, ch := range strings.ToLower(name) {
+		switch {
+		case ch == '*':
+			b.WriteString("wildcard")
+		case ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9', ch == '_', ch == '-':
+			b.WriteRune(ch)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	base := strings.Trim(b.String(), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') || base[0] == '-' {
+		base = "_" + base
+	}
+
+	unique := base
+	for i := 2; w.names[resourceType+"."+unique]; i++ {
+		unique = base + "_" + strconv.Itoa(i)
+	}
+	w.names[resourceType+"."+unique] = true
+	return unique
+}
+
+// Write attributes with their equals signs aligned, as terraform fmt does. A
+// multi-line value ends the group of aligned attributes.
+func writeHCLAttributes(b *strings.Builder, indent string, attrs []hclAttribute) {
+	for start := 0; start < len(attrs); {
+		end, width := start, 0
+		for end < len(attrs) {
+			width = max(width, len(attrs[end].Name))
+			end++
+			if strings.Contains(attrs[end-1].Value, "\n") {
+				break
+			}
+		}
+		for _, a := range attrs[start:end] {
+			fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, a.Name, a.Value)
+		}
+		start = end
+	}
+}
+
+// HCL string literal. Template sequences are escaped so values are taken literally.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch == '"' || ch == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		case ch == '\n':
+			b.WriteString(`\n`)
+		case ch == '\r':
+			b.WriteString(`\r`)
+		case ch == '\t':
+			b.WriteString(`\t`)
+		case (ch == '$' || ch == '%') && i+1 < len(s) && s[i+1] == '{':
+			b.WriteByte(ch)
+			b.WriteByte(ch)
+		case ch < ' ':
+			fmt.Fprintf(&b, `\u%04x`, ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// HCL list of strings, one per line when there are several
+func hclStringList(values []string) string {
+	switch len(values) {
+	case 0:
+		return "[]"
+	case 1:
+		return "[" + hclString(values[0]) + "]"
+	}
+	var b strings.Builder
+	b.WriteString("[\n")
+	for _, v := range values {
+		b.WriteString("    " + hclString(v) + ",\n")
+	}
+	b.WriteString("  ]")
+	return b.String()
+}
@@ -91,5 +91,21 @@ func setHelpTemplates() {
 			color.YellowString("Record Types: \n") +
 			"{{range .Subcommands}}   {{.Name}}\n{{end}}{{end}}"
 
-	cli.SubcommandHelpTemplate = cli.CommandHelpTemplate
+	cli.SubcommandHelpTemplate =
+		color.YellowString("Name: \n") +
+			"   {{.HelpName}}\n\n" +
+
+			`{{if .Description}}` +
+			color.YellowString("Description: \n") +
+			"   {{.Description}}\n\n" +
+			`{{end}}` +
+
+			color.YellowString("Usage: \n") +
+			color.BlueString("   {{.HelpName}} <command> {{if .ArgsUsage}}{{.ArgsUsage}} {{end}}[flags]\n\n") +
+
+			color.YellowString("Commands: \n") +
+			"{{range .VisibleCommands}}" +
+			color.GreenString("   {{.Name}}") +
+			"{{if .Description}}\t{{.Description}}{{end}}\n" +
+			"{{end}}"
 }
@@ -0,0 +1,241 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"github.com/akamai/cli-dns/edgegrid"
+	"github.com/urfave/cli"
+)
+
+const defaultZoneCacheTTL = 5 * time.Minute
+
+// ZoneInfo is the zone metadata kept between commands, so the ALIAS check most
+// commands make does not cost a GetZone call each time
+type ZoneInfo struct {
+	Zone       string    `json:"zone"`
+	Type       string    `json:"type"`
+	ContractID string    `json:"contractId,omitempty"`
+	VersionID  string    `json:"versionId,omitempty"`
+	Fetched    time.Time `json:"fetched"`
+
+	// Full zone when it was just retrieved, nil when read from the cache
+	zone *dns.GetZoneResponse
+}
+
+// Content of the cache file: zones by name, by account
+type zoneCacheFile struct {
+	Accounts map[string]map[string]*ZoneInfo `json:"accounts"`
+}
+
+// ZoneCache is the on-disk zone metadata cache of the account in use
+type ZoneCache struct {
+	mu      sync.Mutex
+	path    string
+	account string
+	ttl     time.Duration
+	enabled bool
+}
+
+// Zone cache of the command run, set up before any command runs
+var zoneCache = &ZoneCache{}
+
+// Path of the cache file, from AKAMAI_DNS_CACHE_DIR or the user cache directory
+func zoneCacheFilePath() string {
+	dir := os.Getenv("AKAMAI_DNS_CACHE_DIR")
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(base, "akamai-dns")
+	}
+	return filepath.Join(dir, "zones.json")
+}
+
+// Set up the zone cache for the account selected by the global flags. Lookups
+// are off with --no-cache and while recording or replaying, so cassettes hold
+// every request; invalidation still applies.
+func initZoneCache(c *cli.Context) error {
+	ttl := c.Duration("cache-ttl")
+	if ttl < 0 {
+		return newCommandError(exitValidation, "cache-ttl must not be negative")
+	}
+	account := strings.Join([]string{
+		edgegrid.GetEdgercPath(c),
+		edgegrid.GetEdgercSection(c),
+		c.GlobalString("accountkey"),
+		edgegrid.GetEndpoint(c),
+	}, "|")
+	zoneCache = &ZoneCache{
+		path:    zoneCacheFilePath(),
+		account: account,
+		ttl:     ttl,
+		enabled: !c.Bool("no-cache") && ttl > 0 && edgegrid.GetRecordDir(c) == "" && edgegrid.GetReplayDir(c) == "",
+	}
+	return nil
+}
+
+// Zone metadata from the cache, or from GetZone when it is missing or expired
+func getZoneInfo(ctx context.Context, dnsClient dns.DNS, zonename string) (*ZoneInfo, error) {
+	if info := zoneCache.get(zonename); info != nil {
+		return info, nil
+	}
+	return fetchZoneInfo(ctx, dnsClient, zonename)
+}
+
+// Zone metadata from GetZone, which also refreshes the cache
+func fetchZoneInfo(ctx context.Context, dnsClient dns.DNS, zonename string) (*ZoneInfo, error) {
+	zone, err := dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: zonename})
+	if err != nil {
+		return nil, err
+	}
+	info := &ZoneInfo{
+		Zone:       zonename,
+		Type:       zone.Type,
+		ContractID: zone.ContractID,
+		VersionID:  zone.VersionID,
+		Fetched:    time.Now().UTC(),
+		zone:       zone,
+	}
+	zoneCache.put(info)
+	return info, nil
+}
+
+// Full zone for the output formats that print the zone header, YAML and BIND.
+// The cached metadata is enough for the others, which get nil.
+func zoneDetails(ctx context.Context, c *cli.Context, dnsClient dns.DNS, info *ZoneInfo) (*dns.GetZoneResponse, error) {
+	if info.zone != nil {
+		return info.zone, nil
+	}
+	format, err := outputFormat(c, zoneExportOutput(&ZoneExport{}))
+	if err != nil || (format != formatYAML && format != formatBIND) {
+		return nil, nil
+	}
+	return dnsClient.GetZone(ctx, dns.GetZoneRequest{Zone: info.Zone})
+}
+
+func (zc *ZoneCache) get(zonename string) *ZoneInfo {
+	if !zc.enabled {
+		return nil
+	}
+	zc.mu.Lock()
+	defer zc.mu.Unlock()
+	f := zc.load()
+	info := f.Accounts[zc.account][zoneCacheKey(zonename)]
+	if info == nil || time.Since(info.Fetched) > zc.ttl {
+		return nil
+	}
+	return info
+}
+
+func (zc *ZoneCache) put(info *ZoneInfo) {
+	if !zc.enabled {
+		return
+	}
+	zc.update(func(zones map[string]*ZoneInfo) bool {
+		zones[zoneCacheKey(info.Zone)] = info
+		return true
+	})
+}
+
+// Drop zones the CLI has changed. Called after every successful or attempted
+// mutation, whether or not lookups are enabled.
+func (zc *ZoneCache) invalidate(zonenames ...string) {
+	zc.update(func(zones map[string]*ZoneInfo) bool {
+		changed := false
+		for _, z := range zonenames {
+			if _, ok := zones[zoneCacheKey(z)]; ok {
+				delete(zones, zoneCacheKey(z))
+				changed = true
+			}
+		}
+		return changed
+	})
+}
+
+// Change the account's zones and, when fn reports a change, save the file with
+// expired entries dropped. The file is read again first so parallel runs lose
+// as little as possible. Failures are ignored: the cache only saves calls.
+func (zc *ZoneCache) update(fn func(zones map[string]*ZoneInfo) bool) {
+	if zc.path == "" {
+		return
+	}
+	zc.mu.Lock()
+	defer zc.mu.Unlock()
+	f := zc.load()
+	zones := f.Accounts[zc.account]
+	if zones == nil {
+		zones = map[string]*ZoneInfo{}
+		f.Accounts[zc.account] = zones
+	}
+	if !fn(zones) {
+		return
+	}
+	for account, zones := range f.Accounts {
+		for name, info := range zones {
+			if time.Since(info.Fetched) > zc.maxAge() {
+				delete(zones, name)
+			}
+		}
+		if len(zones) == 0 {
+			delete(f.Accounts, account)
+		}
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(zc.path), 0700); err != nil {
+		return
+	}
+	writeFileAtomic(zc.path, append(data, '\n'), 0600)
+}
+
+// Age after which entries are removed from the file. Other runs may use a
+// longer --cache-ttl, so entries are kept for at least the default.
+func (zc *ZoneCache) maxAge() time.Duration {
+	if zc.ttl > defaultZoneCacheTTL {
+		return zc.ttl
+	}
+	return defaultZoneCacheTTL
+}
+
+// Read the cache file. A missing or unreadable file is an empty cache.
+func (zc *ZoneCache) load() *zoneCacheFile {
+	f := &zoneCacheFile{}
+	if data, err := os.ReadFile(zc.path); err == nil {
+		if err := json.Unmarshal(data, f); err != nil {
+			f = &zoneCacheFile{}
+		}
+	}
+	if f.Accounts == nil {
+		f.Accounts = map[string]map[string]*ZoneInfo{}
+	}
+	return f
+}
+
+func zoneCacheKey(zonename string) string {
+	return strings.TrimSuffix(strings.ToLower(zonename), ".")
+}
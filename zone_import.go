@@ -0,0 +1,598 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+	"gopkg.in/yaml.v3"
+)
+
+// Source formats accepted by import-zone --from
+const (
+	importRoute53    = "route53"
+	importCloudflare = "cloudflare"
+	importAzure      = "azure"
+	importGCloud     = "gcloud"
+	importOctoDNS    = "octodns"
+)
+
+// Record types Edge DNS can serve
+var edgeDNSRecordTypes = map[string]bool{
+	"A": true, "AAAA": true, "AFSDB": true, "AKAMAICDN": true, "AKAMAITLC": true, "CAA": true, "CERT": true,
+	"CNAME": true, "DNSKEY": true, "DS": true, "HINFO": true, "HTTPS": true, "LOC": true, "MX": true,
+	"NAPTR": true, "NS": true, "NSEC3": true, "NSEC3PARAM": true, "PTR": true, "RP": true, "RRSIG": true,
+	"SOA": true, "SPF": true, "SRV": true, "SSHFP": true, "SVCB": true, "TLSA": true, "TXT": true, "ZONEMD": true,
+}
+
+// ImportIssue is a source record that was left out or changed on conversion
+type ImportIssue struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Skipped bool   `json:"skipped"`
+	Message string `json:"message"`
+}
+
+// ZoneImport is the result of converting another provider's zone export
+type ZoneImport struct {
+	Zone       string          `json:"zone"`
+	Source     string          `json:"source"`
+	RecordSets []dns.RecordSet `json:"recordsets"`
+	Issues     []ImportIssue   `json:"issues"`
+}
+
+// Skipped counts the records left out
+func (imp *ZoneImport) Skipped() int {
+	n := 0
+	for _, issue := range imp.Issues {
+		if issue.Skipped {
+			n++
+		}
+	}
+	return n
+}
+
+// zoneImporter converts one provider's export format
+type zoneImporter func(b *zoneImportBuilder, data []byte, path string) error
+
+// Registered importers by --from name
+var zoneImporters = map[string]zoneImporter{
+	importRoute53:    importRoute53Records,
+	importCloudflare: importCloudflareRecords,
+	importAzure:      importAzureRecords,
+	importGCloud:     importGCloudRecords,
+	importOctoDNS:    importOctoDNSRecords,
+}
+
+func zoneImporterNames() []string {
+	names := make([]string, 0, len(zoneImporters))
+	for name := range zoneImporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Convert a provider export into recordsets for the zone
+func importZone(source string, data []byte, zonename, path string, defaultTTL int) (*ZoneImport, error) {
+	importer, ok := zoneImporters[source]
+	if !ok {
+		return nil, newCommandError(exitValidation, "--from must be one of %s", strings.Join(zoneImporterNames(), ", "))
+	}
+	b := &zoneImportBuilder{
+		zone:       normalizeDNSName(zonename),
+		defaultTTL: defaultTTL,
+		sets:       map[string]*dns.RecordSet{},
+	}
+	if err := importer(b, data, path); err != nil {
+		return nil, err
+	}
+	imp := &ZoneImport{Zone: b.zone, Source: source, RecordSets: []dns.RecordSet{}, Issues: b.issues}
+	for _, key := range b.order {
+		imp.RecordSets = append(imp.RecordSets, *b.sets[key])
+	}
+	if imp.Issues == nil {
+		imp.Issues = []ImportIssue{}
+	}
+	return imp, nil
+}
+
+// zoneImportBuilder collects the converted recordsets in source order, merging
+// records of the same name and type
+type zoneImportBuilder struct {
+	zone       string
+	defaultTTL int
+	sets       map[string]*dns.RecordSet
+	order      []string
+	issues     []ImportIssue
+}
+
+func (b *zoneImportBuilder) skip(name, rtype, format string, args ...interface{}) {
+	b.issues = append(b.issues, ImportIssue{
+		Name: normalizeDNSName(name), Type: strings.ToUpper(rtype), Skipped: true, Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (b *zoneImportBuilder) warn(name, rtype, format string, args ...interface{}) {
+	b.issues = append(b.issues, ImportIssue{
+		Name: normalizeDNSName(name), Type: strings.ToUpper(rtype), Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Fully qualified name for a name relative to the zone. "@" and "" are the apex.
+func (b *zoneImportBuilder) qualify(name string) string {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "" || name == "@":
+		return b.zone
+	case strings.HasSuffix(name, "."):
+		return normalizeDNSName(name)
+	}
+	return normalizeDNSName(name) + "." + b.zone
+}
+
+// Add records to the recordset of a name and type. The SOA and apex NS records
+// are dropped without an issue: Edge DNS creates its own with the zone.
+func (b *zoneImportBuilder) add(name, rtype string, ttl int, rdata ...string) {
+	name = normalizeDNSName(name)
+	rtype = strings.ToUpper(rtype)
+	switch {
+	case name != b.zone && !strings.HasSuffix(name, "."+b.zone):
+		b.skip(name, rtype, "name is outside zone %s", b.zone)
+		return
+	case rtype == "SOA", rtype == "NS" && name == b.zone:
+		return
+	case !edgeDNSRecordTypes[rtype]:
+		b.skip(name, rtype, "record type %s is not supported by Edge DNS", rtype)
+		return
+	case len(rdata) == 0:
+		b.skip(name, rtype, "record has no values")
+		return
+	}
+	if ttl <= 0 {
+		ttl = b.defaultTTL
+	}
+
+	key := recordSetKey(name, rtype)
+	rs, ok := b.sets[key]
+	if !ok {
+		rs = &dns.RecordSet{Name: name, Type: rtype, TTL: ttl}
+		b.sets[key] = rs
+		b.order = append(b.order, key)
+	} else if rs.TTL != ttl {
+		b.warn(name, rtype, "TTL %d differs from %d of the other records; using %d", ttl, rs.TTL, rs.TTL)
+	}
+	for _, v := range rdata {
+		v = qualifyRdataNames(rtype, strings.TrimSpace(v))
+		dup := false
+		for _, existing := range rs.Rdata {
+			dup = dup || existing == v
+		}
+		if !dup {
+			rs.Rdata = append(rs.Rdata, v)
+		}
+	}
+}
+
+// TXT rdata for an unquoted value, split into strings of at most 255 octets.
+// Values that are already quoted are kept.
+func quoteTXTValue(value string) string {
+	if strings.HasPrefix(strings.TrimSpace(value), `"`) {
+		return strings.TrimSpace(value)
+	}
+	return quoteTXTChunks(value)
+}
+
+// Route 53 list-resource-record-sets output
+type route53Export struct {
+	ResourceRecordSets []route53RecordSet `json:"ResourceRecordSets"`
+}
+
+type route53RecordSet struct {
+	Name            string `json:"Name"`
+	Type            string `json:"Type"`
+	TTL             int    `json:"TTL"`
+	ResourceRecords []struct {
+		Value string `json:"Value"`
+	} `json:"ResourceRecords"`
+	AliasTarget *struct {
+		DNSName string `json:"DNSName"`
+	} `json:"AliasTarget"`
+	SetIdentifier           string `json:"SetIdentifier"`
+	TrafficPolicyInstanceID string `json:"TrafficPolicyInstanceId"`
+}
+
+func importRoute53Records(b *zoneImportBuilder, data []byte, path string) error {
+	var export route53Export
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err := json.Unmarshal(data, &export.ResourceRecordSets)
+		if err != nil {
+			return newCommandError(exitValidation, "invalid Route 53 JSON in %s: %v", path, err)
+		}
+	} else if err := json.Unmarshal(data, &export); err != nil {
+		return newCommandError(exitValidation, "invalid Route 53 JSON in %s: %v", path, err)
+	}
+
+	for _, rrs := range export.ResourceRecordSets {
+		name := unescapeRoute53Name(rrs.Name)
+		switch {
+		case rrs.AliasTarget != nil:
+			b.skip(name, rrs.Type, "Route 53 alias to %s has no Edge DNS equivalent", strings.TrimSuffix(rrs.AliasTarget.DNSName, "."))
+			continue
+		case rrs.TrafficPolicyInstanceID != "":
+			b.skip(name, rrs.Type, "record is managed by Route 53 traffic policy %s", rrs.TrafficPolicyInstanceID)
+			continue
+		case rrs.SetIdentifier != "":
+			b.skip(name, rrs.Type, "Route 53 routing policy record %q has no Edge DNS equivalent", rrs.SetIdentifier)
+			continue
+		}
+		values := []string{}
+		for _, rr := range rrs.ResourceRecords {
+			values = append(values, rr.Value)
+		}
+		b.add(name, rrs.Type, rrs.TTL, values...)
+	}
+	return nil
+}
+
+// Route 53 writes characters such as the wildcard * as octal escapes, \052
+func unescapeRoute53Name(name string) string {
+	var out strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+3 < len(name) {
+			if v, err := strconv.ParseUint(name[i+1:i+4], 8, 8); err == nil {
+				out.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		out.WriteByte(name[i])
+	}
+	return out.String()
+}
+
+// Cloudflare's DNS export is a BIND master file. TTL 1 is Cloudflare's
+// automatic TTL, and proxied records are marked in a cf_tags comment.
+func importCloudflareRecords(b *zoneImportBuilder, data []byte, path string) error {
+	recordsets, err := parseMasterFile(data, b.zone, path)
+	if err != nil {
+		return newCommandError(exitValidation, "Invalid Cloudflare zone export: %v", err)
+	}
+
+	proxied := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if strings.Contains(line, "cf-proxied:true") && len(fields) > 0 {
+			proxied[b.qualify(fields[0])] = true
+		}
+	}
+
+	for _, rs := range recordsets {
+		name := normalizeDNSName(rs.Name)
+		if rs.TTL == 1 {
+			b.warn(name, rs.Type, "Cloudflare automatic TTL replaced by %d", b.defaultTTL)
+			rs.TTL = b.defaultTTL
+		}
+		if proxied[name] && (rs.Type == "A" || rs.Type == "AAAA" || rs.Type == "CNAME") {
+			b.warn(name, rs.Type, "record was proxied by Cloudflare; it now points at the origin directly")
+		}
+		b.add(name, rs.Type, rs.TTL, rs.Rdata...)
+	}
+	return nil
+}
+
+// Azure DNS record sets, as listed by az network dns record-set list or the
+// REST API. The CLI flattens the record properties; the API nests them.
+type azureRecordSet struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	azureRecords
+	Properties *azureRecords `json:"properties"`
+}
+
+type azureRecords struct {
+	TTL      int    `json:"ttl"`
+	FQDN     string `json:"fqdn"`
+	ARecords []struct {
+		IPv4Address string `json:"ipv4Address"`
+	} `json:"aRecords"`
+	AAAARecords []struct {
+		IPv6Address string `json:"ipv6Address"`
+	} `json:"aaaaRecords"`
+	CNAMERecord *struct {
+		CNAME string `json:"cname"`
+	} `json:"cnameRecord"`
+	MXRecords []struct {
+		Preference int    `json:"preference"`
+		Exchange   string `json:"exchange"`
+	} `json:"mxRecords"`
+	NSRecords []struct {
+		NSDName string `json:"nsdname"`
+	} `json:"nsRecords"`
+	PTRRecords []struct {
+		PTRDName string `json:"ptrdname"`
+	} `json:"ptrRecords"`
+	SRVRecords []struct {
+		Priority int    `json:"priority"`
+		Weight   int    `json:"weight"`
+		Port     int    `json:"port"`
+		Target   string `json:"target"`
+	} `json:"srvRecords"`
+	TXTRecords []struct {
+		Value []string `json:"value"`
+	} `json:"txtRecords"`
+	CAARecords []struct {
+		Flags int    `json:"flags"`
+		Tag   string `json:"tag"`
+		Value string `json:"value"`
+	} `json:"caaRecords"`
+	TargetResource *struct {
+		ID string `json:"id"`
+	} `json:"targetResource"`
+}
+
+func importAzureRecords(b *zoneImportBuilder, data []byte, path string) error {
+	var items []azureRecordSet
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		// REST API list response
+		var page struct {
+			Value []azureRecordSet `json:"value"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return newCommandError(exitValidation, "invalid Azure DNS JSON in %s: %v", path, err)
+		}
+		items = page.Value
+	} else if err := json.Unmarshal(data, &items); err != nil {
+		return newCommandError(exitValidation, "invalid Azure DNS JSON in %s: %v", path, err)
+	}
+
+	for _, item := range items {
+		rec := item.azureRecords
+		if item.Properties != nil {
+			rec = *item.Properties
+		}
+		rtype := strings.ToUpper(item.Type[strings.LastIndex(item.Type, "/")+1:])
+		name := b.qualify(item.Name)
+		if rec.FQDN != "" {
+			name = normalizeDNSName(rec.FQDN)
+		}
+		if rec.TargetResource != nil && rec.TargetResource.ID != "" {
+			b.skip(name, rtype, "Azure alias record to %s has no Edge DNS equivalent", rec.TargetResource.ID)
+			continue
+		}
+
+		values := []string{}
+		switch rtype {
+		case "A":
+			for _, r := range rec.ARecords {
+				values = append(values, r.IPv4Address)
+			}
+		case "AAAA":
+			for _, r := range rec.AAAARecords {
+				values = append(values, r.IPv6Address)
+			}
+		case "CNAME":
+			if rec.CNAMERecord != nil {
+				values = append(values, rec.CNAMERecord.CNAME)
+			}
+		case "MX":
+			for _, r := range rec.MXRecords {
+				values = append(values, fmt.Sprintf("%d %s", r.Preference, r.Exchange))
+			}
+		case "NS":
+			for _, r := range rec.NSRecords {
+				values = append(values, r.NSDName)
+			}
+		case "PTR":
+			for _, r := range rec.PTRRecords {
+				values = append(values, r.PTRDName)
+			}
+		case "SRV":
+			for _, r := range rec.SRVRecords {
+				values = append(values, fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Target))
+			}
+		case "TXT":
+			for _, r := range rec.TXTRecords {
+				strs := []string{}
+				for _, s := range r.Value {
+					strs = append(strs, quoteTXTValue(s))
+				}
+				values = append(values, strings.Join(strs, " "))
+			}
+		case "CAA":
+			for _, r := range rec.CAARecords {
+				values = append(values, fmt.Sprintf("%d %s %s", r.Flags, r.Tag, quoteTXTString([]byte(r.Value))))
+			}
+		case "SOA":
+			continue
+		default:
+			b.skip(name, rtype, "Azure %s record sets are not supported", rtype)
+			continue
+		}
+		b.add(name, rtype, rec.TTL, values...)
+	}
+	return nil
+}
+
+// Google Cloud DNS record sets, as written by gcloud dns record-sets list or
+// export in YAML or JSON
+type gcloudRecordSet struct {
+	Name          string      `yaml:"name"`
+	Type          string      `yaml:"type"`
+	TTL           int         `yaml:"ttl"`
+	RRDatas       []string    `yaml:"rrdatas"`
+	RoutingPolicy interface{} `yaml:"routingPolicy"`
+}
+
+func importGCloudRecords(b *zoneImportBuilder, data []byte, path string) error {
+	items := []gcloudRecordSet{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return newCommandError(exitValidation, "invalid Google Cloud DNS YAML in %s: %v", path, err)
+		}
+		// Each document is a record set, or a list of them with --format json
+		var doc []gcloudRecordSet
+		if len(node.Content) > 0 && node.Content[0].Kind == yaml.SequenceNode {
+			err = node.Decode(&doc)
+		} else {
+			var rs gcloudRecordSet
+			err = node.Decode(&rs)
+			doc = []gcloudRecordSet{rs}
+		}
+		if err != nil {
+			return newCommandError(exitValidation, "invalid Google Cloud DNS YAML in %s: %v", path, err)
+		}
+		items = append(items, doc...)
+	}
+
+	for _, rs := range items {
+		if rs.Name == "" {
+			continue
+		}
+		if rs.RoutingPolicy != nil {
+			b.skip(rs.Name, rs.Type, "Cloud DNS routing policy records have no Edge DNS equivalent")
+			continue
+		}
+		b.add(rs.Name, rs.Type, rs.TTL, rs.RRDatas...)
+	}
+	return nil
+}
+
+// Field order of the octoDNS value maps, by record type
+var octoDNSValueFields = map[string][]string{
+	"MX":    {"preference", "exchange"},
+	"SRV":   {"priority", "weight", "port", "target"},
+	"CAA":   {"flags", "tag", "value"},
+	"NAPTR": {"order", "preference", "flags", "service", "regexp", "replacement"},
+	"SSHFP": {"algorithm", "fingerprint_type", "fingerprint"},
+	"TLSA":  {"certificate_usage", "selector", "matching_type", "certificate_association_data"},
+}
+
+// octoDNS YAML zone config: records by name relative to the zone, each a
+// record or a list of records of different types
+func importOctoDNSRecords(b *zoneImportBuilder, data []byte, path string) error {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return newCommandError(exitValidation, "invalid octoDNS YAML in %s: %v", path, err)
+	}
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, rel := range names {
+		name := b.qualify(rel)
+		records := []interface{}{config[rel]}
+		if list, ok := config[rel].([]interface{}); ok {
+			records = list
+		}
+		for _, r := range records {
+			record, ok := r.(map[string]interface{})
+			if !ok {
+				b.skip(name, "", "record is not a map")
+				continue
+			}
+			b.addOctoDNSRecord(name, record)
+		}
+	}
+	return nil
+}
+
+func (b *zoneImportBuilder) addOctoDNSRecord(name string, record map[string]interface{}) {
+	rtype := strings.ToUpper(fmt.Sprint(record["type"]))
+	if rtype == "ALIAS" {
+		b.skip(name, rtype, "octoDNS ALIAS records have no Edge DNS equivalent")
+		return
+	}
+	if _, ok := record["dynamic"]; ok {
+		b.warn(name, rtype, "octoDNS dynamic rules dropped; the default values are used")
+	}
+	if _, ok := record["geo"]; ok {
+		b.warn(name, rtype, "octoDNS geo rules dropped; the default values are used")
+	}
+
+	raw := []interface{}{}
+	if values, ok := record["values"].([]interface{}); ok {
+		raw = values
+	} else if value, ok := record["value"]; ok {
+		raw = append(raw, value)
+	}
+	values := []string{}
+	for _, v := range raw {
+		value, err := octoDNSValue(rtype, v)
+		if err != nil {
+			b.skip(name, rtype, "%v", err)
+			return
+		}
+		values = append(values, value)
+	}
+	ttl := 0
+	if v, ok := record["ttl"].(int); ok {
+		ttl = v
+	}
+	b.add(name, rtype, ttl, values...)
+}
+
+// Rdata for an octoDNS value. TXT values escape semicolons as \;.
+func octoDNSValue(rtype string, v interface{}) (string, error) {
+	switch value := v.(type) {
+	case string:
+		if rtype == "TXT" || rtype == "SPF" {
+			return quoteTXTValue(strings.ReplaceAll(value, `\;`, ";")), nil
+		}
+		return value, nil
+	case map[string]interface{}:
+		fields, ok := octoDNSValueFields[rtype]
+		if !ok {
+			return "", fmt.Errorf("cannot convert octoDNS %s values", rtype)
+		}
+		parts := []string{}
+		for _, f := range fields {
+			fv, ok := value[f]
+			// Older configs use priority and value for MX records
+			if !ok && rtype == "MX" {
+				fv, ok = value[map[string]string{"preference": "priority", "exchange": "value"}[f]]
+			}
+			if !ok {
+				return "", fmt.Errorf("octoDNS %s value is missing %s", rtype, f)
+			}
+			s := fmt.Sprint(fv)
+			if (rtype == "CAA" && f == "value") || (rtype == "NAPTR" && f != "order" && f != "preference" && f != "replacement") {
+				s = quoteTXTString([]byte(s))
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, " "), nil
+	}
+	return fmt.Sprint(v), nil
+}
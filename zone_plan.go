@@ -0,0 +1,208 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+)
+
+// Recordset change actions reported by diffRecordSets
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// RecordsetChange describes the difference for a single name/type pair
+type RecordsetChange struct {
+	Name   string         `json:"name"`
+	Type   string         `json:"type"`
+	Action string         `json:"action"`
+	Before *dns.RecordSet `json:"before,omitempty"`
+	After  *dns.RecordSet `json:"after,omitempty"`
+}
+
+// ZonePlan is the set of changes an update would apply to a zone
+type ZonePlan struct {
+	Zone    string            `json:"zone"`
+	Changes []RecordsetChange `json:"changes"`
+}
+
+// Merge input recordsets into the existing list the same way update-zone does.
+// Matching name/type pairs are replaced, new ones appended and, unless the input
+// carries its own SOA, the existing SOA serial is incremented.
+func mergeRecordSets(existing, input []dns.RecordSet) []dns.RecordSet {
+	workList := make([]dns.RecordSet, len(existing))
+	for i, rs := range existing {
+		workList[i] = copyRecordSet(rs)
+	}
+
+	// Index by recordSetKey so names and types match the way diffRecordSets compares them
+	index := make(map[string]int, len(workList))
+	soaInSet := false
+	soaIndex := -1
+	for i, rs := range workList {
+		index[recordSetKey(rs.Name, rs.Type)] = i
+		if soaIndex < 0 && strings.EqualFold(rs.Type, "SOA") {
+			soaIndex = i
+		}
+	}
+
+	for _, updatedRS := range input {
+		key := recordSetKey(updatedRS.Name, updatedRS.Type)
+		if i, ok := index[key]; ok {
+			workList[i] = updatedRS
+		} else {
+			index[key] = len(workList)
+			workList = append(workList, updatedRS)
+		}
+		if strings.EqualFold(updatedRS.Type, "SOA") {
+			soaInSet = true
+		}
+	}
+
+	if !soaInSet && soaIndex >= 0 {
+		incrementSOASerial(&workList[soaIndex])
+	}
+
+	return workList
+}
+
+// Bump the serial field of an SOA recordset in place
+func incrementSOASerial(soaRec *dns.RecordSet) {
+	if len(soaRec.Rdata) == 0 {
+		return
+	}
+	soavals := strings.Fields(soaRec.Rdata[0])
+	if len(soavals) < 3 {
+		return
+	}
+	serial, err := strconv.Atoi(soavals[2])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to parse SOA serial: %v\n", err)
+		return
+	}
+	serial++
+	soavals[2] = strconv.Itoa(serial)
+	soaRec.Rdata[0] = strings.Join(soavals, " ")
+}
+
+func copyRecordSet(rs dns.RecordSet) dns.RecordSet {
+	rs.Rdata = append([]string(nil), rs.Rdata...)
+	return rs
+}
+
+func recordSetKey(name, rtype string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "|" + strings.ToUpper(rtype)
+}
+
+// Compare two recordset lists and return the per name/type changes sorted by name and type
+func diffRecordSets(before, after []dns.RecordSet) []RecordsetChange {
+	beforeMap := make(map[string]dns.RecordSet, len(before))
+	for _, rs := range before {
+		beforeMap[recordSetKey(rs.Name, rs.Type)] = rs
+	}
+	afterMap := make(map[string]dns.RecordSet, len(after))
+	for _, rs := range after {
+		afterMap[recordSetKey(rs.Name, rs.Type)] = rs
+	}
+
+	changes := make([]RecordsetChange, 0)
+	for key, b := range beforeMap {
+		a, ok := afterMap[key]
+		if !ok {
+			changes = append(changes, RecordsetChange{Name: b.Name, Type: b.Type, Action: changeRemoved, Before: &b})
+			continue
+		}
+		if !recordSetsEqual(b, a) {
+			changes = append(changes, RecordsetChange{Name: a.Name, Type: a.Type, Action: changeChanged, Before: &b, After: &a})
+		}
+	}
+	for key, a := range afterMap {
+		if _, ok := beforeMap[key]; !ok {
+			changes = append(changes, RecordsetChange{Name: a.Name, Type: a.Type, Action: changeAdded, After: &a})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Type < changes[j].Type
+	})
+	return changes
+}
+
+// Recordsets are equal when TTL and the unordered rdata values match
+func recordSetsEqual(a, b dns.RecordSet) bool {
+	if a.TTL != b.TTL || len(a.Rdata) != len(b.Rdata) {
+		return false
+	}
+	ar := append([]string(nil), a.Rdata...)
+	br := append([]string(nil), b.Rdata...)
+	sort.Strings(ar)
+	sort.Strings(br)
+	for i := range ar {
+		if ar[i] != br[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Count changes by action
+func summarizeChanges(changes []RecordsetChange) (added, removed, changed int) {
+	for _, ch := range changes {
+		switch ch.Action {
+		case changeAdded:
+			added++
+		case changeRemoved:
+			removed++
+		case changeChanged:
+			changed++
+		}
+	}
+	return
+}
+
+// Build the recordset work list for an update-zone style request and diff it against the live zone.
+// With overwrite the input replaces the zone content, otherwise it is merged into the existing recordsets.
+// The live recordsets are returned along with the work list.
+func planZoneUpdate(ctx context.Context, dnsClient dns.DNS, zonename string, input []dns.RecordSet, overwrite bool) (existing, workList []dns.RecordSet, changes []RecordsetChange, err error) {
+	existingResp, err := dnsClient.GetRecordSets(ctx, dns.GetRecordSetsRequest{
+		Zone: zonename,
+		QueryArgs: &dns.RecordSetQueryArgs{
+			ShowAll: true,
+		},
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if overwrite {
+		workList = input
+	} else {
+		workList = mergeRecordSets(existingResp.RecordSets, input)
+	}
+
+	return existingResp.RecordSets, workList, diffRecordSets(existingResp.RecordSets, workList), nil
+}
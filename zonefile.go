@@ -0,0 +1,492 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+)
+
+// Largest master zone file accepted by the Edge DNS API
+const masterFileMaxSize = 10 * 1024 * 1024
+
+// Nesting limit for $INCLUDE directives
+const masterFileMaxIncludeDepth = 10
+
+type zoneToken struct {
+	text   string
+	quoted bool
+}
+
+// One logical master file entry, possibly spanning several lines in parentheses
+type zoneEntry struct {
+	tokens     []zoneToken
+	blankOwner bool
+	line       int
+}
+
+type masterFileParser struct {
+	file       string
+	baseDir    string
+	origin     string
+	defaultTTL int
+	hasDefault bool
+	lastTTL    int
+	hasLast    bool
+	lastOwner  string
+	depth      int
+	sets       map[string]*dns.RecordSet
+	order      []string
+}
+
+// Parse RFC 1035 master file content into recordsets. origin is the zone name and
+// path, when set, is used to resolve $INCLUDE directives and in error messages.
+func parseMasterFile(data []byte, origin, path string) ([]dns.RecordSet, error) {
+	p := &masterFileParser{
+		file:   path,
+		origin: strings.ToLower(strings.TrimSuffix(origin, ".")),
+		sets:   map[string]*dns.RecordSet{},
+	}
+	if path == "" {
+		p.file = "<stdin>"
+		p.baseDir = "."
+	} else {
+		p.baseDir = filepath.Dir(path)
+	}
+
+	if err := p.parse(string(data)); err != nil {
+		return nil, err
+	}
+
+	recordsets := make([]dns.RecordSet, 0, len(p.order))
+	for _, key := range p.order {
+		recordsets = append(recordsets, *p.sets[key])
+	}
+	return recordsets, nil
+}
+
+// Master file content for recordsets, as uploaded to the API. Owner names and
+// rdata are written as parsed, so nothing is left for the API to resolve.
+func renderMasterFile(zonename string, recordsets []dns.RecordSet) (string, error) {
+	data, err := exportBIND(&ZoneExport{Name: zonename, RecordSets: recordsets})
+	if err != nil {
+		return "", err
+	}
+	return data + "\n", nil
+}
+
+// Read a master file, rejecting it before reading when it exceeds the API size limit
+func readMasterFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > masterFileMaxSize {
+		return nil, fmt.Errorf("Master Zone File size too large to process")
+	}
+	return os.ReadFile(path)
+}
+
+func (p *masterFileParser) errorf(line int, format string, args ...interface{}) error {
+	return fmt.Errorf("%s:%d: %s", p.file, line, fmt.Sprintf(format, args...))
+}
+
+func (p *masterFileParser) parse(data string) error {
+	entries, err := tokenizeMasterFile(data)
+	if err != nil {
+		return fmt.Errorf("%s:%w", p.file, err)
+	}
+
+	for _, e := range entries {
+		if !e.blankOwner && !e.tokens[0].quoted && strings.HasPrefix(e.tokens[0].text, "$") {
+			if err := p.directive(e); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := p.record(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handle $ORIGIN, $TTL and $INCLUDE
+func (p *masterFileParser) directive(e zoneEntry) error {
+	args := e.tokens[1:]
+	switch strings.ToUpper(e.tokens[0].text) {
+	case "$ORIGIN":
+		if len(args) != 1 {
+			return p.errorf(e.line, "$ORIGIN requires one domain name")
+		}
+		p.origin = strings.TrimSuffix(p.qualifyOwner(args[0].text), ".")
+	case "$TTL":
+		if len(args) != 1 {
+			return p.errorf(e.line, "$TTL requires one value")
+		}
+		ttl, ok := parseZoneTTL(args[0].text)
+		if !ok {
+			return p.errorf(e.line, "invalid $TTL value %q", args[0].text)
+		}
+		p.defaultTTL, p.hasDefault = ttl, true
+	case "$INCLUDE":
+		if len(args) < 1 || len(args) > 2 {
+			return p.errorf(e.line, "$INCLUDE requires a file name and an optional origin")
+		}
+		if p.depth >= masterFileMaxIncludeDepth {
+			return p.errorf(e.line, "$INCLUDE nested too deeply")
+		}
+		incPath := args[0].text
+		if !filepath.IsAbs(incPath) {
+			incPath = filepath.Join(p.baseDir, incPath)
+		}
+		data, err := readMasterFile(incPath)
+		if err != nil {
+			return p.errorf(e.line, "$INCLUDE failed: %v", err)
+		}
+
+		// The included file starts with its own origin and does not change ours
+		savedFile, savedOrigin, savedOwner := p.file, p.origin, p.lastOwner
+		if len(args) == 2 {
+			p.origin = strings.TrimSuffix(p.qualifyOwner(args[1].text), ".")
+		}
+		p.file = incPath
+		p.depth++
+		err = p.parse(string(data))
+		p.depth--
+		p.file, p.origin, p.lastOwner = savedFile, savedOrigin, savedOwner
+		if err != nil {
+			return err
+		}
+	default:
+		return p.errorf(e.line, "unsupported directive %s", e.tokens[0].text)
+	}
+	return nil
+}
+
+// Handle a resource record entry: [owner] [ttl] [class] type rdata
+func (p *masterFileParser) record(e zoneEntry) error {
+	toks := e.tokens
+	owner := p.lastOwner
+	if !e.blankOwner {
+		owner = strings.TrimSuffix(p.qualifyOwner(toks[0].text), ".")
+		toks = toks[1:]
+	}
+	if owner == "" {
+		return p.errorf(e.line, "record has no owner name")
+	}
+	p.lastOwner = owner
+
+	ttl := -1
+	for len(toks) > 0 && !toks[0].quoted {
+		t := strings.ToUpper(toks[0].text)
+		if t == "IN" {
+			toks = toks[1:]
+			continue
+		}
+		if t == "CH" || t == "HS" || t == "CS" {
+			return p.errorf(e.line, "unsupported class %s", t)
+		}
+		if v, ok := parseZoneTTL(t); ok && ttl < 0 {
+			ttl = v
+			toks = toks[1:]
+			continue
+		}
+		break
+	}
+	if len(toks) == 0 {
+		return p.errorf(e.line, "record type is missing")
+	}
+
+	switch {
+	case ttl >= 0:
+		p.lastTTL, p.hasLast = ttl, true
+	case p.hasDefault:
+		ttl = p.defaultTTL
+	case p.hasLast:
+		ttl = p.lastTTL
+	default:
+		return p.errorf(e.line, "no TTL specified and no $TTL default")
+	}
+
+	rtype := strings.ToUpper(toks[0].text)
+	if !isZoneTypeName(rtype) {
+		return p.errorf(e.line, "invalid record type %q", toks[0].text)
+	}
+	rdata, err := p.formatRdata(rtype, toks[1:])
+	if err != nil {
+		return p.errorf(e.line, "%s %s: %v", owner, rtype, err)
+	}
+
+	key := recordSetKey(owner, rtype)
+	rs, ok := p.sets[key]
+	if !ok {
+		rs = &dns.RecordSet{Name: owner, Type: rtype, TTL: ttl}
+		p.sets[key] = rs
+		p.order = append(p.order, key)
+	} else if rs.TTL != ttl {
+		fmt.Fprintf(os.Stderr, "Warning: %s:%d: TTL %d differs from %d for %s %s; using %d\n", p.file, e.line, ttl, rs.TTL, owner, rtype, rs.TTL)
+	}
+	for _, existing := range rs.Rdata {
+		if existing == rdata {
+			return nil
+		}
+	}
+	rs.Rdata = append(rs.Rdata, rdata)
+	return nil
+}
+
+// Qualify an owner name with the current origin. The result has a trailing dot.
+func (p *masterFileParser) qualifyOwner(name string) string {
+	if name == "@" {
+		return p.origin + "."
+	}
+	if strings.HasSuffix(name, ".") && !strings.HasSuffix(name, "\\.") {
+		return strings.ToLower(name)
+	}
+	if p.origin == "" {
+		return strings.ToLower(name) + "."
+	}
+	return strings.ToLower(name) + "." + p.origin + "."
+}
+
+// Build the API presentation format rdata for a single record
+func (p *masterFileParser) formatRdata(rtype string, toks []zoneToken) (string, error) {
+	if len(toks) == 0 {
+		return "", fmt.Errorf("rdata is missing")
+	}
+
+	if rtype == "TXT" || rtype == "SPF" {
+		parts := make([]string, len(toks))
+		for i, t := range toks {
+			if t.quoted {
+				parts[i] = `"` + t.text + `"`
+			} else {
+				parts[i] = `"` + strings.ReplaceAll(t.text, `"`, `\"`) + `"`
+			}
+		}
+		return strings.Join(parts, " "), nil
+	}
+
+	fields := make([]string, len(toks))
+	for i, t := range toks {
+		if t.quoted {
+			fields[i] = `"` + t.text + `"`
+		} else {
+			fields[i] = t.text
+		}
+	}
+
+	// Generic RFC 3597 rdata is passed through untouched
+	if fields[0] == `\#` {
+		return strings.Join(fields, " "), nil
+	}
+
+	for _, idx := range rdataNameFields[rtype] {
+		if idx >= len(fields) {
+			return "", fmt.Errorf("rdata has too few fields")
+		}
+		if fields[idx] != "." {
+			fields[idx] = p.qualifyOwner(fields[idx])
+		}
+	}
+
+	if rtype == "SOA" {
+		if len(fields) != 7 {
+			return "", fmt.Errorf("SOA rdata requires 7 fields, found %d", len(fields))
+		}
+		for i := 2; i < 7; i++ {
+			v, ok := parseZoneTTL(fields[i])
+			if !ok {
+				return "", fmt.Errorf("invalid SOA value %q", fields[i])
+			}
+			fields[i] = strconv.Itoa(v)
+		}
+	}
+
+	return strings.Join(fields, " "), nil
+}
+
+// Parse a TTL in seconds or BIND unit notation such as 1h30m or 1w
+func parseZoneTTL(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if v, err := strconv.ParseUint(s, 10, 31); err == nil {
+		return int(v), true
+	}
+
+	total, num, digits := 0, 0, 0
+	for _, r := range strings.ToLower(s) {
+		if r >= '0' && r <= '9' {
+			num = num*10 + int(r-'0')
+			digits++
+			continue
+		}
+		if digits == 0 {
+			return 0, false
+		}
+		switch r {
+		case 's':
+		case 'm':
+			num *= 60
+		case 'h':
+			num *= 3600
+		case 'd':
+			num *= 86400
+		case 'w':
+			num *= 604800
+		default:
+			return 0, false
+		}
+		total += num
+		num, digits = 0, 0
+	}
+	if digits > 0 {
+		return 0, false
+	}
+	return total, true
+}
+
+func isZoneTypeName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsUpper(r) && !unicode.IsDigit(r) && r != '-' {
+			return false
+		}
+	}
+	return unicode.IsUpper(rune(s[0]))
+}
+
+// Split master file content into logical entries. Handles comments, quoted strings
+// with escapes and parentheses that continue an entry across lines.
+func tokenizeMasterFile(data string) ([]zoneEntry, error) {
+	var (
+		entries    []zoneEntry
+		cur        zoneEntry
+		tok        strings.Builder
+		inTok      bool
+		quoted     bool
+		inQuote    bool
+		depth      int
+		line       = 1
+		entryStart = true
+	)
+
+	flushTok := func() {
+		if !inTok {
+			return
+		}
+		if len(cur.tokens) == 0 {
+			cur.line = line
+		}
+		cur.tokens = append(cur.tokens, zoneToken{text: tok.String(), quoted: quoted})
+		tok.Reset()
+		inTok, quoted = false, false
+	}
+	flushEntry := func() {
+		if len(cur.tokens) > 0 {
+			entries = append(entries, cur)
+		}
+		cur = zoneEntry{}
+		entryStart = true
+	}
+
+	for i := 0; i < len(data); i++ {
+		ch := data[i]
+		if entryStart {
+			cur.blankOwner = ch == ' ' || ch == '\t'
+			entryStart = false
+		}
+
+		if inQuote {
+			switch ch {
+			case '\\':
+				if i+1 < len(data) {
+					tok.WriteByte(ch)
+					tok.WriteByte(data[i+1])
+					if data[i+1] == '\n' {
+						line++
+					}
+					i++
+				}
+			case '"':
+				inQuote = false
+				flushTok()
+			case '\n':
+				return nil, fmt.Errorf("%d: unterminated quoted string", line)
+			default:
+				tok.WriteByte(ch)
+			}
+			continue
+		}
+
+		switch ch {
+		case '\\':
+			inTok = true
+			tok.WriteByte(ch)
+			if i+1 < len(data) {
+				tok.WriteByte(data[i+1])
+				i++
+			}
+		case '"':
+			flushTok()
+			inQuote, inTok, quoted = true, true, true
+		case ';':
+			flushTok()
+			for i+1 < len(data) && data[i+1] != '\n' {
+				i++
+			}
+		case '(':
+			flushTok()
+			depth++
+		case ')':
+			flushTok()
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("%d: unbalanced closing parenthesis", line)
+			}
+		case '\n':
+			flushTok()
+			if depth == 0 {
+				flushEntry()
+			}
+			line++
+		case ' ', '\t', '\r':
+			flushTok()
+		default:
+			inTok = true
+			tok.WriteByte(ch)
+		}
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("%d: unterminated quoted string", line)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("%d: unbalanced opening parenthesis", line)
+	}
+	flushTok()
+	flushEntry()
+	return entries, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2026. Akamai Technologies, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v11/pkg/dns"
+)
+
+func TestParseMasterFile(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []dns.RecordSet
+		err  string
+	}{
+		{
+			name: "origin qualifies relative names",
+			data: "$TTL 300\nwww A 192.0.2.1\n$ORIGIN sub\nhost CNAME www\n@ MX 10 mail.example.net.\n",
+			want: []dns.RecordSet{
+				{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}},
+				{Name: "host.sub.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www.sub.example.com."}},
+				{Name: "sub.example.com", Type: "MX", TTL: 300, Rdata: []string{"10 mail.example.net."}},
+			},
+		},
+		{
+			name: "absolute origin",
+			data: "$ORIGIN other.org.\n$TTL 60\n@ NS ns1\n",
+			want: []dns.RecordSet{
+				{Name: "other.org", Type: "NS", TTL: 60, Rdata: []string{"ns1.other.org."}},
+			},
+		},
+		{
+			name: "parentheses span lines",
+			data: "@ 3600 IN SOA ns1 hostmaster (\n  2024010101 ; serial\n  1h 15m\n  1w 5m )\n",
+			want: []dns.RecordSet{
+				{Name: "example.com", Type: "SOA", TTL: 3600, Rdata: []string{"ns1.example.com. hostmaster.example.com. 2024010101 3600 900 604800 300"}},
+			},
+		},
+		{
+			name: "quoted TXT strings",
+			data: "$TTL 300\ntxt TXT ( \"one\"\n \"two \\\" three\" )\n",
+			want: []dns.RecordSet{
+				{Name: "txt.example.com", Type: "TXT", TTL: 300, Rdata: []string{`"one" "two \" three"`}},
+			},
+		},
+		{
+			name: "blank owner and last TTL",
+			data: "www 120 A 192.0.2.1\n     A 192.0.2.2\nftp A 192.0.2.3\n",
+			want: []dns.RecordSet{
+				{Name: "www.example.com", Type: "A", TTL: 120, Rdata: []string{"192.0.2.1", "192.0.2.2"}},
+				{Name: "ftp.example.com", Type: "A", TTL: 120, Rdata: []string{"192.0.2.3"}},
+			},
+		},
+		{
+			name: "$TTL overrides the last TTL",
+			data: "www 120 A 192.0.2.1\n$TTL 1h\nftp A 192.0.2.3\n",
+			want: []dns.RecordSet{
+				{Name: "www.example.com", Type: "A", TTL: 120, Rdata: []string{"192.0.2.1"}},
+				{Name: "ftp.example.com", Type: "A", TTL: 3600, Rdata: []string{"192.0.2.3"}},
+			},
+		},
+		{
+			name: "explicit $TTL 0",
+			data: "www 120 A 192.0.2.1\n$TTL 0\nftp A 192.0.2.3\n",
+			want: []dns.RecordSet{
+				{Name: "www.example.com", Type: "A", TTL: 120, Rdata: []string{"192.0.2.1"}},
+				{Name: "ftp.example.com", Type: "A", TTL: 0, Rdata: []string{"192.0.2.3"}},
+			},
+		},
+		{
+			name: "explicit record TTL 0 is inherited",
+			data: "www 0 A 192.0.2.1\nftp A 192.0.2.3\n",
+			want: []dns.RecordSet{
+				{Name: "www.example.com", Type: "A", TTL: 0, Rdata: []string{"192.0.2.1"}},
+				{Name: "ftp.example.com", Type: "A", TTL: 0, Rdata: []string{"192.0.2.3"}},
+			},
+		},
+		{name: "no TTL", data: "www A 192.0.2.1\n", err: "no TTL specified"},
+		{name: "unbalanced parenthesis", data: "$TTL 60\n@ SOA ns1 hostmaster ( 1 2 3 4 5\n", err: "unbalanced opening parenthesis"},
+		{name: "unterminated string", data: "$TTL 60\ntxt TXT \"open\n", err: "unterminated quoted string"},
+		{name: "bad directive", data: "$GENERATE 1-10 host$ A 192.0.2.$\n", err: "unsupported directive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMasterFile([]byte(tt.data), "example.com", "")
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMasterFileInclude(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// The included origin applies to the included file only
+	write("hosts.zone", "www A 192.0.2.1\n")
+	main := write("main.zone", "$TTL 300\n$INCLUDE hosts.zone lab\nftp A 192.0.2.2\n")
+	got, err := parseMasterFile(readTestData(t, main), "example.com", main)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []dns.RecordSet{
+		{Name: "www.lab.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}},
+		{Name: "ftp.example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+
+	// Nesting is allowed up to the depth limit
+	for i := 0; i < masterFileMaxIncludeDepth; i++ {
+		write(fmt.Sprintf("nest%d.zone", i), fmt.Sprintf("$INCLUDE nest%d.zone\n", i+1))
+	}
+	write(fmt.Sprintf("nest%d.zone", masterFileMaxIncludeDepth), "leaf 60 A 192.0.2.3\n")
+	nest := filepath.Join(dir, "nest0.zone")
+	if _, err := parseMasterFile(readTestData(t, nest), "example.com", nest); err != nil {
+		t.Errorf("include depth %d: %v", masterFileMaxIncludeDepth, err)
+	}
+
+	// A file that includes itself hits the limit
+	loop := write("loop.zone", "$INCLUDE loop.zone\n")
+	if _, err := parseMasterFile(readTestData(t, loop), "example.com", loop); err == nil || !strings.Contains(err.Error(), "nested too deeply") {
+		t.Errorf("include loop error %v", err)
+	}
+
+	missing := write("missing.zone", "$INCLUDE nowhere.zone\n")
+	if _, err := parseMasterFile(readTestData(t, missing), "example.com", missing); err == nil || !strings.Contains(err.Error(), "$INCLUDE failed") {
+		t.Errorf("missing include error %v", err)
+	}
+}
+
+func TestRenderMasterFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hosts.zone"), []byte("www A 192.0.2.1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "main.zone")
+	data := "$TTL 300\n$INCLUDE hosts.zone\n$ORIGIN lab\nhost CNAME www\n"
+	parsed, err := parseMasterFile([]byte(data), "example.com", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The rendered file has no directives left to resolve and reads back the same
+	rendered, err := renderMasterFile("example.com", parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(rendered, "$INCLUDE") {
+		t.Errorf("rendered file still has $INCLUDE:\n%s", rendered)
+	}
+	reparsed, err := parseMasterFile([]byte(rendered), "example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reparsed, parsed) {
+		t.Errorf("rendered file parses to %+v\nwant %+v", reparsed, parsed)
+	}
+}
+
+func readTestData(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}